@@ -0,0 +1,192 @@
+// Package cli implements the commands godcr runs without a display when it is
+// started with --nogui.
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	flags "github.com/jessevdk/go-flags"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// ErrNoCommand is returned by Run if no command was specified.
+var ErrNoCommand = errors.New("no command specified, run with --nogui -h to list the commands")
+
+// Commands holds the commands of the command line mode. It is embedded in the
+// app config so that they are parsed with the rest of the options.
+type Commands struct {
+	Wallets   walletsCommand   `command:"wallets" description:"Manage the wallets of the app"`
+	Balance   balanceCommand   `command:"balance" description:"Show the balance of the accounts of a wallet"`
+	Receive   receiveCommand   `command:"receive" description:"Show an address to receive funds to"`
+	Send      sendCommand      `command:"send" description:"Send funds to an address"`
+	Sync      syncCommand      `command:"sync" description:"Synchronize the wallets with the Decred network"`
+	Tickets   ticketsCommand   `command:"tickets" description:"Manage tickets"`
+	Mixer     mixerCommand     `command:"mixer" description:"Control the account mixer"`
+	Proposals proposalsCommand `command:"proposals" description:"Browse Politeia proposals"`
+	ExportTx  exportTxCommand  `command:"export-tx" description:"Export the transaction history"`
+}
+
+// command is implemented by every command that can be run.
+type command interface {
+	flags.Commander
+	run(c *client, args []string) error
+}
+
+// commandBase implements flags.Commander for the commands. The wallets are
+// not loaded when the command line is parsed, so commands are run by Run
+// instead of by the parser.
+type commandBase struct{}
+
+func (commandBase) Execute([]string) error {
+	return errors.New("commands must be run with cli.Run")
+}
+
+// client holds what a command needs to run.
+type client struct {
+	ctx  context.Context
+	wal  *wallet.Wallet
+	mw   *dcrlibwallet.MultiWallet
+	json bool
+
+	stdout io.Writer
+	stderr io.Writer
+	stdin  *os.File
+	// lines reads stdin when it is not a terminal. A single reader is used
+	// for every prompt as it may buffer the lines that follow the one read.
+	lines *bufio.Reader
+}
+
+// Run opens the wallets of wal and runs cmd, the command selected on the
+// command line. Its output is printed as JSON if asJSON is true. Commands
+// that run until they are stopped return when ctx is canceled.
+func Run(ctx context.Context, wal *wallet.Wallet, cmd flags.Commander, args []string, asJSON bool) error {
+	if cmd == nil {
+		return ErrNoCommand
+	}
+	runner, ok := cmd.(command)
+	if !ok {
+		return fmt.Errorf("%T cannot be run", cmd)
+	}
+
+	c := &client{
+		ctx:    ctx,
+		wal:    wal,
+		mw:     wal.GetMultiWallet(),
+		json:   asJSON,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		stdin:  os.Stdin,
+		lines:  bufio.NewReader(os.Stdin),
+	}
+	if err := c.openWallets(); err != nil {
+		return err
+	}
+
+	return runner.run(c, args)
+}
+
+// Serve opens the wallets of wal and keeps them synced until ctx is canceled.
+// It is used to run the RPC server without a display.
+func Serve(ctx context.Context, wal *wallet.Wallet) error {
+	c := &client{
+		ctx:    ctx,
+		wal:    wal,
+		mw:     wal.GetMultiWallet(),
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	if err := c.openWallets(); err != nil {
+		return err
+	}
+	if err := c.sync(); err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	c.mw.CancelSync()
+	return nil
+}
+
+// openWallets opens the wallets, asking for the startup passphrase if one is
+// set.
+func (c *client) openWallets() error {
+	var pass []byte
+	if c.mw.IsStartupSecuritySet() {
+		passphrase, err := c.readPassphrase("Startup passphrase: ")
+		if err != nil {
+			return err
+		}
+		pass = []byte(passphrase)
+	}
+
+	if err := c.mw.OpenWallets(pass); err != nil {
+		if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+			return errors.New("invalid startup passphrase")
+		}
+		return err
+	}
+	return nil
+}
+
+// walletOption selects the wallet a command runs against.
+type walletOption struct {
+	Wallet string `long:"wallet" description:"ID or name of the wallet, required if there is more than one wallet"`
+}
+
+// selectWallet returns the wallet with the ID or name selector. The only
+// wallet is returned if selector is empty.
+func (c *client) selectWallet(selector string) (*dcrlibwallet.Wallet, error) {
+	wallets := c.mw.AllWallets()
+	if selector == "" {
+		switch len(wallets) {
+		case 0:
+			return nil, errors.New("no wallet has been created, create one in the app first")
+		case 1:
+			return wallets[0], nil
+		default:
+			return nil, errors.New("there is more than one wallet, select one with --wallet")
+		}
+	}
+
+	if id, err := strconv.Atoi(selector); err == nil {
+		if w := c.mw.WalletWithID(id); w != nil {
+			return w, nil
+		}
+	}
+	for _, w := range wallets {
+		if strings.EqualFold(w.Name, selector) {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("wallet %q not found", selector)
+}
+
+// print prints result as JSON if --json was set, or calls text to print it
+// for people otherwise.
+func (c *client) print(result interface{}, text func(w io.Writer)) error {
+	if c.json {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	tw := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	text(tw)
+	return tw.Flush()
+}
+
+// progress prints a status message. Status messages are written to stderr so
+// that they do not mix with the JSON output.
+func (c *client) progress(format string, args ...interface{}) {
+	fmt.Fprintf(c.stderr, format+"\n", args...)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPrint(t *testing.T) {
+	result := []addressResult{{Wallet: "default", Account: 0, Address: "TsAddr"}}
+	text := func(w io.Writer) {
+		fmt.Fprintln(w, "ADDRESS\tWALLET")
+		fmt.Fprintf(w, "%s\t%s\n", result[0].Address, result[0].Wallet)
+	}
+
+	var out bytes.Buffer
+	c := &client{stdout: &out}
+	if err := c.print(result, text); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "ADDRESS  WALLET\nTsAddr   default\n"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+
+	out.Reset()
+	c.json = true
+	if err := c.print(result, text); err != nil {
+		t.Fatal(err)
+	}
+	expected := "[\n  {\n    \"wallet\": \"default\",\n    \"account\": 0,\n    \"address\": \"TsAddr\"\n  }\n]\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	if d, err := parseDate("--from", ""); err != nil || !d.IsZero() {
+		t.Errorf("expected a zero time for an empty date, got %v, %v", d, err)
+	}
+
+	d, err := parseDate("--from", "2021-06-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Equal(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v", d)
+	}
+
+	if _, err = parseDate("--to", "01/06/2021"); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
+
+func TestReadPassphrase(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	fmt.Fprint(w, "start\nspend\r\n")
+	w.Close()
+
+	// Each prompt reads its own line of the piped passphrases.
+	c := &client{stderr: io.Discard, stdin: r, lines: bufio.NewReader(r)}
+	for _, expected := range []string{"start", "spend"} {
+		pass, err := c.readPassphrase("Passphrase: ")
+		if err != nil || pass != expected {
+			t.Fatalf("expected %q, got %q (%v)", expected, pass, err)
+		}
+	}
+	if _, err := c.readPassphrase("Passphrase: "); err == nil {
+		t.Errorf("expected an error once stdin is read")
+	}
+}
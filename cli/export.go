@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const dateLayout = "2006-01-02"
+
+type exportTxCommand struct {
+	commandBase
+	Wallet string `long:"wallet" description:"ID or name of the wallet to export, all wallets are exported if not set"`
+	Format string `long:"format" default:"csv" choice:"csv" choice:"json" choice:"ofx" description:"Format of the export"`
+	From   string `long:"from" description:"Only export transactions from this day on (YYYY-MM-DD)"`
+	To     string `long:"to" description:"Only export transactions up to this day (YYYY-MM-DD)"`
+	Fiat   string `long:"fiat" description:"Include the value of each transaction in this currency on the day it was mined"`
+	Output string `short:"o" long:"output" default:"-" description:"File to export to, - writes to stdout"`
+}
+
+type exportResult struct {
+	Count int    `json:"count"`
+	Path  string `json:"path"`
+}
+
+func parseDate(option, date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s date %q, expected YYYY-MM-DD", option, date)
+	}
+	return t, nil
+}
+
+func (cmd *exportTxCommand) run(c *client, _ []string) error {
+	opts := wallet.TxExportOptions{
+		Format:       cmd.Format,
+		Filter:       dcrlibwallet.TxFilterAll,
+		FiatCurrency: cmd.Fiat,
+	}
+	var err error
+	if opts.From, err = parseDate("--from", cmd.From); err != nil {
+		return err
+	}
+	if opts.To, err = parseDate("--to", cmd.To); err != nil {
+		return err
+	}
+	if !opts.To.IsZero() {
+		opts.To = opts.To.AddDate(0, 0, 1) // include transactions of the end date
+	}
+
+	wallets := c.mw.AllWallets()
+	if cmd.Wallet != "" {
+		w, err := c.selectWallet(cmd.Wallet)
+		if err != nil {
+			return err
+		}
+		wallets = []*dcrlibwallet.Wallet{w}
+	}
+
+	records, err := c.wal.TxExportRecords(wallets, opts)
+	if err != nil {
+		return err
+	}
+	if opts.FiatCurrency != "" {
+		c.fillPriceHistory(records)
+	}
+
+	if cmd.Output == "-" {
+		return wallet.WriteTxExport(c.stdout, opts.Format, records)
+	}
+
+	f, err := os.Create(cmd.Output)
+	if err != nil {
+		return err
+	}
+	err = wallet.WriteTxExport(f, opts.Format, records)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	result := exportResult{Count: len(records), Path: cmd.Output}
+	return c.print(result, func(tw io.Writer) {
+		fmt.Fprintf(tw, "%d transactions exported to %s\n", result.Count, result.Path)
+	})
+}
+
+// fillPriceHistory fetches the missing rates of the days the transactions of
+// records were mined on and values them. Transactions without a rate are
+// exported without a fiat value, so errors are only reported.
+func (c *client) fillPriceHistory(records []wallet.TxExportRecord) {
+	// Any provider that has the rates of the currency is used.
+	added, err := c.wal.FillTxExportRecords(c.ctx, "", records)
+	if err != nil {
+		c.progress("Error fetching price history: %v", err)
+	}
+	if added > 0 {
+		c.progress("Fetched %d %s rates", added, records[0].FiatCurrency)
+	}
+}
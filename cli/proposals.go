@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// proposalCategories maps the --category choices to Politeia categories.
+var proposalCategories = map[string]int32{
+	"all":       dcrlibwallet.ProposalCategoryAll,
+	"pre":       dcrlibwallet.ProposalCategoryPre,
+	"active":    dcrlibwallet.ProposalCategoryActive,
+	"approved":  dcrlibwallet.ProposalCategoryApproved,
+	"rejected":  dcrlibwallet.ProposalCategoryRejected,
+	"abandoned": dcrlibwallet.ProposalCategoryAbandoned,
+}
+
+type proposalsCommand struct {
+	List proposalsListCommand `command:"list" description:"List the proposals"`
+}
+
+type proposalsListCommand struct {
+	commandBase
+	Category string `long:"category" default:"all" choice:"all" choice:"pre" choice:"active" choice:"approved" choice:"rejected" choice:"abandoned" description:"Category of the proposals to list"`
+	Limit    int32  `long:"limit" description:"Maximum number of proposals to list, 0 lists all"`
+	NoSync   bool   `long:"nosync" description:"List the proposals saved by the app without fetching updates from Politeia"`
+}
+
+type proposalResult struct {
+	Token       string `json:"token"`
+	Name        string `json:"name"`
+	Username    string `json:"username"`
+	PublishedAt int64  `json:"publishedat"`
+	YesVotes    int32  `json:"yesvotes"`
+	NoVotes     int32  `json:"novotes"`
+	Approved    bool   `json:"approved"`
+}
+
+func (cmd *proposalsListCommand) run(c *client, _ []string) error {
+	if !cmd.NoSync {
+		c.progress("Fetching proposals from Politeia...")
+		if err := c.mw.Politeia.Sync(); err != nil {
+			return err
+		}
+	}
+
+	proposals, err := c.mw.Politeia.GetProposalsRaw(proposalCategories[cmd.Category], 0, cmd.Limit, true)
+	if err != nil {
+		return err
+	}
+
+	result := make([]proposalResult, len(proposals))
+	for i, p := range proposals {
+		result[i] = proposalResult{
+			Token:       p.Token,
+			Name:        p.Name,
+			Username:    p.Username,
+			PublishedAt: p.PublishedAt,
+			YesVotes:    p.YesVotes,
+			NoVotes:     p.NoVotes,
+			Approved:    p.VoteApproved,
+		}
+	}
+
+	return c.print(result, func(tw io.Writer) {
+		fmt.Fprintln(tw, "TOKEN\tPUBLISHED\tYES\tNO\tNAME")
+		for _, p := range result {
+			published := time.Unix(p.PublishedAt, 0).Format("2006-01-02")
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\n", p.Token, published, p.YesVotes, p.NoVotes, p.Name)
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+type sendCommand struct {
+	commandBase
+	walletOption
+	Account int32   `long:"account" description:"Number of the account to send from"`
+	Address string  `long:"address" required:"true" description:"Address to send to"`
+	Amount  float64 `long:"amount" description:"Amount to send in DCR"`
+	Max     bool    `long:"max" description:"Send the whole spendable balance of the account"`
+}
+
+type sendResult struct {
+	Hash   string  `json:"hash"`
+	Amount float64 `json:"amount,omitempty"`
+}
+
+func (cmd *sendCommand) run(c *client, _ []string) error {
+	if cmd.Max == (cmd.Amount != 0) {
+		return errors.New("specify either --amount or --max")
+	}
+	amount, err := dcrutil.NewAmount(cmd.Amount)
+	if err != nil || amount < 0 {
+		return fmt.Errorf("invalid amount %v", cmd.Amount)
+	}
+
+	w, err := c.selectWallet(cmd.Wallet)
+	if err != nil {
+		return err
+	}
+	passphrase, err := spendingPassphrase(c, w)
+	if err != nil {
+		return err
+	}
+	if err = c.sync(); err != nil {
+		return err
+	}
+
+	// The coins are selected with the strategy of the account, frozen coins
+	// are not spent.
+	txAuthor, err := wallet.NewTxAuthor(w, cmd.Account)
+	if err != nil {
+		return err
+	}
+	if err = txAuthor.AddSendDestination(cmd.Address, int64(amount), cmd.Max); err != nil {
+		return err
+	}
+	if cmd.Max {
+		if amount, err = txAuthor.SendMaxAmount(); err != nil {
+			return err
+		}
+	}
+
+	hash, err := txAuthor.Broadcast([]byte(passphrase))
+	if err != nil {
+		return err
+	}
+	txHash, err := chainhash.NewHash(hash)
+	if err != nil {
+		return err
+	}
+
+	result := sendResult{Hash: txHash.String(), Amount: amount.ToCoin()}
+	return c.print(result, func(tw io.Writer) {
+		fmt.Fprintf(tw, "Sent transaction %s\n", result.Hash)
+	})
+}
+
+type ticketsCommand struct {
+	Buy ticketsBuyCommand `command:"buy" description:"Purchase tickets through a VSP"`
+}
+
+type ticketsBuyCommand struct {
+	commandBase
+	walletOption
+	Account int32  `long:"account" description:"Number of the account to purchase tickets with"`
+	Count   int32  `long:"count" default:"1" description:"Number of tickets to purchase"`
+	VSP     string `long:"vsp" description:"Host of the VSP, defaults to the VSP last used in the app"`
+}
+
+func (cmd *ticketsBuyCommand) run(c *client, _ []string) error {
+	if cmd.Count < 1 {
+		return errors.New("--count must be at least 1")
+	}
+
+	w, err := c.selectWallet(cmd.Wallet)
+	if err != nil {
+		return err
+	}
+
+	host := cmd.VSP
+	if host == "" {
+		host = c.mw.LastUsedVSP()
+		if host == "" {
+			return errors.New("no VSP has been used in the app, select one with --vsp")
+		}
+	}
+	c.mw.ReloadVSPList(c.ctx)
+	var vsp *dcrlibwallet.VSP
+	for _, known := range c.mw.KnownVSPs() {
+		if known.Host == host {
+			vsp = known
+			break
+		}
+	}
+	if vsp == nil || vsp.VspInfoResponse == nil {
+		return fmt.Errorf("unknown VSP %s, add it in the app first", host)
+	}
+
+	passphrase, err := spendingPassphrase(c, w)
+	if err != nil {
+		return err
+	}
+	if err = c.sync(); err != nil {
+		return err
+	}
+
+	hashes, err := w.PurchaseTickets(cmd.Account, cmd.Count, vsp.Host, vsp.PubKey, []byte(passphrase))
+	if err != nil {
+		return err
+	}
+
+	tickets := make([]string, len(hashes))
+	for i, hash := range hashes {
+		tickets[i] = hash.String()
+	}
+	return c.print(tickets, func(tw io.Writer) {
+		fmt.Fprintf(tw, "Purchased %d tickets:\n", len(tickets))
+		for _, ticket := range tickets {
+			fmt.Fprintln(tw, ticket)
+		}
+	})
+}
+
+type mixerCommand struct {
+	Start mixerStartCommand `command:"start" description:"Run the account mixer until interrupted"`
+}
+
+type mixerStartCommand struct {
+	commandBase
+	walletOption
+}
+
+func (cmd *mixerStartCommand) run(c *client, _ []string) error {
+	w, err := c.selectWallet(cmd.Wallet)
+	if err != nil {
+		return err
+	}
+	if !w.AccountMixerConfigIsSet() {
+		return fmt.Errorf("privacy has not been set up for wallet %s, set it up in the app first", w.Name)
+	}
+
+	passphrase, err := spendingPassphrase(c, w)
+	if err != nil {
+		return err
+	}
+	if err = c.sync(); err != nil {
+		return err
+	}
+
+	if err = c.mw.StartAccountMixer(w.ID, passphrase); err != nil {
+		return err
+	}
+	c.progress("Account mixer started, press Ctrl+C to stop it")
+
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			c.progress("Stopping account mixer")
+			return c.mw.StopAccountMixer(w.ID)
+		case <-ticker.C:
+			if !w.IsAccountMixerActive() {
+				return errors.New("account mixer stopped, see the log for details")
+			}
+		}
+	}
+}
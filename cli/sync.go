@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/listeners"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/term"
+)
+
+const syncListenerID = "godcr-cli"
+
+type syncCommand struct {
+	commandBase
+}
+
+type syncResult struct {
+	Synced         bool  `json:"synced"`
+	BestBlock      int32 `json:"bestblock"`
+	BestBlockTime  int64 `json:"bestblocktime"`
+	ConnectedPeers int32 `json:"connectedpeers"`
+}
+
+func (cmd *syncCommand) run(c *client, _ []string) error {
+	if err := c.sync(); err != nil {
+		return err
+	}
+
+	result := syncResult{
+		Synced:         c.mw.IsSynced(),
+		ConnectedPeers: c.mw.ConnectedPeers(),
+	}
+	if block := c.mw.GetBestBlock(); block != nil {
+		result.BestBlock = block.Height
+		result.BestBlockTime = block.Timestamp
+	}
+
+	return c.print(result, func(w io.Writer) {
+		fmt.Fprintf(w, "Synced to block %d (%s) with %d peers\n", result.BestBlock,
+			time.Unix(result.BestBlockTime, 0).Format(time.RFC1123), result.ConnectedPeers)
+	})
+}
+
+// sync starts the SPV sync of the wallets and waits until it completes,
+// printing its progress.
+func (c *client) sync() error {
+	if c.mw.IsSynced() {
+		return nil
+	}
+
+	listener := listeners.NewSyncProgress()
+	if err := c.mw.AddSyncProgressListener(listener, syncListenerID); err != nil {
+		return err
+	}
+	// Sync progress is no longer consumed once the sync completes.
+	defer c.mw.RemoveSyncProgressListener(syncListenerID)
+
+	if !c.mw.IsSyncing() {
+		if err := c.mw.SpvSync(); err != nil {
+			return err
+		}
+	}
+	c.progress("Syncing wallets...")
+
+	// The listener does not report sync errors, the sync is checked regularly
+	// to detect that it ended.
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	lastProgress := int32(-1)
+	for {
+		select {
+		case <-c.ctx.Done():
+			// Stop listening first, the cancellation is not consumed.
+			c.mw.RemoveSyncProgressListener(syncListenerID)
+			c.mw.CancelSync()
+			return c.ctx.Err()
+
+		case update := <-listener.SyncStatusChan:
+			switch update.Stage {
+			case wallet.SyncCompleted:
+				c.progress("Sync completed")
+				return nil
+			case wallet.SyncCanceled:
+				return errors.New("sync canceled")
+			case wallet.PeersConnected:
+				c.progress("Connected peers: %d", update.ConnectedPeers)
+			}
+
+		case <-ticker.C:
+			if c.mw.IsSynced() {
+				return nil
+			}
+			if !c.mw.IsSyncing() {
+				return errors.New("sync ended before completing, see the log for details")
+			}
+			if p := c.mw.GeneralSyncProgress(); p != nil && p.TotalSyncProgress != lastProgress {
+				lastProgress = p.TotalSyncProgress
+				c.progress("Sync progress: %d%%, %s remaining", p.TotalSyncProgress,
+					time.Duration(p.TotalTimeRemainingSeconds)*time.Second)
+			}
+		}
+	}
+}
+
+// readPassphrase prompts for a passphrase on stderr. The passphrase is not
+// echoed if stdin is a terminal, otherwise a line is read from stdin so that
+// scripts can pipe it.
+func (c *client) readPassphrase(prompt string) (string, error) {
+	fd := int(c.stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(c.stderr, prompt)
+		pass, err := term.ReadPassword(fd)
+		fmt.Fprintln(c.stderr)
+		return string(pass), err
+	}
+
+	line, err := c.lines.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("reading passphrase: %v", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// spendingPassphrase prompts for the spending passphrase of w.
+func spendingPassphrase(c *client, w *dcrlibwallet.Wallet) (string, error) {
+	if w.IsWatchingOnlyWallet() {
+		return "", fmt.Errorf("wallet %s is watch-only and cannot spend funds", w.Name)
+	}
+	return c.readPassphrase(fmt.Sprintf("Spending passphrase for %s: ", w.Name))
+}
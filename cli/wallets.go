@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+type walletsCommand struct {
+	List walletsListCommand `command:"list" description:"List the wallets and their balances"`
+}
+
+type walletsListCommand struct {
+	commandBase
+}
+
+type walletResult struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	WatchingOnly bool    `json:"watchingonly"`
+	Balance      float64 `json:"balance"`
+}
+
+func (cmd *walletsListCommand) run(c *client, _ []string) error {
+	wallets := c.mw.AllWallets()
+	sort.Slice(wallets, func(i, j int) bool {
+		return wallets[i].ID < wallets[j].ID
+	})
+
+	totals := make([]dcrutil.Amount, 0, len(wallets))
+	result := make([]walletResult, 0, len(wallets))
+	for _, w := range wallets {
+		accounts, err := w.GetAccountsRaw()
+		if err != nil {
+			return err
+		}
+		var total int64
+		for _, acct := range accounts.Acc {
+			total += acct.TotalBalance
+		}
+		totals = append(totals, dcrutil.Amount(total))
+		result = append(result, walletResult{
+			ID:           w.ID,
+			Name:         w.Name,
+			WatchingOnly: w.IsWatchingOnlyWallet(),
+			Balance:      dcrutil.Amount(total).ToCoin(),
+		})
+	}
+
+	return c.print(result, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNAME\tBALANCE\tWATCH-ONLY")
+		for i, r := range result {
+			fmt.Fprintf(w, "%d\t%s\t%v\t%t\n", r.ID, r.Name, totals[i], r.WatchingOnly)
+		}
+	})
+}
+
+type balanceCommand struct {
+	commandBase
+	walletOption
+}
+
+type accountBalanceResult struct {
+	Account         int32   `json:"account"`
+	Name            string  `json:"name"`
+	Total           float64 `json:"total"`
+	Spendable       float64 `json:"spendable"`
+	Immature        float64 `json:"immature"`
+	LockedByTickets float64 `json:"lockedbytickets"`
+	Unconfirmed     float64 `json:"unconfirmed"`
+}
+
+func (cmd *balanceCommand) run(c *client, _ []string) error {
+	w, err := c.selectWallet(cmd.Wallet)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := w.GetAccountsRaw()
+	if err != nil {
+		return err
+	}
+
+	var balances []*dcrlibwallet.Balance
+	result := make([]accountBalanceResult, 0, len(accounts.Acc))
+	for _, acct := range accounts.Acc {
+		b := acct.Balance
+		balances = append(balances, b)
+		result = append(result, accountBalanceResult{
+			Account:         acct.Number,
+			Name:            acct.Name,
+			Total:           dcrutil.Amount(b.Total).ToCoin(),
+			Spendable:       dcrutil.Amount(b.Spendable).ToCoin(),
+			Immature:        dcrutil.Amount(b.ImmatureReward + b.ImmatureStakeGeneration).ToCoin(),
+			LockedByTickets: dcrutil.Amount(b.LockedByTickets).ToCoin(),
+			Unconfirmed:     dcrutil.Amount(b.UnConfirmed).ToCoin(),
+		})
+	}
+
+	return c.print(result, func(tw io.Writer) {
+		fmt.Fprintln(tw, "ACCOUNT\tNAME\tTOTAL\tSPENDABLE\tIMMATURE\tLOCKED\tUNCONFIRMED")
+		for i, r := range result {
+			b := balances[i]
+			fmt.Fprintf(tw, "%d\t%s\t%v\t%v\t%v\t%v\t%v\n", r.Account, r.Name,
+				dcrutil.Amount(b.Total), dcrutil.Amount(b.Spendable),
+				dcrutil.Amount(b.ImmatureReward+b.ImmatureStakeGeneration),
+				dcrutil.Amount(b.LockedByTickets), dcrutil.Amount(b.UnConfirmed))
+		}
+	})
+}
+
+type receiveCommand struct {
+	commandBase
+	walletOption
+	Account int32 `long:"account" description:"Number of the account to receive funds to"`
+	New     bool  `long:"new" description:"Generate a new address instead of showing the current one"`
+}
+
+type addressResult struct {
+	Wallet  string `json:"wallet"`
+	Account int32  `json:"account"`
+	Address string `json:"address"`
+}
+
+func (cmd *receiveCommand) run(c *client, _ []string) error {
+	w, err := c.selectWallet(cmd.Wallet)
+	if err != nil {
+		return err
+	}
+
+	var addr string
+	if cmd.New {
+		addr, err = w.NextAddress(cmd.Account)
+	} else {
+		addr, err = w.CurrentAddress(cmd.Account)
+	}
+	if err != nil {
+		return err
+	}
+
+	result := addressResult{Wallet: w.Name, Account: cmd.Account, Address: addr}
+	return c.print(result, func(tw io.Writer) {
+		fmt.Fprintln(tw, result.Address)
+	})
+}
@@ -12,7 +12,10 @@ import (
 	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/decred/slog"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/planetdecred/godcr/cli"
+	"github.com/planetdecred/godcr/urihandler"
 	"github.com/planetdecred/godcr/version"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
@@ -21,25 +24,58 @@ const (
 	defaultLogFilename    = "godcr.log"
 	defaultLogLevel       = "info"
 	defaultLogDirname     = "logs"
+	defaultRPCListen      = "127.0.0.1:9119"
+	defaultRPCCertFile    = "rpc.cert"
+	defaultRPCKeyFile     = "rpc.key"
 )
 
 var (
 	defaultHomeDir        = dcrutil.AppDataDir("godcr", false)
 	defaultConfigFilename = filepath.Join(defaultHomeDir, defaultConfigFileName)
 	defaultLogDir         = filepath.Join(defaultHomeDir, defaultLogDirname)
+	defaultRPCCert        = filepath.Join(defaultHomeDir, defaultRPCCertFile)
+	defaultRPCKey         = filepath.Join(defaultHomeDir, defaultRPCKeyFile)
 )
 
 type config struct {
 	Network          string `long:"network" description:"Network to use"`
 	HomeDir          string `long:"appdata" description:"Directory where the app configuration file and wallet data is stored"`
 	ConfigFile       string `long:"configfile" description:"Filename of the config file in the app directory"`
-	ShowVersion      bool   `short:"V" long:"version" description:"Display version information and exit"`
+	ShowVersion      bool   `short:"V" long:"version" no-ini:"true" description:"Display version information and exit"`
 	MaxLogZips       int    `long:"max-log-zips" description:"The number of zipped log files created by the log rotator to be retained. Setting to 0 will keep all."`
 	LogDir           string `long:"logdir" description:"Directory to log output."`
 	DebugLevel       string `short:"d" long:"debuglevel" description:"Logging level {trace, debug, info, warn, error, critical}"`
 	Quiet            bool   `short:"q" long:"quiet" description:"Easy way to set debuglevel to error"`
 	SpendUnconfirmed bool   `long:"spendunconfirmed" description:"Allow the multiwallet to use transactions that have not been confirmed"`
 	Profile          int    `long:"profile" description:"Runs local web server for profiling"`
+	RegisterURI      bool   `long:"registeruri" no-ini:"true" description:"Register the app as the handler of decred: payment URIs and exit"`
+
+	// RPC server
+	RPC          bool   `long:"rpc" description:"Start the JSON-RPC server to control the wallets from scripts"`
+	RPCListen    string `long:"rpclisten" description:"Interface/port the JSON-RPC server listens on"`
+	RPCUser      string `long:"rpcuser" description:"Username for JSON-RPC connections that may spend funds"`
+	RPCPass      string `long:"rpcpass" default-mask:"-" description:"Password for JSON-RPC connections that may spend funds"`
+	RPCLimitUser string `long:"rpclimituser" description:"Username for read-only JSON-RPC connections"`
+	RPCLimitPass string `long:"rpclimitpass" default-mask:"-" description:"Password for read-only JSON-RPC connections"`
+	RPCCert      string `long:"rpccert" description:"File containing the JSON-RPC server certificate, generated if missing"`
+	RPCKey       string `long:"rpckey" description:"File containing the JSON-RPC server certificate key, generated if missing"`
+
+	// Command line mode
+	NoGUI bool `long:"nogui" description:"Run a command, or the JSON-RPC server with --rpc, without the graphical interface"`
+	JSON  bool `long:"json" description:"Print the output of commands as JSON"`
+
+	PrintConfig bool `long:"print-config" no-ini:"true" description:"Print the effective settings in the config file format and exit"`
+
+	cli.Commands
+
+	// command is the command selected on the command line and commandArgs
+	// its remaining arguments. They are run once the wallets are loaded.
+	command     flags.Commander
+	commandArgs []string
+
+	// paymentURI is the decred: payment URI the app was launched with, it is
+	// opened on the send page.
+	paymentURI string
 }
 
 var defaultConfig = config{
@@ -48,6 +84,9 @@ var defaultConfig = config{
 	ConfigFile: defaultConfigFilename,
 	LogDir:     defaultLogDir,
 	DebugLevel: defaultLogLevel,
+	RPCListen:  defaultRPCListen,
+	RPCCert:    defaultRPCCert,
+	RPCKey:     defaultRPCKey,
 }
 
 // validLogLevel returns whether or not logLevel is a valid debug log level.
@@ -132,10 +171,16 @@ func loadConfig() (*config, error) {
 	cfg := defaultConfig
 	defaultConfigNow := defaultConfig
 
-	// Pre-parse the command line options to see if an alternative config file
-	// or the version flag was specified. Override any environment variables
-	// with parsed command line flags.
+	// Pre-parse the environment and the command line options to see if an
+	// alternative config file or the version flag was specified. Override any
+	// environment variables with parsed command line flags.
 	preParser := flags.NewParser(&cfg, flags.HelpFlag|flags.PassDoubleDash)
+	preParser.SubcommandsOptional = true
+	preParser.CommandHandler = func(flags.Commander, []string) error { return nil }
+	if err := parseEnv(preParser); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
 	_, err := preParser.Parse()
 
 	if err != nil {
@@ -159,6 +204,23 @@ func loadConfig() (*config, error) {
 		os.Exit(0)
 	}
 
+	// Register the URI handler and exit if requested.
+	if cfg.RegisterURI {
+		if err := urihandler.Register(); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to register the %s: URI handler: %v\n", wallet.PaymentURIScheme, err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s registered as the handler of %s: URIs\n", appName, wallet.PaymentURIScheme)
+		os.Exit(0)
+	}
+
+	// The network section of the config file is selected by the network set
+	// in the environment or on the command line, or else in the config file.
+	var network string
+	if preParser.FindOptionByLongName("network").IsSet() {
+		network = cfg.Network
+	}
+
 	// If a non-default appdata folder is specified on the command line, it may
 	// be necessary adjust the config file location. If the the config file
 	// location was not specified on the command line, the default location
@@ -166,7 +228,7 @@ func loadConfig() (*config, error) {
 	// file was specified on the command line, it should be used regardless of
 	// the appdata directory.
 	if defaultHomeDir != cfg.HomeDir && defaultConfigNow.ConfigFile == cfg.ConfigFile {
-		cfg.ConfigFile = filepath.Join(cfg.HomeDir, defaultConfigFilename)
+		cfg.ConfigFile = filepath.Join(cfg.HomeDir, defaultConfigFileName)
 		// Update the defaultConfig to avoid an error if the config file in this
 		// "new default" location does not exist.
 		defaultConfigNow.ConfigFile = cfg.ConfigFile
@@ -177,6 +239,11 @@ func loadConfig() (*config, error) {
 	// Config file name for logging.
 	configFile := "NONE (defaults)"
 	parser := flags.NewParser(&cfg, flags.Default)
+	parser.SubcommandsOptional = true
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		cfg.command, cfg.commandArgs = command, args
+		return nil
+	}
 
 	// Do not error default config file is missing.
 	if _, err := os.Stat(cfg.ConfigFile); os.IsNotExist(err) {
@@ -186,15 +253,18 @@ func loadConfig() (*config, error) {
 			return loadConfigError(err)
 		}
 		// Warn about missing default config file, but continue
-		fmt.Printf("Config file (%s) does not exist. Using defaults.\n",
+		fmt.Fprintf(os.Stderr, "Config file (%s) does not exist. Using defaults.\n",
 			cfg.ConfigFile)
 	} else {
-		// The config file exists, so attempt to parse it.
-		err = flags.NewIniParser(parser).ParseFile(cfg.ConfigFile)
+		// The config file exists, so attempt to parse it. The options of the
+		// network section take precedence over the global options.
+		cf, err := readConfigFile(cfg.ConfigFile)
+		if err == nil {
+			err = cf.parse(parser, network)
+		}
 		if err != nil {
 			if _, ok := err.(*os.PathError); !ok {
 				fmt.Fprintln(os.Stderr, err)
-				parser.WriteHelp(os.Stderr)
 				return loadConfigError(err)
 			}
 			configFileError = err
@@ -202,6 +272,12 @@ func loadConfig() (*config, error) {
 		configFile = cfg.ConfigFile
 	}
 
+	// Environment variables override the config file.
+	if err := parseEnv(parser); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+
 	// Parse command line options again to ensure they take precedence.
 	_, err = parser.Parse()
 	if err != nil {
@@ -211,6 +287,30 @@ func loadConfig() (*config, error) {
 		return loadConfigError(err)
 	}
 
+	if cfg.command != nil && !cfg.NoGUI {
+		err := fmt.Errorf("commands can only be run with --nogui")
+		fmt.Fprintln(os.Stderr, err)
+		return loadConfigError(err)
+	}
+	if cfg.command == nil && len(cfg.commandArgs) > 0 {
+		if len(cfg.commandArgs) > 1 || !wallet.IsPaymentURI(cfg.commandArgs[0]) {
+			err := fmt.Errorf("unexpected arguments %q, only a %s: payment URI may be passed",
+				cfg.commandArgs, wallet.PaymentURIScheme)
+			fmt.Fprintln(os.Stderr, err)
+			return loadConfigError(err)
+		}
+		if cfg.NoGUI {
+			err := fmt.Errorf("payment URIs can only be opened without --nogui")
+			fmt.Fprintln(os.Stderr, err)
+			return loadConfigError(err)
+		}
+		cfg.paymentURI = cfg.commandArgs[0]
+	}
+	if cfg.NoGUI {
+		// Keep stdout for the output of commands.
+		logStdout = os.Stderr
+	}
+
 	// Create the home directory if it doesn't already exist.
 	funcName := "loadConfig"
 	err = os.MkdirAll(cfg.HomeDir, 0700)
@@ -231,12 +331,20 @@ func loadConfig() (*config, error) {
 	}
 
 	// If a non-default appdata folder is specified, it may be necessary to
-	// adjust the LogDir.
+	// adjust the LogDir and the RPC certificate files.
 	if defaultHomeDir != cfg.HomeDir {
 		if defaultLogDir == cfg.LogDir {
 			cfg.LogDir = filepath.Join(cfg.HomeDir, defaultLogDirname)
 		}
+		if defaultRPCCert == cfg.RPCCert {
+			cfg.RPCCert = filepath.Join(cfg.HomeDir, defaultRPCCertFile)
+		}
+		if defaultRPCKey == cfg.RPCKey {
+			cfg.RPCKey = filepath.Join(cfg.HomeDir, defaultRPCKeyFile)
+		}
 	}
+	cfg.RPCCert = cleanAndExpandPath(cfg.RPCCert)
+	cfg.RPCKey = cleanAndExpandPath(cfg.RPCKey)
 
 	// Warn about missing config file after the final command line parse
 	// succeeds.  This prevents the warning on help messages and invalid
@@ -249,6 +357,11 @@ func loadConfig() (*config, error) {
 	logRotator = nil
 	cfg.LogDir = cleanAndExpandPath(cfg.LogDir)
 
+	if cfg.PrintConfig {
+		writeConfig(os.Stdout, parser, cfg.Network)
+		os.Exit(0)
+	}
+
 	// Initialize log rotation. After log rotation has been initialized, the
 	// logger variables may be used. This creates the LogDir if needed.
 	if cfg.MaxLogZips < 0 {
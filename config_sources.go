@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+)
+
+// envPrefix is the prefix of the environment variables that override config
+// options, e.g. GODCR_NETWORK for --network and GODCR_MAX_LOG_ZIPS for
+// --max-log-zips.
+const envPrefix = "GODCR_"
+
+// networkSections maps the names of the config file sections that hold the
+// options of a single network to the network.
+var networkSections = map[string]string{
+	"mainnet":  "mainnet",
+	"testnet3": "testnet3",
+	"testnet":  "testnet3",
+}
+
+// globalSection is the name go-flags gives the options that are not in a
+// command or named group.
+const globalSection = "application options"
+
+// configFile is a config file split into the options that apply to every
+// network and the options of each network section. Lines of other sections
+// are blanked so that ini errors report the line numbers of the file.
+type configFile struct {
+	path     string
+	global   []string
+	networks map[string][]string
+}
+
+// readConfigFile reads the config file at path. Sections other than the
+// global and network sections are rejected.
+func readConfigFile(path string) (*configFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	cf := &configFile{
+		path:     path,
+		global:   make([]string, len(lines)),
+		networks: make(map[string][]string),
+	}
+	network := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section := strings.ToLower(strings.TrimSpace(trimmed[1 : len(trimmed)-1]))
+			switch net, ok := networkSections[section]; {
+			case ok:
+				network = net
+			case section == globalSection:
+				network = ""
+			default:
+				return nil, fmt.Errorf("%s:%d: unknown section [%s], expected [mainnet] or [testnet3]",
+					path, i+1, section)
+			}
+			continue
+		}
+
+		if network == "" {
+			cf.global[i] = line
+			continue
+		}
+		if cf.networks[network] == nil {
+			cf.networks[network] = make([]string, len(lines))
+		}
+		cf.networks[network][i] = line
+	}
+
+	return cf, nil
+}
+
+// parse sets the options of the config file: the global options then those of
+// the section of network, which take precedence. The network set in the
+// config file is used if network is empty.
+func (cf *configFile) parse(parser *flags.Parser, network string) error {
+	if err := cf.parseGlobal(parser); err != nil {
+		return err
+	}
+	if network == "" {
+		network, _ = parser.FindOptionByLongName("network").Value().(string)
+	}
+	return cf.parseNetwork(parser, networkSections[network])
+}
+
+// parseGlobal sets the options of the config file that apply to every network.
+func (cf *configFile) parseGlobal(parser *flags.Parser) error {
+	return cf.parseLines(parser, cf.global)
+}
+
+// parseNetwork sets the options of the section of network, which take
+// precedence over the global options.
+func (cf *configFile) parseNetwork(parser *flags.Parser, network string) error {
+	lines, ok := cf.networks[network]
+	if !ok {
+		return nil
+	}
+	for i, line := range lines {
+		if name := iniOptionName(line); name == "network" || name == "appdata" || name == "configfile" {
+			return fmt.Errorf("%s:%d: %s cannot be set in the [%s] section", cf.path, i+1, name, network)
+		}
+	}
+	return cf.parseLines(parser, lines)
+}
+
+func (cf *configFile) parseLines(parser *flags.Parser, lines []string) error {
+	err := flags.NewIniParser(parser).Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if iniErr, ok := err.(*flags.IniError); ok {
+		return fmt.Errorf("%s:%d: %s", cf.path, iniErr.LineNumber, iniErr.Message)
+	}
+	return err
+}
+
+// iniOptionName returns the lowercase name of the option set on an ini line.
+func iniOptionName(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == ';' || line[0] == '#' {
+		return ""
+	}
+	name := strings.SplitN(line, "=", 2)[0]
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// envOption is a config option overridden by an environment variable.
+type envOption struct {
+	name  string // long name of the option
+	key   string // name of the environment variable
+	value string
+}
+
+// envOptions returns the options overridden by GODCR_* environment variables,
+// sorted by variable name. Empty variables are ignored. An error is returned
+// for a variable that does not match any option.
+func envOptions(parser *flags.Parser) ([]envOption, error) {
+	var environ []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, envPrefix) {
+			environ = append(environ, kv)
+		}
+	}
+	sort.Strings(environ)
+
+	options := make([]envOption, 0, len(environ))
+	for _, kv := range environ {
+		parts := strings.SplitN(kv, "=", 2)
+		key := parts[0]
+		if parts[1] == "" {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(key, envPrefix))
+		name = strings.Replace(name, "_", "-", -1)
+
+		opt := parser.FindOptionByLongName(name)
+		if opt == nil {
+			// Options such as --spendunconfirmed have no dashes.
+			opt = parser.FindOptionByLongName(strings.Replace(name, "-", "", -1))
+		}
+		if opt == nil {
+			return nil, fmt.Errorf("unknown environment variable %s, "+
+				"run with -h to list the options that can be set with %s<OPTION>", key, envPrefix)
+		}
+
+		options = append(options, envOption{name: opt.LongName, key: key, value: parts[1]})
+	}
+
+	return options, nil
+}
+
+// parseEnv sets the options overridden by GODCR_* environment variables.
+func parseEnv(parser *flags.Parser) error {
+	options, err := envOptions(parser)
+	if err != nil || len(options) == 0 {
+		return err
+	}
+
+	lines := make([]string, len(options))
+	for i, opt := range options {
+		lines[i] = opt.name + " = " + strconv.Quote(opt.value)
+	}
+
+	err = flags.NewIniParser(parser).Parse(strings.NewReader(strings.Join(lines, "\n")))
+	if iniErr, ok := err.(*flags.IniError); ok {
+		return fmt.Errorf("invalid %s: %s", options[iniErr.LineNumber-1].key, iniErr.Message)
+	}
+	return err
+}
+
+// writeConfig writes the options of parser in the config file format.
+// Passwords and other options with a default mask are not written, nor are
+// options that cannot be set in the config file.
+func writeConfig(w io.Writer, parser *flags.Parser, network string) {
+	fmt.Fprintf(w, "; Effective settings for %s\n", network)
+	group := parser.Group.Find("Application Options")
+	if group == nil {
+		return
+	}
+	for _, opt := range group.Options() {
+		if opt.LongName == "" || opt.Hidden || opt.Field().Tag.Get("no-ini") != "" {
+			continue
+		}
+		value := opt.Value()
+		if reflect.ValueOf(value).IsZero() {
+			fmt.Fprintf(w, "; %s =\n", opt.LongName)
+			continue
+		}
+		if opt.DefaultMask == "-" {
+			fmt.Fprintf(w, "%s = <hidden>\n", opt.LongName)
+			continue
+		}
+		fmt.Fprintf(w, "%s = %v\n", opt.LongName, value)
+	}
+}
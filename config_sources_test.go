@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+)
+
+func writeTestConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), defaultConfigFileName)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func newTestParser(cfg *config) *flags.Parser {
+	parser := flags.NewParser(cfg, flags.None)
+	parser.SubcommandsOptional = true
+	parser.CommandHandler = func(flags.Commander, []string) error { return nil }
+	return parser
+}
+
+// parseTestConfig sets the options of a config from the config file content,
+// the environment and args in the order loadConfig does.
+func parseTestConfig(t *testing.T, content string, env map[string]string, args []string) (*config, error) {
+	t.Helper()
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	cfg := defaultConfig
+	preParser := newTestParser(&cfg)
+	if err := parseEnv(preParser); err != nil {
+		return nil, err
+	}
+	if _, err := preParser.ParseArgs(args); err != nil {
+		return nil, err
+	}
+	var network string
+	if preParser.FindOptionByLongName("network").IsSet() {
+		network = cfg.Network
+	}
+
+	parser := newTestParser(&cfg)
+	cf, err := readConfigFile(writeTestConfigFile(t, content))
+	if err != nil {
+		return nil, err
+	}
+	if err = cf.parse(parser, network); err != nil {
+		return nil, err
+	}
+	if err = parseEnv(parser); err != nil {
+		return nil, err
+	}
+	if _, err = parser.ParseArgs(args); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func TestReadConfigFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		global   []string
+		networks map[string][]string
+		err      string
+	}{{
+		name:     "global options",
+		content:  "debuglevel = warn\n; comment\n",
+		global:   []string{"debuglevel = warn", "; comment"},
+		networks: map[string][]string{},
+	}, {
+		name:    "network sections",
+		content: "debuglevel = warn\n[testnet]\nmax-log-zips = 3\n[Application Options]\nquiet = true\n[MainNet]\nmax-log-zips = 5",
+		global:  []string{"debuglevel = warn", "", "", "", "quiet = true", "", ""},
+		networks: map[string][]string{
+			"testnet3": {"", "", "max-log-zips = 3", "", "", "", ""},
+			"mainnet":  {"", "", "", "", "", "", "max-log-zips = 5"},
+		},
+	}, {
+		name:    "unknown section",
+		content: "debuglevel = warn\n\n[rpc]\nrpc = true\n",
+		err:     ":3: unknown section [rpc]",
+	}}
+
+	for _, test := range tests {
+		cf, err := readConfigFile(writeTestConfigFile(t, test.content))
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: expected error %q, got %v", test.name, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(cf.global, test.global) {
+			t.Errorf("%s: global lines %q, expected %q", test.name, cf.global, test.global)
+		}
+		if !reflect.DeepEqual(cf.networks, test.networks) {
+			t.Errorf("%s: network lines %q, expected %q", test.name, cf.networks, test.networks)
+		}
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		env        map[string]string
+		args       []string
+		debugLevel string
+		maxLogZips int
+		err        string
+	}{{
+		name:       "defaults",
+		debugLevel: defaultLogLevel,
+	}, {
+		name:       "file defaults",
+		content:    "debuglevel = warn\nmax-log-zips = 2",
+		debugLevel: "warn",
+		maxLogZips: 2,
+	}, {
+		name:       "section of the network of the file",
+		content:    "network = testnet\ndebuglevel = warn\n[testnet3]\ndebuglevel = debug\n[mainnet]\nmax-log-zips = 5",
+		debugLevel: "debug",
+	}, {
+		name:       "section of another network",
+		content:    "debuglevel = warn\n[testnet]\ndebuglevel = debug",
+		debugLevel: "warn",
+	}, {
+		name:       "section of the network of the environment",
+		content:    "debuglevel = warn\n[testnet]\ndebuglevel = debug",
+		env:        map[string]string{"GODCR_NETWORK": "testnet"},
+		debugLevel: "debug",
+	}, {
+		name:       "section of the network of the command line",
+		content:    "network = testnet\n[mainnet]\ndebuglevel = error\n[testnet]\ndebuglevel = debug",
+		args:       []string{"--network", "mainnet"},
+		debugLevel: "error",
+	}, {
+		name:       "environment over file",
+		content:    "debuglevel = warn\n[mainnet]\nmax-log-zips = 2",
+		env:        map[string]string{"GODCR_DEBUGLEVEL": "error", "GODCR_MAX_LOG_ZIPS": "7"},
+		debugLevel: "error",
+		maxLogZips: 7,
+	}, {
+		name:       "command line over environment",
+		content:    "debuglevel = warn",
+		env:        map[string]string{"GODCR_DEBUGLEVEL": "error"},
+		args:       []string{"-d", "trace"},
+		debugLevel: "trace",
+	}, {
+		name:       "empty environment variable",
+		content:    "debuglevel = warn",
+		env:        map[string]string{"GODCR_DEBUGLEVEL": ""},
+		debugLevel: "warn",
+	}, {
+		name:    "unknown key",
+		content: "debuglevel = warn\nmaxlogs = 3",
+		err:     "unknown option: maxlogs",
+	}, {
+		name:    "network in a section",
+		content: "[testnet]\nnetwork = mainnet",
+		args:    []string{"--network", "testnet"},
+		err:     ":2: network cannot be set in the [testnet3] section",
+	}, {
+		name: "unknown environment variable",
+		env:  map[string]string{"GODCR_MAX_LOGS": "3"},
+		err:  "unknown environment variable GODCR_MAX_LOGS",
+	}, {
+		name: "invalid environment variable",
+		env:  map[string]string{"GODCR_MAX_LOG_ZIPS": "many"},
+		err:  "invalid GODCR_MAX_LOG_ZIPS",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := parseTestConfig(t, test.content, test.env, test.args)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.DebugLevel != test.debugLevel || cfg.MaxLogZips != test.maxLogZips {
+				t.Errorf("debuglevel %q and maxlogzips %d, expected %q and %d",
+					cfg.DebugLevel, cfg.MaxLogZips, test.debugLevel, test.maxLogZips)
+			}
+		})
+	}
+}
+
+func TestEnvOptions(t *testing.T) {
+	t.Setenv("GODCR_SPEND_UNCONFIRMED", "true")
+	t.Setenv("GODCR_MAX_LOG_ZIPS", "4")
+	t.Setenv("GODCR_RPCPASS", "")
+	t.Setenv("DEBUGLEVEL", "trace")
+
+	cfg := defaultConfig
+	options, err := envOptions(newTestParser(&cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []envOption{
+		{name: "max-log-zips", key: "GODCR_MAX_LOG_ZIPS", value: "4"},
+		{name: "spendunconfirmed", key: "GODCR_SPEND_UNCONFIRMED", value: "true"},
+	}
+	if !reflect.DeepEqual(options, expected) {
+		t.Errorf("options %+v, expected %+v", options, expected)
+	}
+}
+
+func TestWriteConfig(t *testing.T) {
+	cfg := defaultConfig
+	cfg.RPCUser = "user"
+	cfg.RPCPass = "secret"
+	parser := newTestParser(&cfg)
+
+	var buf bytes.Buffer
+	writeConfig(&buf, parser, "testnet3")
+	out := buf.String()
+
+	for _, line := range []string{"; Effective settings for testnet3\n", "rpcuser = user\n", "rpcpass = <hidden>\n", "; rpclimitpass =\n"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected %q in\n%s", line, out)
+		}
+	}
+	for _, text := range []string{"secret", "print-config", "version ="} {
+		if strings.Contains(out, text) {
+			t.Errorf("unexpected %q in\n%s", text, out)
+		}
+	}
+}
@@ -28,7 +28,7 @@ require (
 require (
 	decred.org/cspp/v2 v2.0.0 // indirect
 	decred.org/dcrwallet v1.7.0 // indirect
-	decred.org/dcrwallet/v2 v2.0.2-0.20220505152146-ece5da349895 // indirect
+	decred.org/dcrwallet/v2 v2.0.2-0.20220505152146-ece5da349895
 	gioui.org/cpu v0.0.0-20210817075930-8d6a761490d2 // indirect
 	gioui.org/shader v1.0.6 // indirect
 	github.com/AndreasBriese/bbloom v0.0.0-20190825152654-46b345b51c96 // indirect
@@ -59,9 +59,9 @@ require (
 	github.com/decred/dcrd/blockchain/stake/v4 v4.0.0 // indirect
 	github.com/decred/dcrd/blockchain/standalone/v2 v2.1.0 // indirect
 	github.com/decred/dcrd/blockchain/v4 v4.0.0 // indirect
-	github.com/decred/dcrd/certgen v1.1.1 // indirect
-	github.com/decred/dcrd/chaincfg/chainhash v1.0.3 // indirect
-	github.com/decred/dcrd/chaincfg/v3 v3.1.1 // indirect
+	github.com/decred/dcrd/certgen v1.1.1
+	github.com/decred/dcrd/chaincfg/chainhash v1.0.3
+	github.com/decred/dcrd/chaincfg/v3 v3.1.1
 	github.com/decred/dcrd/connmgr/v3 v3.1.0 // indirect
 	github.com/decred/dcrd/crypto/blake256 v1.0.1-0.20200921185235-6d75c7ec1199 // indirect
 	github.com/decred/dcrd/crypto/ripemd160 v1.0.1 // indirect
@@ -80,8 +80,8 @@ require (
 	github.com/decred/dcrd/rpc/jsonrpc/types/v3 v3.0.0 // indirect
 	github.com/decred/dcrd/rpcclient/v7 v7.0.0 // indirect
 	github.com/decred/dcrd/txscript/v3 v3.0.0 // indirect
-	github.com/decred/dcrd/txscript/v4 v4.0.0 // indirect
-	github.com/decred/dcrd/wire v1.5.0 // indirect
+	github.com/decred/dcrd/txscript/v4 v4.0.0
+	github.com/decred/dcrd/wire v1.5.0
 	github.com/decred/dcrdata/v7 v7.0.0-20211216152310-365c9dc820eb // indirect
 	github.com/decred/dcrtime v0.0.0-20191018193024-8d8b4ef0458e // indirect
 	github.com/decred/go-socks v1.1.0 // indirect
@@ -125,8 +125,8 @@ require (
 	golang.org/x/crypto v0.0.0-20220427172511-eb4f295cb31f // indirect
 	golang.org/x/net v0.0.0-20220425223048-2871e0cb64e4 // indirect
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c // indirect
-	golang.org/x/sys v0.0.0-20220503163025-988cb79eb6c6 // indirect
-	golang.org/x/term v0.0.0-20220411215600-e5f449aeb171 // indirect
+	golang.org/x/sys v0.0.0-20220503163025-988cb79eb6c6
+	golang.org/x/term v0.0.0-20220411215600-e5f449aeb171
 	golang.org/x/time v0.0.0-20210220033141-f8bda1e9f3ba // indirect
 	google.golang.org/genproto v0.0.0-20220505152158-f39f71e6c8f3 // indirect
 	google.golang.org/grpc v1.46.0 // indirect
@@ -0,0 +1,28 @@
+package listeners
+
+import "sync"
+
+// TxNotificationQueue holds the tx and block notifications received by a
+// page until they are handled on the UI goroutine, where the page state they
+// change is read.
+type TxNotificationQueue struct {
+	mu            sync.Mutex
+	notifications []TxNotification
+}
+
+// Push adds n to the queue.
+func (q *TxNotificationQueue) Push(n TxNotification) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.notifications = append(q.notifications, n)
+}
+
+// Take empties the queue and returns the notifications it held, in the order
+// they were received.
+func (q *TxNotificationQueue) Take() []TxNotification {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	notifications := q.notifications
+	q.notifications = nil
+	return notifications
+}
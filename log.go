@@ -6,6 +6,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/jrick/logrotate/rotator"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/listeners"
+	"github.com/planetdecred/godcr/rpcserver"
 	"github.com/planetdecred/godcr/ui"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
@@ -26,13 +28,17 @@ import (
 	"github.com/planetdecred/godcr/wallet"
 )
 
+// logStdout is where logs are printed besides the log file. It is standard
+// error in command line mode so that logs do not mix with command output.
+var logStdout io.Writer = os.Stdout
+
 // logWriter implements an io.Writer that outputs to both standard output and
 // the write-end pipe of an initialized log rotator.
 type logWriter struct{}
 
 // Write writes the data in p to standard out and the log rotator.
 func (l logWriter) Write(p []byte) (n int, err error) {
-	os.Stdout.Write(p)
+	logStdout.Write(p)
 	return logRotator.Write(p)
 }
 
@@ -60,6 +66,7 @@ var (
 	winLog     = backendLog.Logger("UI")
 	dlwlLog    = backendLog.Logger("DLWL")
 	lstnersLog = backendLog.Logger("LSTN")
+	rpcsLog    = backendLog.Logger("RPCS")
 )
 
 // Initialize package-global logger variables.
@@ -77,6 +84,7 @@ func init() {
 	staking.UseLogger(winLog)
 	privacy.UseLogger(winLog)
 	modal.UseLogger(winLog)
+	rpcserver.UseLogger(rpcsLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -86,6 +94,7 @@ var subsystemLoggers = map[string]slog.Logger{
 	"UI":   winLog,
 	"GDCR": log,
 	"LSTN": lstnersLog,
+	"RPCS": rpcsLog,
 }
 
 // initLogRotator initializes the logging rotater to write logs to logFile and
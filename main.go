@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	"gioui.org/app"
 
 	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/cli"
+	"github.com/planetdecred/godcr/rpcserver"
 	"github.com/planetdecred/godcr/ui"
 	_ "github.com/planetdecred/godcr/ui/assets"
 	"github.com/planetdecred/godcr/wallet"
@@ -70,14 +75,54 @@ func main() {
 		return
 	}
 
+	var rpcServer *rpcserver.Server
+	if cfg.RPC {
+		rpcServer, err = rpcserver.New(rpcserver.Config{
+			Listen:           cfg.RPCListen,
+			Username:         cfg.RPCUser,
+			Password:         cfg.RPCPass,
+			ReadOnlyUsername: cfg.RPCLimitUser,
+			ReadOnlyPassword: cfg.RPCLimitPass,
+			CertFile:         cfg.RPCCert,
+			KeyFile:          cfg.RPCKey,
+		}, wal)
+		if err == nil {
+			err = rpcServer.Start()
+		}
+		if err != nil {
+			log.Errorf("Could not start RPC server: %v", err)
+			wal.Shutdown()
+			return
+		}
+	}
+
+	if cfg.NoGUI {
+		err = runNoGUI(cfg, wal)
+		if rpcServer != nil {
+			rpcServer.Stop()
+		}
+		wal.Shutdown()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	win, err := ui.CreateWindow(wal)
 	if err != nil {
 		log.Errorf("Could not initialize window: %s\ns", err)
 		return
 	}
+	if cfg.paymentURI != "" {
+		win.OpenPaymentURI(cfg.paymentURI)
+	}
 
 	go func() {
 		win.HandleEvents() // blocks until the app window is closed
+		if rpcServer != nil {
+			rpcServer.Stop()
+		}
 		wal.Shutdown()
 		os.Exit(0)
 	}()
@@ -85,3 +130,16 @@ func main() {
 	// Start the GUI frontend.
 	app.Main()
 }
+
+// runNoGUI runs the command selected on the command line, or keeps the wallets
+// synced for the RPC server if no command was selected, until it completes or
+// the app is interrupted.
+func runNoGUI(cfg *config, wal *wallet.Wallet) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.command == nil && cfg.RPC {
+		return cli.Serve(ctx, wal)
+	}
+	return cli.Run(ctx, wal, cfg.command, cfg.commandArgs, cfg.JSON)
+}
@@ -0,0 +1,68 @@
+package rpcserver
+
+import (
+	"crypto/elliptic"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/decred/dcrd/certgen"
+)
+
+// certValidity is how long a generated certificate is valid for.
+const certValidity = 10 * 365 * 24 * time.Hour
+
+// loadCertPair loads the TLS certificate and key at certFile and keyFile,
+// generating a self-signed pair that is valid for the host of listen if
+// neither file exists.
+func loadCertPair(certFile, keyFile, listen string) (tls.Certificate, error) {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	switch {
+	case os.IsNotExist(certErr) && os.IsNotExist(keyErr):
+		if err := genCertPair(certFile, keyFile, listen); err != nil {
+			return tls.Certificate{}, err
+		}
+	case os.IsNotExist(certErr) || os.IsNotExist(keyErr):
+		return tls.Certificate{}, fmt.Errorf("rpc certificate %s or key %s is missing, "+
+			"remove the other file to generate a new pair", certFile, keyFile)
+	}
+
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+func genCertPair(certFile, keyFile, listen string) error {
+	log.Infof("Generating TLS certificate for the RPC server")
+
+	var extraHosts []string
+	if host := listenHost(listen); host != "" {
+		extraHosts = append(extraHosts, host)
+	}
+
+	validUntil := time.Now().Add(certValidity)
+	cert, key, err := certgen.NewTLSCertPair(elliptic.P256(), "godcr autogenerated cert", validUntil, extraHosts)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range []string{certFile, keyFile} {
+		if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return err
+		}
+	}
+
+	// Only the key is kept private, clients need the certificate to connect.
+	if err = ioutil.WriteFile(certFile, cert, 0644); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(keyFile, key, 0600); err != nil {
+		os.Remove(certFile)
+		return err
+	}
+
+	log.Infof("RPC certificate written to %s", certFile)
+	return nil
+}
@@ -0,0 +1,328 @@
+package rpcserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// JSON-RPC error codes. Codes above -32000 are specific to this server.
+const (
+	errCodeParse          = -32700
+	errCodeInvalidRequest = -32600
+	errCodeMethodNotFound = -32601
+	errCodeInvalidParams  = -32602
+	errCodeInternal       = -32603
+	errCodePermission     = -32001
+	errCodeWallet         = -32002
+)
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func invalidParams(format string, args ...interface{}) error {
+	return &rpcError{Code: errCodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+type handlerFunc func(ctx context.Context, s *Server, params json.RawMessage) (interface{}, error)
+
+type handler struct {
+	perm permission
+	fn   handlerFunc
+}
+
+// rpcHandlers maps each method to its handler and the permission required to
+// call it.
+var rpcHandlers = map[string]handler{
+	"listwallets":       {permReadOnly, listWallets},
+	"getbalance":        {permReadOnly, getBalance},
+	"getsyncstatus":     {permReadOnly, getSyncStatus},
+	"getcurrentaddress": {permReadOnly, getCurrentAddress},
+	"getnewaddress":     {permSpending, getNewAddress},
+	"sendtoaddress":     {permSpending, sendToAddress},
+	"purchasetickets":   {permSpending, purchaseTickets},
+}
+
+// parseParams decodes the named params of a request into v. Unknown params
+// are rejected so that misspelled names do not go unnoticed.
+func parseParams(params json.RawMessage, v interface{}) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(params))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return invalidParams("invalid params: %v", err)
+	}
+	return nil
+}
+
+// walletWithID returns the opened wallet with the specified ID.
+func (s *Server) walletWithID(id int) (*dcrlibwallet.Wallet, error) {
+	w := s.wal.GetMultiWallet().WalletWithID(id)
+	if w == nil {
+		return nil, &rpcError{Code: errCodeWallet, Message: fmt.Sprintf("wallet %d not found", id)}
+	}
+	return w, nil
+}
+
+// walletError wraps an error returned by dcrlibwallet.
+func walletError(err error) error {
+	return &rpcError{Code: errCodeWallet, Message: err.Error()}
+}
+
+type walletResult struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	WatchingOnly bool   `json:"watchingonly"`
+}
+
+func listWallets(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	wallets := s.wal.GetMultiWallet().AllWallets()
+	result := make([]walletResult, 0, len(wallets))
+	for _, w := range wallets {
+		result = append(result, walletResult{
+			ID:           w.ID,
+			Name:         w.Name,
+			WatchingOnly: w.IsWatchingOnlyWallet(),
+		})
+	}
+	return result, nil
+}
+
+type accountBalanceResult struct {
+	WalletID        int     `json:"walletid"`
+	Account         int32   `json:"account"`
+	Name            string  `json:"name"`
+	Total           float64 `json:"total"`
+	Spendable       float64 `json:"spendable"`
+	Immature        float64 `json:"immature"`
+	LockedByTickets float64 `json:"lockedbytickets"`
+	Unconfirmed     float64 `json:"unconfirmed"`
+}
+
+// getBalance returns the balances of the accounts of a wallet, or of every
+// wallet if no wallet ID is passed.
+func getBalance(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		WalletID *int `json:"walletid"`
+	}
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+
+	wallets := s.wal.GetMultiWallet().AllWallets()
+	if p.WalletID != nil {
+		w, err := s.walletWithID(*p.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		wallets = []*dcrlibwallet.Wallet{w}
+	}
+
+	result := make([]accountBalanceResult, 0)
+	for _, w := range wallets {
+		accounts, err := w.GetAccountsRaw()
+		if err != nil {
+			return nil, walletError(err)
+		}
+		for _, acct := range accounts.Acc {
+			b := acct.Balance
+			result = append(result, accountBalanceResult{
+				WalletID:        w.ID,
+				Account:         acct.Number,
+				Name:            acct.Name,
+				Total:           dcrutil.Amount(b.Total).ToCoin(),
+				Spendable:       dcrutil.Amount(b.Spendable).ToCoin(),
+				Immature:        dcrutil.Amount(b.ImmatureReward + b.ImmatureStakeGeneration).ToCoin(),
+				LockedByTickets: dcrutil.Amount(b.LockedByTickets).ToCoin(),
+				Unconfirmed:     dcrutil.Amount(b.UnConfirmed).ToCoin(),
+			})
+		}
+	}
+	return result, nil
+}
+
+type syncStatusResult struct {
+	Synced         bool  `json:"synced"`
+	Syncing        bool  `json:"syncing"`
+	ConnectedPeers int32 `json:"connectedpeers"`
+	BestBlock      int32 `json:"bestblock"`
+	BestBlockTime  int64 `json:"bestblocktime"`
+}
+
+func getSyncStatus(_ context.Context, s *Server, _ json.RawMessage) (interface{}, error) {
+	mw := s.wal.GetMultiWallet()
+	result := syncStatusResult{
+		Synced:         mw.IsSynced(),
+		Syncing:        mw.IsSyncing(),
+		ConnectedPeers: mw.ConnectedPeers(),
+	}
+	if block := mw.GetBestBlock(); block != nil {
+		result.BestBlock = block.Height
+		result.BestBlockTime = block.Timestamp
+	}
+	return result, nil
+}
+
+type addressParams struct {
+	WalletID int   `json:"walletid"`
+	Account  int32 `json:"account"`
+}
+
+// getCurrentAddress returns the current receive address of an account
+// without generating a new one.
+func getCurrentAddress(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p addressParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	w, err := s.walletWithID(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := w.CurrentAddress(p.Account)
+	if err != nil {
+		return nil, walletError(err)
+	}
+	return addr, nil
+}
+
+// getNewAddress generates a new receive address for an account. It requires
+// the spending permission since it changes the wallet.
+func getNewAddress(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p addressParams
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	w, err := s.walletWithID(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := w.NextAddress(p.Account)
+	if err != nil {
+		return nil, walletError(err)
+	}
+	return addr, nil
+}
+
+// sendToAddress sends an amount in DCR from an account to an address and
+// returns the hash of the broadcast transaction.
+func sendToAddress(_ context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		WalletID   int     `json:"walletid"`
+		Account    int32   `json:"account"`
+		Address    string  `json:"address"`
+		Amount     float64 `json:"amount"`
+		Passphrase string  `json:"passphrase"`
+	}
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Address == "" {
+		return nil, invalidParams("address is required")
+	}
+	amount, err := dcrutil.NewAmount(p.Amount)
+	if err != nil || amount <= 0 {
+		return nil, invalidParams("invalid amount %v", p.Amount)
+	}
+	w, err := s.walletWithID(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The coins are selected with the strategy of the account, frozen coins
+	// are not spent.
+	txAuthor, err := wallet.NewTxAuthor(w, p.Account)
+	if err != nil {
+		return nil, walletError(err)
+	}
+	if err = txAuthor.AddSendDestination(p.Address, int64(amount), false); err != nil {
+		return nil, walletError(err)
+	}
+
+	hash, err := txAuthor.Broadcast([]byte(p.Passphrase))
+	if err != nil {
+		return nil, walletError(err)
+	}
+	txHash, err := chainhash.NewHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("RPC client sent %v to %s in tx %s", amount, p.Address, txHash)
+	return txHash.String(), nil
+}
+
+// purchaseTickets buys tickets with the funds of an account and returns their
+// hashes. The fees are paid to the VSP last used in the app if no VSP is
+// specified.
+func purchaseTickets(ctx context.Context, s *Server, params json.RawMessage) (interface{}, error) {
+	var p struct {
+		WalletID   int    `json:"walletid"`
+		Account    int32  `json:"account"`
+		Count      int32  `json:"count"`
+		VSPHost    string `json:"vsphost"`
+		Passphrase string `json:"passphrase"`
+	}
+	if err := parseParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Count < 1 {
+		return nil, invalidParams("count must be at least 1")
+	}
+	w, err := s.walletWithID(p.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	mw := s.wal.GetMultiWallet()
+	if p.VSPHost == "" {
+		p.VSPHost = mw.LastUsedVSP()
+	}
+	vsp := knownVSP(mw, p.VSPHost)
+	if vsp == nil {
+		// The list of VSPs is only loaded when the staking page is opened.
+		mw.ReloadVSPList(ctx)
+		vsp = knownVSP(mw, p.VSPHost)
+	}
+	if vsp == nil || vsp.VspInfoResponse == nil {
+		return nil, invalidParams("unknown VSP %q, add it in the app first", p.VSPHost)
+	}
+
+	hashes, err := w.PurchaseTickets(p.Account, p.Count, vsp.Host, vsp.PubKey, []byte(p.Passphrase))
+	if err != nil {
+		return nil, walletError(err)
+	}
+
+	tickets := make([]string, len(hashes))
+	for i, hash := range hashes {
+		tickets[i] = hash.String()
+	}
+	log.Infof("RPC client purchased %d tickets", len(tickets))
+	return tickets, nil
+}
+
+func knownVSP(mw *dcrlibwallet.MultiWallet, host string) *dcrlibwallet.VSP {
+	for _, vsp := range mw.KnownVSPs() {
+		if vsp.Host == host {
+			return vsp
+		}
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+// Package rpcserver provides an authenticated JSON-RPC server over HTTPS that
+// lets scripts control the wallets opened by the app.
+package rpcserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const (
+	// maxRequestSize is the maximum size of a request body in bytes.
+	maxRequestSize = 1 << 20
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// permission is the access level of an authenticated client. A client with
+// the spending permission may also call every read-only method.
+type permission int
+
+const (
+	permReadOnly permission = iota
+	permSpending
+)
+
+// Config holds the options the server is started with.
+type Config struct {
+	// Listen is the interface and port the server listens on.
+	Listen string
+	// Username and Password authenticate clients that may call every method,
+	// including those that spend funds.
+	Username, Password string
+	// ReadOnlyUsername and ReadOnlyPassword authenticate clients that may only
+	// call methods that do not change the wallets.
+	ReadOnlyUsername, ReadOnlyPassword string
+	// CertFile and KeyFile are the TLS certificate and key of the server. A
+	// self-signed pair is generated if neither file exists.
+	CertFile, KeyFile string
+}
+
+// Server is a JSON-RPC server that wraps the multiwallet of the app.
+type Server struct {
+	cfg Config
+	wal *wallet.Wallet
+
+	authSHA         [sha256.Size]byte
+	readOnlyAuthSHA [sha256.Size]byte
+	spendingAuth    bool
+	readOnlyAuth    bool
+
+	mtx        sync.Mutex
+	httpServer *http.Server
+}
+
+// New returns a server for the wallets of wal. At least one set of
+// credentials must be configured.
+func New(cfg Config, wal *wallet.Wallet) (*Server, error) {
+	s := &Server{
+		cfg:          cfg,
+		wal:          wal,
+		spendingAuth: cfg.Username != "" && cfg.Password != "",
+		readOnlyAuth: cfg.ReadOnlyUsername != "" && cfg.ReadOnlyPassword != "",
+	}
+
+	if !s.spendingAuth && !s.readOnlyAuth {
+		return nil, errors.New("rpc server requires a username and password")
+	}
+	if s.spendingAuth && s.readOnlyAuth && cfg.Username == cfg.ReadOnlyUsername {
+		return nil, errors.New("rpc server usernames for spending and read-only access must differ")
+	}
+
+	if s.spendingAuth {
+		s.authSHA = basicAuthSHA(cfg.Username, cfg.Password)
+	}
+	if s.readOnlyAuth {
+		s.readOnlyAuthSHA = basicAuthSHA(cfg.ReadOnlyUsername, cfg.ReadOnlyPassword)
+	}
+
+	return s, nil
+}
+
+func basicAuthSHA(username, password string) [sha256.Size]byte {
+	login := username + ":" + password
+	auth := "Basic " + base64.StdEncoding.EncodeToString([]byte(login))
+	return sha256.Sum256([]byte(auth))
+}
+
+// Start loads or generates the TLS certificate and starts serving requests in
+// the background. It returns once the server is listening.
+func (s *Server) Start() error {
+	keyPair, err := loadCertPair(s.cfg.CertFile, s.cfg.KeyFile, s.cfg.Listen)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{keyPair},
+		MinVersion:   tls.VersionTLS12,
+	}
+	listener, err := tls.Listen("tcp", s.cfg.Listen, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:     s,
+		ReadTimeout: time.Minute,
+	}
+
+	s.mtx.Lock()
+	s.httpServer = httpServer
+	s.mtx.Unlock()
+
+	log.Infof("RPC server listening on %s", listener.Addr())
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Errorf("RPC server error: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops the server, waiting a few seconds for running requests to
+// complete.
+func (s *Server) Stop() {
+	s.mtx.Lock()
+	httpServer := s.httpServer
+	s.httpServer = nil
+	s.mtx.Unlock()
+
+	if httpServer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		log.Errorf("RPC server shutdown error: %v", err)
+	}
+	log.Info("RPC server stopped")
+}
+
+// authenticate returns the permission of the credentials of r. ok is false if
+// the credentials are missing or invalid.
+func (s *Server) authenticate(r *http.Request) (perm permission, ok bool) {
+	authSHA := sha256.Sum256([]byte(r.Header.Get("Authorization")))
+
+	// Both hashes are compared so that the time taken does not reveal which
+	// set of credentials was tried.
+	spending := s.spendingAuth && subtle.ConstantTimeCompare(authSHA[:], s.authSHA[:]) == 1
+	readOnly := s.readOnlyAuth && subtle.ConstantTimeCompare(authSHA[:], s.readOnlyAuthSHA[:]) == 1
+
+	switch {
+	case spending:
+		return permSpending, true
+	case readOnly:
+		return permReadOnly, true
+	default:
+		return 0, false
+	}
+}
+
+// request is a JSON-RPC request. Params are passed by name.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	perm, ok := s.authenticate(r)
+	if !ok {
+		log.Warnf("RPC authentication failure from %s", r.RemoteAddr)
+		w.Header().Set("WWW-Authenticate", `Basic realm="godcr RPC"`)
+		http.Error(w, "401 unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	resp := response{JSONRPC: "2.0", ID: json.RawMessage("null")}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		resp.Error = &rpcError{Code: errCodeParse, Message: err.Error()}
+		s.writeResponse(w, resp)
+		return
+	}
+
+	var req request
+	if err = json.Unmarshal(body, &req); err != nil {
+		resp.Error = &rpcError{Code: errCodeParse, Message: "invalid JSON: " + err.Error()}
+		s.writeResponse(w, resp)
+		return
+	}
+	if req.ID != nil {
+		resp.ID = req.ID
+	}
+
+	resp.Result, err = s.call(r.Context(), perm, &req)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: errCodeInternal, Message: err.Error()}
+		}
+		log.Debugf("RPC %s from %s failed: %v", req.Method, r.RemoteAddr, rpcErr)
+		resp.Error = rpcErr
+		resp.Result = nil
+	}
+
+	s.writeResponse(w, resp)
+}
+
+// call runs the handler of the method of req if perm allows it.
+func (s *Server) call(ctx context.Context, perm permission, req *request) (interface{}, error) {
+	if req.Method == "" {
+		return nil, &rpcError{Code: errCodeInvalidRequest, Message: "missing method"}
+	}
+
+	h, ok := rpcHandlers[req.Method]
+	if !ok {
+		return nil, &rpcError{Code: errCodeMethodNotFound, Message: fmt.Sprintf("unknown method %q", req.Method)}
+	}
+	if h.perm > perm {
+		return nil, &rpcError{Code: errCodePermission, Message: fmt.Sprintf("method %q requires spending permission", req.Method)}
+	}
+
+	if s.wal.GetMultiWallet() == nil {
+		return nil, &rpcError{Code: errCodeWallet, Message: "multiwallet is not loaded"}
+	}
+
+	return h.fn(ctx, s, req.Params)
+}
+
+func (s *Server) writeResponse(w http.ResponseWriter, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Errorf("error writing RPC response: %v", err)
+	}
+}
+
+// listenHost returns the host of a listen address, or "" if the server listens
+// on all interfaces.
+func listenHost(listen string) string {
+	host, _, err := net.SplitHostPort(listen)
+	if err != nil || host == "0.0.0.0" || host == "::" {
+		return ""
+	}
+	return host
+}
@@ -0,0 +1,132 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+func testServer(t *testing.T) *Server {
+	s, err := New(Config{
+		Username:         "spender",
+		Password:         "spendpass",
+		ReadOnlyUsername: "reader",
+		ReadOnlyPassword: "readpass",
+	}, &wallet.Wallet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func post(s *Server, user, pass, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	if user != "" {
+		req.SetBasicAuth(user, pass)
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRequiresCredentials(t *testing.T) {
+	if _, err := New(Config{}, &wallet.Wallet{}); err == nil {
+		t.Error("expected an error without credentials")
+	}
+
+	cfg := Config{Username: "user", Password: "a", ReadOnlyUsername: "user", ReadOnlyPassword: "b"}
+	if _, err := New(cfg, &wallet.Wallet{}); err == nil {
+		t.Error("expected an error for the same username in both tiers")
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := testServer(t)
+	body := `{"jsonrpc":"2.0","id":1,"method":"getsyncstatus"}`
+
+	for _, test := range []struct {
+		user, pass string
+		status     int
+	}{
+		{"", "", http.StatusUnauthorized},
+		{"spender", "wrong", http.StatusUnauthorized},
+		{"reader", "spendpass", http.StatusUnauthorized},
+		{"reader", "readpass", http.StatusOK},
+		{"spender", "spendpass", http.StatusOK},
+	} {
+		if rec := post(s, test.user, test.pass, body); rec.Code != test.status {
+			t.Errorf("%s:%s: expected status %d, got %d", test.user, test.pass, test.status, rec.Code)
+		}
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	s := testServer(t)
+
+	errorCode := func(user, pass, method string) int {
+		rec := post(s, user, pass, `{"jsonrpc":"2.0","id":"a","method":"`+method+`"}`)
+		var resp struct {
+			ID    string    `json:"id"`
+			Error *rpcError `json:"error"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: invalid response: %v", method, err)
+		}
+		if resp.ID != "a" {
+			t.Errorf("%s: expected id a, got %q", method, resp.ID)
+		}
+		if resp.Error == nil {
+			return 0
+		}
+		return resp.Error.Code
+	}
+
+	// The multiwallet is not loaded, so permitted methods fail with a wallet
+	// error after the permission check.
+	for _, test := range []struct {
+		user, pass, method string
+		code               int
+	}{
+		{"reader", "readpass", "getbalance", errCodeWallet},
+		{"reader", "readpass", "sendtoaddress", errCodePermission},
+		{"reader", "readpass", "purchasetickets", errCodePermission},
+		{"reader", "readpass", "getnewaddress", errCodePermission},
+		{"spender", "spendpass", "sendtoaddress", errCodeWallet},
+		{"spender", "spendpass", "getbalance", errCodeWallet},
+		{"spender", "spendpass", "nosuchmethod", errCodeMethodNotFound},
+	} {
+		if code := errorCode(test.user, test.pass, test.method); code != test.code {
+			t.Errorf("%s as %s: expected error code %d, got %d", test.method, test.user, test.code, code)
+		}
+	}
+}
+
+func TestInvalidRequest(t *testing.T) {
+	s := testServer(t)
+	rec := post(s, "reader", "readpass", `{"method":`)
+	if !strings.Contains(rec.Body.String(), `"code":-32700`) {
+		t.Errorf("expected a parse error, got %s", rec.Body.String())
+	}
+}
+
+func TestLoadCertPair(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "rpc.cert"), filepath.Join(dir, "rpc.key")
+
+	if _, err := loadCertPair(certFile, keyFile, "127.0.0.1:0"); err != nil {
+		t.Fatalf("generating certificate: %v", err)
+	}
+	// The generated pair is loaded the next time.
+	if _, err := loadCertPair(certFile, keyFile, "127.0.0.1:0"); err != nil {
+		t.Fatalf("loading certificate: %v", err)
+	}
+
+	if _, err := loadCertPair(certFile, filepath.Join(dir, "missing.key"), "127.0.0.1:0"); err == nil {
+		t.Error("expected an error when only the certificate exists")
+	}
+}
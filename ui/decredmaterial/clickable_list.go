@@ -37,13 +37,13 @@ func (cl *ClickableList) ItemClicked() (bool, int) {
 }
 
 func (cl *ClickableList) handleClickables(count int) {
-	if len(cl.clickables) != count {
-
-		cl.clickables = make([]*Clickable, count)
-		for i := 0; i < count; i++ {
-			clickable := cl.theme.NewClickable(cl.IsHoverable)
-			cl.clickables[i] = clickable
-		}
+	// The clickables are kept when the count changes so that long lists
+	// growing page by page don't recreate them.
+	if len(cl.clickables) > count {
+		cl.clickables = cl.clickables[:count]
+	}
+	for len(cl.clickables) < count {
+		cl.clickables = append(cl.clickables, cl.theme.NewClickable(cl.IsHoverable))
 	}
 
 	for index, clickable := range cl.clickables {
@@ -69,6 +69,8 @@ func (cl *ClickableList) Layout(gtx layout.Context, count int, w layout.ListElem
 }
 
 func (cl *ClickableList) row(gtx layout.Context, count int, i int, w layout.ListElement) layout.Dimensions {
+	// The clickable may have been the first or last of a shorter list.
+	cl.clickables[i].Radius = CornerRadius{}
 	if i == 0 { // first item
 		cl.clickables[i].Radius.TopLeft = cl.Radius.TopLeft
 		cl.clickables[i].Radius.TopRight = cl.Radius.TopRight
@@ -219,6 +219,14 @@ func (s *SwitchButtonText) SelectedIndex() int {
 	return s.selected
 }
 
+// SetSelectedIndex selects the item at index, which starts at 1 like the
+// index returned by SelectedIndex.
+func (s *SwitchButtonText) SetSelectedIndex(index int) {
+	if index > 0 && index < len(s.items) {
+		s.selected = index
+	}
+}
+
 func (s *SwitchButtonText) Changed() bool {
 	changed := s.changed
 	s.changed = false
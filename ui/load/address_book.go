@@ -0,0 +1,42 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// AddressBookPath returns the default path of the address book export: a file
+// in the home directory.
+func (wl *WalletLoad) AddressBookPath() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "address-book.json")
+}
+
+// ImportAddressBook adds the contacts of the JSON file at path to the address
+// book. It returns the number of contacts imported.
+func (wl *WalletLoad) ImportAddressBook(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return wl.Wallet.ImportAddressBook(f)
+}
+
+// ExportAddressBook writes the address book to the JSON file at path.
+func (wl *WalletLoad) ExportAddressBook(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = wl.Wallet.ExportAddressBook(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -1,38 +1,119 @@
 package load
 
 import (
-	"encoding/json"
-	"net/http"
+	"context"
+	"os"
+	"time"
 
-	"golang.org/x/text/message"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"golang.org/x/text/language"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
-func GetUSDExchangeValue(target interface{}) error {
-	url := "https://api.bittrex.com/v3/markets/DCR-USDT/ticker"
-	res, err := http.Get(url)
-	// TODO: include user agent in req header
-	if err != nil {
-		return err
+// ExchangeRateSetting returns the exchange rate provider and currency of the
+// currency conversion option selected by the user. ok is false if currency
+// conversion is disabled.
+func (wl *WalletLoad) ExchangeRateSetting() (provider, currency string, ok bool) {
+	option := wl.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
+	if option == values.LegacyUSDExchangeValue {
+		// Bittrex no longer exists, move users that had it selected to the
+		// default USD source.
+		option = values.DefaultUSDExchangeValue
+		wl.MultiWallet.SaveUserConfigValue(dcrlibwallet.CurrencyConversionConfigKey, option)
 	}
 
-	defer res.Body.Close()
+	return values.ParseExchangeValue(option)
+}
+
+// FetchExchangeRate fetches the DCR exchange rate for the currency conversion
+// option selected by the user.
+func (wl *WalletLoad) FetchExchangeRate(ctx context.Context) (*wallet.ExchangeRate, error) {
+	provider, currency, ok := wl.ExchangeRateSetting()
+	if !ok {
+		return nil, wallet.ErrUnknownProvider
+	}
 
-	err = json.NewDecoder(res.Body).Decode(target)
-	if err != nil {
-		return err
+	return wl.Wallet.ExchangeRates().Rate(ctx, provider, currency)
+}
+
+// NewFiatConverter returns a converter for rate that formats fiat amounts for
+// the language selected by the user.
+func NewFiatConverter(rate *wallet.ExchangeRate) *wallet.FiatConverter {
+	return wallet.NewFiatConverter(rate, language.Make(values.UserLanguages[0]))
+}
+
+// FiatConverter returns a converter for the last exchange rate fetched for the
+// currency conversion option selected by the user. nil is returned if currency
+// conversion is disabled or no rate has been fetched yet.
+func (wl *WalletLoad) FiatConverter() *wallet.FiatConverter {
+	provider, currency, ok := wl.ExchangeRateSetting()
+	if !ok {
+		return nil
+	}
+
+	rate := wl.Wallet.ExchangeRates().CachedRate(provider, currency)
+	if rate == nil {
+		return nil
 	}
+	return NewFiatConverter(rate)
+}
 
-	return nil
+// HistoricalFiatConverter returns a converter for the currency selected by the
+// user that is used with TxFiatValue to value transactions at past rates. nil
+// is returned if currency conversion is disabled.
+func (wl *WalletLoad) HistoricalFiatConverter() *wallet.FiatConverter {
+	provider, currency, ok := wl.ExchangeRateSetting()
+	if !ok {
+		return nil
+	}
+	return NewFiatConverter(&wallet.ExchangeRate{Provider: provider, Currency: currency})
 }
 
-func FormatUSDBalance(p *message.Printer, balance float64) string {
-	return p.Sprintf("$%.2f", balance)
+// TxFiatValue returns the value of tx at the rate of the day it was mined,
+// formatted with fiat. An empty string is returned if tx is unmined or the
+// rate of that day is not in the price history.
+func (wl *WalletLoad) TxFiatValue(fiat *wallet.FiatConverter, tx *dcrlibwallet.Transaction) string {
+	if fiat == nil || tx.BlockHeight == -1 {
+		return ""
+	}
+
+	rate, ok := wl.Wallet.PriceHistory().Rate(fiat.Code(), time.Unix(tx.Timestamp, 0))
+	if !ok {
+		return ""
+	}
+
+	return fiat.AtRate(rate).FormatAmount(dcrutil.Amount(wallet.SignedTxAmount(tx)))
 }
 
-func DCRToUSD(exchangeRate, dcr float64) float64 {
-	return dcr * exchangeRate
+// FillPriceHistory fetches the rates of the days txs were mined on that are
+// missing from the price history, in the currency selected by the user. It
+// returns the number of rates added.
+func (wl *WalletLoad) FillPriceHistory(ctx context.Context, txs []dcrlibwallet.Transaction) (int, error) {
+	provider, currency, ok := wl.ExchangeRateSetting()
+	if !ok {
+		return 0, nil
+	}
+
+	times := make([]time.Time, 0, len(txs))
+	for _, tx := range txs {
+		if tx.BlockHeight != -1 {
+			times = append(times, time.Unix(tx.Timestamp, 0))
+		}
+	}
+	return wl.Wallet.PriceHistory().Fill(ctx, wl.Wallet.ExchangeRates(), provider, currency, times)
 }
 
-func USDToDCR(exchangeRate, usd float64) float64 {
-	return usd / exchangeRate
+// ImportPriceHistory adds the rates in the CSV file at path to the price
+// history. It returns the number of rates imported.
+func (wl *WalletLoad) ImportPriceHistory(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return wl.Wallet.PriceHistory().ImportCSV(f)
 }
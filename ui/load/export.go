@@ -0,0 +1,42 @@
+package load
+
+import (
+	"context"
+	"os"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// ExportTransactions writes the transactions of wallets that match opts to the
+// file at path. The missing rates of the days the exported transactions were
+// mined on are fetched first if fiat values are requested so that every mined
+// transaction can be valued. It returns the number of transactions exported.
+func (wl *WalletLoad) ExportTransactions(ctx context.Context, path string, wallets []*dcrlibwallet.Wallet, opts wallet.TxExportOptions) (int, error) {
+	records, err := wl.Wallet.TxExportRecords(wallets, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if opts.FiatCurrency != "" {
+		// The provider of the user is preferred if it has the rates of the
+		// currency exported.
+		provider, _, _ := wl.ExchangeRateSetting()
+		if _, err = wl.Wallet.FillTxExportRecords(ctx, provider, records); err != nil {
+			// Transactions without a rate are exported without a fiat
+			// value.
+			log.Errorf("error fetching price history for export: %v", err)
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	err = wallet.WriteTxExport(f, opts.Format, records)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return len(records), err
+}
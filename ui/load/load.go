@@ -16,10 +16,6 @@ import (
 	"github.com/planetdecred/godcr/wallet"
 )
 
-type DCRUSDTBittrex struct {
-	LastTradeRate string
-}
-
 type Load struct {
 	Theme *decredmaterial.Theme
 
@@ -34,6 +30,10 @@ type Load struct {
 
 	ToggleSync func()
 
+	// PaymentURI is the payment URI the app was launched with, it is opened
+	// on the send page once the wallets are synced.
+	PaymentURI string
+
 	DarkModeSettingChanged func(bool)
 	LanguageSettingChanged func()
 	CurrencySettingChanged func()
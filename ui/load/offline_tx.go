@@ -0,0 +1,43 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// OfflineTxPath returns the default path of the offline transaction file
+// named name: a file in the home directory.
+func (wl *WalletLoad) OfflineTxPath(name string) string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, name+"."+wallet.OfflineTxExtension)
+}
+
+// SaveOfflineTx writes otx to the file at path.
+func (wl *WalletLoad) SaveOfflineTx(path string, otx *wallet.OfflineTx) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = wallet.WriteOfflineTx(f, otx)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// OpenOfflineTx reads the offline transaction in the file at path.
+func (wl *WalletLoad) OpenOfflineTx(path string) (*wallet.OfflineTx, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return wallet.ReadOfflineTx(f)
+}
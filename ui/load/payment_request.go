@@ -0,0 +1,39 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/planetdecred/godcr/wallet"
+	qrcode "github.com/yeqown/go-qrcode"
+)
+
+// PaymentRequestQRPath returns the default path of the QR code image of the
+// payment request r: a file in the home directory.
+func (wl *WalletLoad) PaymentRequestQRPath(r *wallet.PaymentRequest) string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "payment-request-"+r.ID+".png")
+}
+
+// SavePaymentRequestQR writes the QR code of the payment URI of r to the PNG
+// file at path.
+func (wl *WalletLoad) SavePaymentRequestQR(path string, r *wallet.PaymentRequest) error {
+	qrCode, err := qrcode.New(r.URI(), qrcode.WithBuiltinImageEncoder(qrcode.PNG_FORMAT))
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = qrCode.SaveTo(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
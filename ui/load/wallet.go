@@ -3,6 +3,7 @@ package load
 import (
 	"errors"
 	"fmt"
+	"os"
 	"sort"
 
 	"github.com/decred/dcrd/dcrutil/v4"
@@ -115,3 +116,15 @@ func (wl *WalletLoad) DataSize() string {
 	}
 	return fmt.Sprintf("%f GB", float64(v)*1e-9)
 }
+
+// ReadRecipientsCSV reads the recipients of a batch payment from the CSV file
+// at path. The addresses must be valid on the network of the wallets.
+func (wl *WalletLoad) ReadRecipientsCSV(path string) ([]wallet.Recipient, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return wallet.ReadRecipientsCSV(f, wl.MultiWallet.IsAddressValid)
+}
@@ -0,0 +1,147 @@
+package modal
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// ExplorerTemplatesModal edits the URL templates of the block explorer links.
+type ExplorerTemplatesModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	tx      decredmaterial.Editor
+	address decredmaterial.Editor
+	block   decredmaterial.Editor
+	ticket  decredmaterial.Editor
+
+	btnReset    decredmaterial.Button
+	btnPositve  decredmaterial.Button
+	btnNegative decredmaterial.Button
+
+	saveError string
+}
+
+func NewExplorerTemplatesModal(l *load.Load) *ExplorerTemplatesModal {
+	em := &ExplorerTemplatesModal{
+		Load:        l,
+		Modal:       l.Theme.ModalFloatTitle("explorer_templates_modal"),
+		btnReset:    l.Theme.OutlineButton(values.String(values.StrResetToDefaults)),
+		btnPositve:  l.Theme.Button(values.String(values.StrSave)),
+		btnNegative: l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	em.btnPositve.Font.Weight = text.Medium
+	em.btnNegative.Font.Weight = text.Medium
+	em.btnNegative.Margin = layout.Inset{Right: values.MarginPadding8}
+
+	newEditor := func(hint string) decredmaterial.Editor {
+		editor := l.Theme.Editor(new(widget.Editor), hint)
+		editor.Editor.SingleLine, editor.Editor.Submit = true, true
+		return editor
+	}
+	em.tx = newEditor(values.String(values.StrTxTemplate))
+	em.address = newEditor(values.String(values.StrAddressTemplate))
+	em.block = newEditor(values.String(values.StrBlockTemplate))
+	em.ticket = newEditor(values.String(values.StrTicketTemplate))
+
+	em.setTemplates(l.WL.Wallet.ExplorerTemplates())
+
+	return em
+}
+
+func (em *ExplorerTemplatesModal) setTemplates(templates wallet.ExplorerTemplates) {
+	em.tx.Editor.SetText(templates.Tx)
+	em.address.Editor.SetText(templates.Address)
+	em.block.Editor.SetText(templates.Block)
+	em.ticket.Editor.SetText(templates.Ticket)
+}
+
+func (em *ExplorerTemplatesModal) OnResume() {
+	em.tx.Editor.Focus()
+}
+
+func (em *ExplorerTemplatesModal) OnDismiss() {}
+
+func (em *ExplorerTemplatesModal) Handle() {
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(em.tx.Editor, em.address.Editor, em.block.Editor, em.ticket.Editor)
+	if isChanged {
+		em.saveError = ""
+	}
+
+	for em.btnReset.Clicked() {
+		em.saveError = ""
+		em.setTemplates(em.WL.Wallet.DefaultExplorerTemplates())
+	}
+
+	for em.btnPositve.Clicked() || isSubmit {
+		isSubmit = false
+		templates := wallet.ExplorerTemplates{
+			Tx:      em.tx.Editor.Text(),
+			Address: em.address.Editor.Text(),
+			Block:   em.block.Editor.Text(),
+			Ticket:  em.ticket.Editor.Text(),
+		}
+		if err := em.WL.Wallet.SetExplorerTemplates(templates); err != nil {
+			em.saveError = err.Error()
+			continue
+		}
+
+		em.Toast.Notify(values.String(values.StrExplorerTemplatesSaved))
+		em.Dismiss()
+	}
+
+	for em.btnNegative.Clicked() {
+		em.Dismiss()
+	}
+
+	if em.Modal.BackdropClicked(true) {
+		em.Dismiss()
+	}
+}
+
+func (em *ExplorerTemplatesModal) Layout(gtx layout.Context) D {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := em.Theme.H6(values.String(values.StrBlockExplorer))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			info := em.Theme.Body2(values.String(values.StrExplorerTemplatesInfo))
+			info.Color = em.Theme.Color.GrayText2
+			return info.Layout(gtx)
+		},
+		em.tx.Layout,
+		em.address.Layout,
+		em.block.Layout,
+		em.ticket.Layout,
+		func(gtx C) D {
+			if em.saveError == "" {
+				return D{}
+			}
+			return em.Theme.ErrorLabel(em.saveError).Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+				layout.Rigid(em.btnReset.Layout),
+				layout.Flexed(1, func(gtx C) D {
+					return layout.E.Layout(gtx, func(gtx C) D {
+						return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+							layout.Rigid(em.btnNegative.Layout),
+							layout.Rigid(em.btnPositve.Layout),
+						)
+					})
+				}),
+			)
+		},
+	}
+
+	return em.Modal.Layout(gtx, w)
+}
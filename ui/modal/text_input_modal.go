@@ -19,6 +19,7 @@ type TextInputModal struct {
 	showAccountWarnInfo bool
 	isCancelable        bool
 	isEnabled           bool
+	allowEmpty          bool
 
 	textInput decredmaterial.Editor
 	callback  func(string, *TextInputModal) bool
@@ -48,6 +49,20 @@ func (tm *TextInputModal) Hint(hint string) *TextInputModal {
 	return tm
 }
 
+// Text sets the text of the input, e.g. the current value of what is edited.
+func (tm *TextInputModal) Text(text string) *TextInputModal {
+	tm.textInput.Editor.SetText(text)
+	tm.textInput.Editor.MoveCaret(len(text), len(text))
+	return tm
+}
+
+// AllowEmpty enables the positive button when the input is empty, to clear
+// what is edited.
+func (tm *TextInputModal) AllowEmpty(allow bool) *TextInputModal {
+	tm.allowEmpty = allow
+	return tm
+}
+
 func (tm *TextInputModal) SetLoading(loading bool) {
 	tm.isLoading = loading
 	tm.Modal.SetDisabled(loading)
@@ -92,7 +107,7 @@ func (tm *TextInputModal) SetTextWithTemplate(template string) *TextInputModal {
 
 func (tm *TextInputModal) Handle() {
 
-	if editorsNotEmpty(tm.textInput.Editor) {
+	if tm.allowEmpty || editorsNotEmpty(tm.textInput.Editor) {
 		tm.btnPositve.Background = tm.positiveButtonColor
 		tm.isEnabled = true
 	} else {
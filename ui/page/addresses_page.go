@@ -0,0 +1,340 @@
+package page
+
+import (
+	"context"
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+const AddressesPageID = "Addresses"
+
+// addressItem is a row of the addresses page.
+type addressItem struct {
+	wallet.AccountAddress
+	editButton decredmaterial.IconButton
+}
+
+// AddressesPage lists the receiving addresses generated for an account, with
+// their labels and what they received, and warns when too many unused
+// addresses were generated in a row.
+type AddressesPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	listContainer *widget.List
+	backButton    decredmaterial.IconButton
+	selector      *components.AccountSelector
+
+	items []*addressItem
+	// used is the number of used addresses, and gap the number of unused
+	// addresses following the last used address.
+	used, gap int
+
+	// The addresses are loaded in the background, cancelLoad cancels the
+	// running load and its results are received from loadedAddresses.
+	loadGen         int
+	cancelLoad      context.CancelFunc
+	loadedAddresses chan addressesResult
+}
+
+// addressesResult is the result of the load with gen.
+type addressesResult struct {
+	gen       int
+	account   *dcrlibwallet.Account
+	addresses []wallet.AccountAddress
+	err       error
+}
+
+// NewAddressesPage returns the page listing the addresses of account, or of
+// the first account if account is nil.
+func NewAddressesPage(l *load.Load, account *dcrlibwallet.Account) *AddressesPage {
+	pg := &AddressesPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AddressesPageID),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		loadedAddresses: make(chan addressesResult, 1),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.selector = components.NewAccountSelector(pg.Load, nil).
+		Title(values.String(values.StrAccount)).
+		AccountSelected(func(selectedAccount *dcrlibwallet.Account) {
+			pg.loadAddresses()
+		}).
+		AccountValidator(func(account *dcrlibwallet.Account) bool {
+			// Imported addresses are not derived from the account.
+			return account.Number != load.MaxInt32
+		}).
+		IncludeWatchOnly()
+	if account != nil {
+		pg.selector.SetSelectedAccount(account)
+	}
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AddressesPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.selector.ListenForTxNotifications(pg.ctx, pg.ParentWindow())
+	if pg.selector.SelectedAccount() == nil {
+		pg.selector.SelectFirstWalletValidAccount(nil)
+	}
+	pg.loadAddresses()
+}
+
+// loadAddresses loads the addresses of the selected account in the
+// background.
+func (pg *AddressesPage) loadAddresses() {
+	account := pg.selector.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	if pg.cancelLoad != nil {
+		pg.cancelLoad()
+	}
+	var ctx context.Context
+	ctx, pg.cancelLoad = context.WithCancel(pg.ctx)
+	pg.loadGen++
+	gen := pg.loadGen
+
+	go func() {
+		addresses, err := pg.WL.Wallet.AccountAddresses(pg.WL.MultiWallet.WalletWithID(account.WalletID), account.Number)
+		select {
+		case pg.loadedAddresses <- addressesResult{gen: gen, account: account, addresses: addresses, err: err}:
+			pg.ParentWindow().Reload()
+		case <-ctx.Done():
+		}
+	}()
+}
+
+// receiveAddresses lists the addresses of the current load once received.
+func (pg *AddressesPage) receiveAddresses() {
+	for {
+		select {
+		case result := <-pg.loadedAddresses:
+			if result.gen != pg.loadGen {
+				continue
+			}
+			if result.err != nil {
+				log.Errorf("Error loading the addresses of account %s: %v", result.account.Name, result.err)
+				pg.Toast.NotifyError(components.TranslateErr(result.err))
+				continue
+			}
+			pg.listAddresses(result.addresses)
+		default:
+			return
+		}
+	}
+}
+
+func (pg *AddressesPage) listAddresses(addresses []wallet.AccountAddress) {
+	items := make([]*addressItem, len(addresses))
+	used := 0
+	for i, address := range addresses {
+		if address.Used() {
+			used++
+		}
+		item := &addressItem{
+			AccountAddress: address,
+			editButton:     pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ImageEdit))),
+		}
+		item.editButton.Inset, item.editButton.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+		item.editButton.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
+		items[i] = item
+	}
+	pg.items = items
+	pg.used, pg.gap = used, wallet.UnusedAddressGap(addresses)
+}
+
+func (pg *AddressesPage) showLabelModal(item *addressItem) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrLabel)).
+		Text(item.Label).
+		AllowEmpty(true).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrSave), func(label string, tm *modal.TextInputModal) bool {
+			pg.WL.Wallet.SetAddressLabel(item.Address, label)
+			pg.Toast.Notify(values.String(values.StrAddressLabelSaved))
+			pg.loadAddresses()
+			return true
+		})
+
+	textModal.Title(values.String(values.StrEditAddressLabel)).
+		Body(item.Address).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AddressesPage) HandleUserInteractions() {
+	pg.receiveAddresses()
+
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	for _, item := range pg.items {
+		if item.editButton.Button.Clicked() {
+			pg.showLabelModal(item)
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AddressesPage) Layout(gtx C) D {
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(values.String(values.StrAddresses)).Layout)
+						}),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						gtx.Constraints.Min.X = gtx.Constraints.Max.X
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, pg.summaryLayout)
+					})
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+						if len(pg.items) == 0 {
+							txt := pg.Theme.Body1(values.String(values.StrNoAddresses))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Center.Layout(gtx, txt.Layout)
+						}
+						return pg.Theme.List(pg.listContainer).Layout(gtx, len(pg.items), func(gtx C, i int) D {
+							// The most recent addresses first.
+							return pg.addressRow(gtx, pg.items[len(pg.items)-1-i], i == len(pg.items)-1)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+// summaryLayout lays out the account selector, the number of addresses
+// generated and used, and the gap limit warning.
+func (pg *AddressesPage) summaryLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return pg.selector.Layout(pg.ParentWindow(), gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2(values.StringF(values.StrAddressesSummary, len(pg.items), pg.used))
+			txt.Color = pg.Theme.Color.GrayText2
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, txt.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.gap < int(dcrlibwallet.AddressGapLimit) {
+				return D{}
+			}
+			txt := pg.Theme.Body2(values.StringF(values.StrAddressGapWarning, pg.gap, dcrlibwallet.AddressGapLimit))
+			txt.Color = pg.Theme.Color.Danger
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, txt.Layout)
+		}),
+	)
+}
+
+func (pg *AddressesPage) addressRow(gtx C, item *addressItem, last bool) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return pg.layoutAddress(gtx, item)
+			}, item.editButton.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if last {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+	)
+}
+
+// layoutAddress lays out the label, the derivation index, the address and
+// what it received of item.
+func (pg *AddressesPage) layoutAddress(gtx C, item *addressItem) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			label := item.Label
+			if label == "" {
+				label = values.String(values.StrNoLabel)
+			}
+			txt := pg.Theme.Body1(fmt.Sprintf("#%d  %s", item.Index, label))
+			txt.Font.Weight = text.SemiBold
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2(item.Address)
+			txt.Color = pg.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !item.Used() {
+				txt := pg.Theme.Caption(values.String(values.StrUnused))
+				txt.Color = pg.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}
+			received := values.StringF(values.StrAddressReceivedTxs, dcrutil.Amount(item.Received).String(), item.TxCount)
+			txt := pg.Theme.Caption(fmt.Sprintf("%s  •  %s", values.String(values.StrUsed), received))
+			txt.Color = pg.Theme.Color.Success
+			return txt.Layout(gtx)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AddressesPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
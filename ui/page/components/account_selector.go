@@ -30,6 +30,9 @@ type AccountSelector struct {
 
 	accountIsValid func(*dcrlibwallet.Account) bool
 	callback       func(*dcrlibwallet.Account)
+	// includeWatchOnly lists the accounts of watch-only wallets, which are
+	// hidden by default.
+	includeWatchOnly bool
 
 	openSelectorDialog *decredmaterial.Clickable
 	selectorModal      *AccountSelectorModal
@@ -63,6 +66,13 @@ func (as *AccountSelector) AccountValidator(accountIsValid func(*dcrlibwallet.Ac
 	return as
 }
 
+// IncludeWatchOnly lists the accounts of the watch-only wallets, which are
+// hidden by default, if the account validator allows them.
+func (as *AccountSelector) IncludeWatchOnly() *AccountSelector {
+	as.includeWatchOnly = true
+	return as
+}
+
 func (as *AccountSelector) AccountSelected(callback func(*dcrlibwallet.Account)) *AccountSelector {
 	as.callback = callback
 	return as
@@ -79,6 +89,7 @@ func (as *AccountSelector) Handle(window app.WindowNavigator) {
 		as.selectorModal = newAccountSelectorModal(as.Load, as.selectedAccount, as.selectedWallet).
 			title(as.dialogTitle).
 			accountValidator(as.accountIsValid).
+			watchOnly(as.includeWatchOnly).
 			accountSelected(func(account *dcrlibwallet.Account) {
 				if as.selectedAccount.Number != account.Number {
 					as.changed = true
@@ -292,7 +303,8 @@ type AccountSelectorModal struct {
 
 	dialogTitle string
 
-	isCancelable bool
+	isCancelable     bool
+	includeWatchOnly bool
 }
 
 type selectorAccount struct {
@@ -329,7 +341,7 @@ func (asm *AccountSelectorModal) setupWalletAccounts() {
 	walletAccounts := make(map[int][]*selectorAccount)
 
 	for _, wal := range asm.WL.SortedWalletList() {
-		if wal.IsWatchingOnlyWallet() {
+		if wal.IsWatchingOnlyWallet() && !asm.includeWatchOnly {
 			continue
 		}
 
@@ -409,6 +421,11 @@ func (asm *AccountSelectorModal) accountValidator(accountIsValid func(*dcrlibwal
 	return asm
 }
 
+func (asm *AccountSelectorModal) watchOnly(include bool) *AccountSelectorModal {
+	asm.includeWatchOnly = include
+	return asm
+}
+
 func (asm *AccountSelectorModal) accountSelected(callback func(*dcrlibwallet.Account)) *AccountSelectorModal {
 	asm.callback = callback
 	return asm
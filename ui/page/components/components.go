@@ -21,6 +21,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
@@ -38,6 +39,10 @@ type (
 		Transaction dcrlibwallet.Transaction
 		Index       int
 		ShowBadge   bool
+		FiatValue   string // value at the time the transaction was mined
+		// PendingBroadcast is true for the transactions signed while offline
+		// that wait for peers to be broadcast.
+		PendingBroadcast bool
 	}
 
 	TxStatus struct {
@@ -503,13 +508,36 @@ func LayoutTransactionRow(gtx layout.Context, l *load.Load, row TransactionRow)
 								}),
 							)
 						}),
+						layout.Rigid(func(gtx C) D {
+							if row.FiatValue == "" {
+								return D{}
+							}
+
+							label := l.Theme.Label(values.TextSize12, row.FiatValue)
+							label.Color = l.Theme.Color.GrayText2
+							return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, label.Layout)
+						}),
+						layout.Rigid(func(gtx C) D {
+							txLabel := l.WL.Wallet.TxLabelOf(row.Transaction.WalletID, row.Transaction.Hash)
+							if txLabel.Label == "" {
+								return D{}
+							}
+
+							label := l.Theme.Label(values.TextSize12, txLabel.Label)
+							label.Color = l.Theme.Color.Primary
+							label.MaxLines = 1
+							return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, label.Layout)
+						}),
 					)
 				}),
 			)
 		}),
 		layout.Flexed(1, func(gtx C) D {
 			status := l.Theme.Body1(values.String(values.StrPending))
-			if TxConfirmations(l, row.Transaction) <= 1 {
+			if row.PendingBroadcast {
+				status.Text = values.String(values.StrPendingBroadcast)
+				status.Color = l.Theme.Color.Orange
+			} else if TxConfirmations(l, row.Transaction) <= 1 {
 				status.Color = l.Theme.Color.GrayText1
 			} else {
 				status.Color = l.Theme.Color.GrayText2
@@ -739,13 +767,31 @@ func CreateOrderDropDown(l *load.Load, grp uint, pos uint) *decredmaterial.DropD
 		{Text: values.String(values.StrOldest)}}, grp, pos)
 }
 
+// errorCodeStrings maps the wallet error codes to their translated message.
+var errorCodeStrings = map[wallet.ErrorCode]string{
+	wallet.ErrCodeBadPassphrase:     values.StrInvalidPassphrase,
+	wallet.ErrCodeInsufficientFunds: values.StrErrInsufficientFunds,
+	wallet.ErrCodeNotSynced:         values.StrErrNotSynced,
+	wallet.ErrCodeNoPeers:           values.StrErrNoPeers,
+	wallet.ErrCodeVSP:               values.StrErrVSP,
+	wallet.ErrCodePoliteia:          values.StrErrPoliteia,
+	wallet.ErrCodeDEX:               values.StrErrDEX,
+}
+
+// TranslateErr returns the message shown to the user for err: the translated
+// message of its wallet.ErrorCode followed by its technical details. Errors
+// without a code are shown as is.
 func TranslateErr(err error) string {
-	switch err.Error() {
-	case dcrlibwallet.ErrInvalidPassphrase:
-		return values.String(values.StrInvalidPassphrase)
+	key, ok := errorCodeStrings[wallet.ErrorCodeOf(err)]
+	if !ok {
+		return err.Error()
 	}
 
-	return err.Error()
+	details := wallet.ErrorDetails(err)
+	if details == "" {
+		return values.String(key)
+	}
+	return values.StringF(values.StrErrDetails, values.String(key), details)
 }
 
 // CoinImageBySymbol returns image widget for supported asset coins.
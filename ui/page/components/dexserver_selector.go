@@ -11,6 +11,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const DexServerSelectorID = "dex_server_selector"
@@ -60,7 +61,7 @@ func (ds *DexServerSelector) isLoadingDexClient() bool {
 func (ds *DexServerSelector) startDexClient() {
 	_, err := ds.WL.MultiWallet.StartDexClient()
 	if err != nil {
-		ds.Toast.NotifyError(err.Error())
+		ds.Toast.NotifyError(TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 		return
 	}
 
@@ -68,7 +69,7 @@ func (ds *DexServerSelector) startDexClient() {
 	if !ds.Dexc().Initialized() {
 		err = ds.Dexc().InitializeWithPassword([]byte(values.DEXClientPass))
 		if err != nil {
-			ds.Toast.NotifyError(err.Error())
+			ds.Toast.NotifyError(TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 	}
@@ -76,7 +77,7 @@ func (ds *DexServerSelector) startDexClient() {
 	if !ds.Dexc().IsLoggedIn() {
 		err := ds.Dexc().Login([]byte(values.DEXClientPass))
 		if err != nil {
-			ds.Toast.NotifyError(err.Error())
+			ds.Toast.NotifyError(TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 	}
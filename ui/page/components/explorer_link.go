@@ -0,0 +1,70 @@
+package components
+
+import (
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// ShowExplorerLink displays a block explorer link in a modal with a button to
+// copy it. Nothing is displayed if redirectURL is empty, which is the case on
+// networks without an explorer.
+func ShowExplorerLink(l *load.Load, window app.WindowNavigator, title, redirectURL string) {
+	if redirectURL == "" {
+		return
+	}
+
+	copyRedirectURL := l.Theme.NewClickable(false)
+	info := modal.NewInfoModal(l).
+		Title(title).
+		Body(values.String(values.StrCopyLink)).
+		SetCancelable(true).
+		UseCustomWidget(func(gtx C) D {
+			return layout.Stack{}.Layout(gtx,
+				layout.Stacked(func(gtx C) D {
+					border := widget.Border{Color: l.Theme.Color.Gray4, CornerRadius: values.MarginPadding10, Width: values.MarginPadding2}
+					wrapper := l.Theme.Card()
+					wrapper.Color = l.Theme.Color.Gray4
+					return border.Layout(gtx, func(gtx C) D {
+						return wrapper.Layout(gtx, func(gtx C) D {
+							return layout.UniformInset(values.MarginPadding10).Layout(gtx, func(gtx C) D {
+								return layout.Flex{}.Layout(gtx,
+									layout.Flexed(0.9, l.Theme.Body1(redirectURL).Layout),
+									layout.Flexed(0.1, func(gtx C) D {
+										return layout.E.Layout(gtx, func(gtx C) D {
+											return layout.Inset{Top: values.MarginPadding7}.Layout(gtx, func(gtx C) D {
+												if copyRedirectURL.Clicked() {
+													clipboard.WriteOp{Text: redirectURL}.Add(gtx.Ops)
+													l.Toast.Notify(values.String(values.StrCopied))
+												}
+												return copyRedirectURL.Layout(gtx, l.Theme.Icons.CopyIcon.Layout24dp)
+											})
+										})
+									}),
+								)
+							})
+						})
+					})
+				}),
+				layout.Stacked(func(gtx C) D {
+					return layout.Inset{
+						Top:  values.MarginPaddingMinus10,
+						Left: values.MarginPadding10,
+					}.Layout(gtx, func(gtx C) D {
+						label := l.Theme.Body2(values.String(values.StrWebURL))
+						label.Color = l.Theme.Color.GrayText2
+						return label.Layout(gtx)
+					})
+				}),
+			)
+		}).
+		PositiveButton(values.String(values.StrGotIt), func(isChecked bool) bool {
+			return true
+		})
+	window.ShowModal(info)
+}
@@ -0,0 +1,133 @@
+package components
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// LayoutOfflineTx lays out the inputs, the outputs and the fee of a
+// transaction signed offline, for the user to check it at every step.
+func LayoutOfflineTx(gtx layout.Context, l *load.Load, tx *wallet.DecodedOfflineTx) D {
+	status := values.String(values.StrUnsigned)
+	if tx.Signed {
+		status = values.String(values.StrSigned)
+	}
+
+	children := []layout.FlexChild{
+		layout.Rigid(func(gtx C) D {
+			return offlineTxRow(gtx, l, values.String(values.StrTransactionID), tx.Hash)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return offlineTxRow(gtx, l, values.String(values.StrStatus), status)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return offlineTxHeader(gtx, l, values.StringF(values.StrTxInputs, len(tx.Inputs)))
+		}),
+	}
+	for _, in := range tx.Inputs {
+		in := in
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return offlineTxIO(gtx, l, in)
+		}))
+	}
+	children = append(children, layout.Rigid(func(gtx C) D {
+		return offlineTxHeader(gtx, l, values.StringF(values.StrTxOutputs, len(tx.Outputs)))
+	}))
+	for _, out := range tx.Outputs {
+		out := out
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return offlineTxIO(gtx, l, out)
+		}))
+	}
+	children = append(children,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return offlineTxRow(gtx, l, values.String(values.StrFee), tx.Fee.String())
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !tx.FeeUnverified {
+				return D{}
+			}
+			return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+				txt := l.Theme.Caption(values.String(values.StrFeeUnverified))
+				txt.Color = l.Theme.Color.Orange
+				return txt.Layout(gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return offlineTxRow(gtx, l, values.String(values.StrSize), fmt.Sprintf("%d bytes", tx.Size))
+		}),
+	)
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func offlineTxHeader(gtx layout.Context, l *load.Load, title string) D {
+	return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		txt := l.Theme.Body1(title)
+		txt.Font.Weight = text.SemiBold
+		return txt.Layout(gtx)
+	})
+}
+
+func offlineTxRow(gtx layout.Context, l *load.Load, label, value string) D {
+	return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		return EndToEndRow(gtx, func(gtx C) D {
+			txt := l.Theme.Body2(label)
+			txt.Color = l.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}, func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, l.Theme.Body2(value).Layout)
+		})
+	})
+}
+
+// offlineTxIO lays out an input, with its outpoint, or an output of a
+// transaction signed offline. The inputs whose amount is not verified and the
+// outputs paying the wallet are marked.
+func offlineTxIO(gtx layout.Context, l *load.Load, io wallet.DecodedTxIO) D {
+	return layout.Inset{Bottom: values.MarginPadding4}.Layout(gtx, func(gtx C) D {
+		return EndToEndRow(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(l.Theme.Body2(io.Address).Layout),
+				layout.Rigid(func(gtx C) D {
+					if io.Outpoint == "" {
+						return D{}
+					}
+					txt := l.Theme.Caption(io.Outpoint)
+					txt.Color = l.Theme.Color.GrayText2
+					return txt.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					if !io.Own {
+						return D{}
+					}
+					txt := l.Theme.Caption(values.String(values.StrPaysThisWallet))
+					txt.Color = l.Theme.Color.Success
+					return txt.Layout(gtx)
+				}),
+			)
+		}, func(gtx C) D {
+			return layout.Inset{Left: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Vertical, Alignment: layout.End}.Layout(gtx,
+					layout.Rigid(l.Theme.Body2(io.Amount.String()).Layout),
+					layout.Rigid(func(gtx C) D {
+						if !io.Unverified {
+							return D{}
+						}
+						txt := l.Theme.Caption(values.String(values.StrAmountUnverified))
+						txt.Color = l.Theme.Color.Orange
+						return txt.Layout(gtx)
+					}),
+				)
+			})
+		})
+	})
+}
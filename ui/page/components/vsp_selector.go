@@ -14,6 +14,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type VSPSelector struct {
@@ -166,7 +167,7 @@ func (v *vspSelectorModal) Handle() {
 		go func() {
 			err := v.WL.MultiWallet.SaveVSP(v.inputVSP.Editor.Text())
 			if err != nil {
-				v.Toast.NotifyError(err.Error())
+				v.Toast.NotifyError(TranslateErr(wallet.NewError(wallet.ErrCodeVSP, err)))
 			} else {
 				v.inputVSP.Editor.SetText("")
 			}
@@ -137,7 +137,7 @@ func (ws *WalletSelector) deleteBadWallet(badWalletID int) {
 			go func() {
 				err := ws.WL.MultiWallet.DeleteBadWallet(badWalletID)
 				if err != nil {
-					ws.Toast.NotifyError(err.Error())
+					ws.Toast.NotifyError(TranslateErr(err))
 					return
 				}
 				ws.Toast.Notify(values.String(values.StrWalletRemoved))
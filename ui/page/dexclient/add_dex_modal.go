@@ -13,6 +13,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type AddDexModal struct {
@@ -100,7 +101,7 @@ func (md *AddDexModal) doAddDexServer(serverAddr string) {
 
 		dexServer, paid, err := md.Dexc().Core().DiscoverAccount(serverAddr, []byte(DEXClientPass), cert)
 		if err != nil {
-			md.Toast.NotifyError(err.Error())
+			md.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 
@@ -189,7 +190,7 @@ func (md *AddDexModal) payFeeAndRegister(dexServer *core.Exchange, cert []byte)
 					if err != nil {
 						assetSelectorModal.SetLoading(false)
 						assetSelectorModal.Modal.SetDisabled(false) // re-enable fee asset selection
-						assetSelectorModal.Toast.NotifyError(err.Error())
+						assetSelectorModal.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 						return
 					}
 					assetSelectorModal.Dismiss()
@@ -17,6 +17,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type createWalletModal struct {
@@ -81,7 +82,7 @@ func (md *createWalletModal) OnResume() {
 
 	err := md.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
 	if err != nil {
-		md.Toast.NotifyError(err.Error())
+		md.Toast.NotifyError(components.TranslateErr(err))
 	}
 }
 
@@ -166,7 +167,7 @@ func (md *createWalletModal) doCreateWallet(walletPass []byte) {
 
 		err := md.Dexc().AddWallet(coinID, walletType, settings, []byte(DEXClientPass), walletPass)
 		if err != nil {
-			md.Toast.NotifyError(err.Error())
+			md.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 
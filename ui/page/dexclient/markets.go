@@ -14,6 +14,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type (
@@ -162,7 +163,7 @@ func (pg *Page) HandleUserInteractions() {
 	if pg.syncBtn.Button.Clicked() {
 		err := pg.WL.MultiWallet.SpvSync()
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(err))
 		}
 	}
 
@@ -186,7 +187,7 @@ func (pg *Page) isLoadingDexClient() bool {
 func (pg *Page) startDexClient() {
 	_, err := pg.WL.MultiWallet.StartDexClient()
 	if err != nil {
-		pg.Toast.NotifyError(err.Error())
+		pg.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 		return
 	}
 
@@ -194,7 +195,7 @@ func (pg *Page) startDexClient() {
 	if !pg.Dexc().Initialized() {
 		err = pg.Dexc().InitializeWithPassword([]byte(DEXClientPass))
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 	}
@@ -202,7 +203,7 @@ func (pg *Page) startDexClient() {
 	if !pg.Dexc().IsLoggedIn() {
 		err := pg.Dexc().Login([]byte(DEXClientPass))
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeDEX, err)))
 			return
 		}
 	}
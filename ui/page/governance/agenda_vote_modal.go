@@ -92,7 +92,7 @@ func (avm *agendaVoteModal) FetchUnspentUnexpiredTickets(walletID int) {
 		wallet := avm.WL.MultiWallet.WalletWithID(walletID)
 		tickets, err := wallet.UnspentUnexpiredTickets()
 		if err != nil {
-			avm.Toast.NotifyError(err.Error())
+			avm.Toast.NotifyError(components.TranslateErr(err))
 			return
 		}
 
@@ -234,7 +234,7 @@ func (avm *agendaVoteModal) sendVotes() {
 			if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
 				avm.spendingPassword.SetError(values.String(values.StrInvalidPassphrase))
 			} else {
-				avm.Toast.NotifyError(err.Error())
+				avm.Toast.NotifyError(components.TranslateErr(err))
 			}
 			return
 		}
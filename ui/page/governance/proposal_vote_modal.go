@@ -15,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type voteModal struct {
@@ -156,7 +157,7 @@ func (vm *voteModal) sendVotes() {
 			go func() {
 				err := vm.WL.MultiWallet.Politeia.CastVotes(vm.walletSelector.selectedWallet.ID, votes, vm.proposal.Token, password)
 				if err != nil {
-					pm.SetError(err.Error())
+					pm.SetError(components.TranslateErr(wallet.NewError(wallet.ErrCodePoliteia, err)))
 					pm.SetLoading(false)
 					return
 				}
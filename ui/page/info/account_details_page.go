@@ -341,7 +341,7 @@ func (pg *AcctDetailsPage) HandleUserInteractions() {
 			PositiveButton(values.String(values.StrRename), func(newName string, tim *modal.TextInputModal) bool {
 				err := pg.wallet.RenameAccount(pg.account.Number, newName)
 				if err != nil {
-					tim.SetError(err.Error())
+					tim.SetError(components.TranslateErr(err))
 					tim.SetLoading(false)
 					return false
 				}
@@ -83,21 +83,27 @@ type MainPage struct {
 	openWalletSelector     *decredmaterial.Clickable
 
 	// page state variables
-	dcrUsdtBittrex load.DCRUSDTBittrex
-	totalBalance   dcrutil.Amount
+	exchangeRate *wallet.ExchangeRate
+	totalBalance dcrutil.Amount
 
-	usdExchangeSet         bool
+	fiatExchangeSet        bool
 	isFetchingExchangeRate bool
 	isBalanceHidden        bool
 	isNavExpanded          bool
 	setNavExpanded         func()
-	totalBalanceUSD        string
+	totalBalanceFiat       string
+
+	// notifiedPaymentRuns is the latest due run of each scheduled payment the
+	// user was notified of, by payment ID.
+	notifiedPaymentRuns map[string]time.Time
 }
 
 func NewMainPage(l *load.Load) *MainPage {
 	mp := &MainPage{
 		Load:       l,
 		MasterPage: app.NewMasterPage(MainPageID),
+
+		notifiedPaymentRuns: make(map[string]time.Time),
 	}
 
 	mp.hideBalanceItem.hideBalanceButton = mp.Theme.IconButton(mp.Theme.Icons.ConcealIcon)
@@ -276,6 +282,9 @@ func (mp *MainPage) OnNavigatedTo() {
 
 	mp.ctx, mp.ctxCancel = context.WithCancel(context.TODO())
 	mp.listenForNotifications()
+	mp.listenForScheduledPayments(mp.ctx)
+	mp.listenForQueuedTxs(mp.ctx)
+	mp.WL.Wallet.BuildTxIndex()
 
 	if mp.CurrentPage() == nil {
 		mp.Display(info.NewInfoPage(mp.Load)) // TODO: Should pagestack have a start page?
@@ -296,8 +305,21 @@ func (mp *MainPage) OnNavigatedTo() {
 		}
 	}
 
+	mp.updateExchangeSetting()
 	mp.updateBalance()
+}
+
+// openPaymentURI opens the payment URI the app was launched with on the send
+// page.
+func (mp *MainPage) openPaymentURI() {
+	uri := mp.PaymentURI
+	mp.PaymentURI = ""
 
+	sendPage := send.NewSendPage(mp.Load)
+	mp.Display(sendPage)
+	if err := sendPage.OpenPaymentURI(uri); err != nil {
+		mp.Toast.NotifyError(values.String(values.StrInvalidPaymentURI))
+	}
 }
 
 func (mp *MainPage) setLanguageSetting() {
@@ -314,12 +336,15 @@ func (mp *MainPage) updateExchangeSetting() {
 		mp.WL.MultiWallet.SaveUserConfigValue(dcrlibwallet.CurrencyConversionConfigKey, values.DefaultExchangeValue)
 	}
 
-	usdExchangeSet := currencyExchangeValue == values.USDExchangeValue
-	if mp.usdExchangeSet == usdExchangeSet {
+	provider, currency, fiatExchangeSet := mp.WL.ExchangeRateSetting()
+	if mp.fiatExchangeSet == fiatExchangeSet && (mp.exchangeRate == nil ||
+		(mp.exchangeRate.Provider == provider && mp.exchangeRate.Currency == currency)) {
 		return // nothing has changed
 	}
-	mp.usdExchangeSet = usdExchangeSet
-	if mp.usdExchangeSet {
+	mp.fiatExchangeSet = fiatExchangeSet
+	mp.exchangeRate = nil
+	mp.totalBalanceFiat = ""
+	if mp.fiatExchangeSet {
 		go mp.fetchExchangeRate()
 	}
 }
@@ -331,16 +356,17 @@ func (mp *MainPage) fetchExchangeRate() {
 	maxAttempts := 5
 	delayBtwAttempts := 2 * time.Second
 	mp.isFetchingExchangeRate = true
-	desc := "for getting dcrUsdtBittrex exchange rate value"
-	attempts, err := components.RetryFunc(maxAttempts, delayBtwAttempts, desc, func() error {
-		return load.GetUSDExchangeValue(&mp.dcrUsdtBittrex)
+	desc := "for getting the dcr exchange rate value"
+	var rate *wallet.ExchangeRate
+	attempts, err := components.RetryFunc(maxAttempts, delayBtwAttempts, desc, func() (err error) {
+		rate, err = mp.WL.FetchExchangeRate(mp.ctx)
+		return err
 	})
 	if err != nil {
-		log.Errorf("error fetching usd exchange rate value after %d attempts: %v", attempts, err)
-	} else if mp.dcrUsdtBittrex.LastTradeRate == "" {
-		log.Errorf("no error while fetching usd exchange rate in %d tries, but no rate was fetched", attempts)
+		log.Errorf("error fetching exchange rate value after %d attempts: %v", attempts, err)
 	} else {
-		log.Infof("exchange rate value fetched: %s", mp.dcrUsdtBittrex.LastTradeRate)
+		log.Infof("exchange rate value fetched from %s: %f %s", rate.Provider, rate.Rate, rate.Currency)
+		mp.exchangeRate = rate
 		mp.updateBalance()
 		mp.ParentWindow().Reload()
 	}
@@ -352,12 +378,8 @@ func (mp *MainPage) updateBalance() {
 	if err == nil {
 		mp.totalBalance = totalBalance.Total
 
-		if mp.usdExchangeSet && mp.dcrUsdtBittrex.LastTradeRate != "" {
-			usdExchangeRate, err := strconv.ParseFloat(mp.dcrUsdtBittrex.LastTradeRate, 64)
-			if err == nil {
-				balanceInUSD := totalBalance.Total.ToCoin() * usdExchangeRate
-				mp.totalBalanceUSD = load.FormatUSDBalance(mp.Printer, balanceInUSD)
-			}
+		if mp.fiatExchangeSet && mp.exchangeRate != nil {
+			mp.totalBalanceFiat = load.NewFiatConverter(mp.exchangeRate).FormatAmount(totalBalance.Total)
 		}
 	}
 }
@@ -442,6 +464,10 @@ func (mp *MainPage) HandleUserInteractions() {
 		go mp.fetchExchangeRate()
 	}
 
+	if mp.PaymentURI != "" && mp.WL.MultiWallet.IsSynced() {
+		mp.openPaymentURI()
+	}
+
 	// darkmode settings
 	for mp.darkmode.Clicked() {
 		isDarkModeOn := mp.WL.MultiWallet.ReadBoolConfigValueForKey(load.DarkModeConfigKey, false)
@@ -563,7 +589,9 @@ func (mp *MainPage) HandleUserInteractions() {
 				continue
 			}
 
-			if mp.WL.MultiWallet.IsSynced() {
+			// Payments composed offline are queued until peers connect.
+			offlineSend := i == 0 && !mp.WL.MultiWallet.IsConnectedToDecredNetwork()
+			if mp.WL.MultiWallet.IsSynced() || offlineSend {
 				mp.Display(pg)
 			} else if mp.WL.MultiWallet.IsSyncing() {
 				mp.Toast.NotifyError(values.String(values.StrWalletSyncing))
@@ -687,12 +715,12 @@ func (mp *MainPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 	)
 }
 
-func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
-	if !mp.usdExchangeSet {
+func (mp *MainPage) LayoutFiatBalance(gtx layout.Context) layout.Dimensions {
+	if !mp.fiatExchangeSet {
 		return D{}
 	}
 	switch {
-	case mp.isFetchingExchangeRate && mp.dcrUsdtBittrex.LastTradeRate == "":
+	case mp.isFetchingExchangeRate && mp.exchangeRate == nil:
 		gtx.Constraints.Max.Y = gtx.Dp(values.MarginPadding18)
 		gtx.Constraints.Max.X = gtx.Constraints.Max.Y
 		return layout.Inset{
@@ -702,7 +730,7 @@ func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
 			loader := material.Loader(mp.Theme.Base)
 			return loader.Layout(gtx)
 		})
-	case !mp.isFetchingExchangeRate && mp.dcrUsdtBittrex.LastTradeRate == "":
+	case !mp.isFetchingExchangeRate && mp.exchangeRate == nil:
 		return layout.Inset{
 			Top:  values.MarginPadding7,
 			Left: values.MarginPadding5,
@@ -711,7 +739,7 @@ func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
 				return mp.Theme.Icons.Restore.Layout16dp(gtx)
 			})
 		})
-	case len(mp.totalBalanceUSD) > 0:
+	case len(mp.totalBalanceFiat) > 0:
 		inset := layout.Inset{
 			Top:  values.MarginPadding3,
 			Left: values.MarginPadding8,
@@ -728,7 +756,7 @@ func (mp *MainPage) LayoutUSDBalance(gtx layout.Context) layout.Dimensions {
 				return padding.Layout(gtx, func(gtx C) D {
 					return layout.Flex{}.Layout(gtx,
 						layout.Rigid(mp.Theme.Body1("/").Layout),
-						layout.Rigid(mp.Theme.Body2(mp.totalBalanceUSD).Layout),
+						layout.Rigid(mp.Theme.Body2(mp.totalBalanceFiat).Layout),
 					)
 				})
 			})
@@ -813,7 +841,7 @@ func (mp *MainPage) LayoutTopBar(gtx layout.Context) layout.Dimensions {
 							}),
 							layout.Rigid(func(gtx C) D {
 								if !mp.isBalanceHidden {
-									return mp.LayoutUSDBalance(gtx)
+									return mp.LayoutFiatBalance(gtx)
 								}
 								return D{}
 							}),
@@ -893,6 +921,76 @@ func initializeBeepNotification(n string) {
 	}
 }
 
+// listenForScheduledPayments starts a goroutine checking every minute for
+// the scheduled payments that are due until ctx is canceled. The payments
+// due when the app starts include the runs missed while it was closed.
+func (mp *MainPage) listenForScheduledPayments(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			mp.notifyDueScheduledPayments()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// listenForQueuedTxs starts a goroutine broadcasting the transactions queued
+// while offline once peers are connected, until ctx is canceled.
+func (mp *MainPage) listenForQueuedTxs(ctx context.Context) {
+	mp.WL.Wallet.LockQueuedInputs()
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				broadcast, rejected, err := mp.WL.Wallet.BroadcastQueuedTxs(ctx)
+				if err != nil {
+					log.Errorf("error broadcasting queued transactions: %v", err)
+				}
+				if len(broadcast) > 0 {
+					mp.Toast.Notify(values.StringF(values.StrQueuedTxsBroadcast, len(broadcast)))
+				}
+				for _, q := range rejected {
+					mp.Toast.NotifyError(values.StringF(values.StrQueuedTxRejected, q.Hash, q.LastError))
+				}
+				if len(broadcast) > 0 || len(rejected) > 0 {
+					mp.updateBalance()
+					mp.ParentWindow().Reload()
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// notifyDueScheduledPayments notifies the user once of each run of the
+// scheduled payments that becomes due, the payments being made from the
+// scheduled payments page.
+func (mp *MainPage) notifyDueScheduledPayments() {
+	now := time.Now()
+	for _, payment := range mp.WL.Wallet.DueScheduledPayments(now) {
+		count, latest := payment.DueRuns(now)
+		if notified, ok := mp.notifiedPaymentRuns[payment.ID]; ok && !latest.After(notified) {
+			continue
+		}
+		mp.notifiedPaymentRuns[payment.ID] = latest
+
+		notification := values.StringF(values.StrScheduledPaymentDue, payment.Label)
+		if count > 1 {
+			notification = values.StringF(values.StrScheduledPaymentMissed, payment.Label, count)
+		}
+		initializeBeepNotification(notification)
+		mp.Toast.Notify(notification)
+	}
+}
+
 // listenForNotifications starts a goroutine to watch for notifications
 // and update the UI accordingly.
 func (mp *MainPage) listenForNotifications() {
@@ -934,6 +1032,8 @@ func (mp *MainPage) listenForNotifications() {
 				switch n.Type {
 				case listeners.NewTransaction:
 					mp.updateBalance()
+					mp.WL.Wallet.TxIndex().AddTx(n.Transaction)
+					notifyPaymentRequests(mp.Load, mp.WL.Wallet.UpdatePaymentRequests(n.Transaction))
 					transactionNotification := mp.WL.MultiWallet.ReadBoolConfigValueForKey(load.TransactionNotificationConfigKey, false)
 					if transactionNotification {
 						update := wallet.NewTransaction{
@@ -955,6 +1055,7 @@ func (mp *MainPage) listenForNotifications() {
 					mp.ParentWindow().Reload()
 				case listeners.TxConfirmed:
 					mp.updateBalance()
+					mp.WL.Wallet.TxIndex().ConfirmTx(n.WalletID, n.Hash, n.BlockHeight)
 					mp.ParentWindow().Reload()
 
 				}
@@ -966,6 +1067,9 @@ func (mp *MainPage) listenForNotifications() {
 			case n := <-mp.SyncStatusChan:
 				if n.Stage == wallet.SyncCompleted {
 					mp.updateBalance()
+					// Rescans and the sync may change the transactions
+					// without notifying them.
+					mp.WL.Wallet.BuildTxIndex()
 					mp.ParentWindow().Reload()
 				}
 			case <-mp.ctx.Done():
@@ -0,0 +1,219 @@
+package page
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const PaymentRequestPageID = "PaymentRequest"
+
+// paymentRequestExpiries are the durations a payment request may be valid for,
+// in the order of the expiry switch. 0 is for requests that don't expire.
+var paymentRequestExpiries = []time.Duration{0, time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// PaymentRequestPage creates a payment request for a fresh address of an
+// account.
+type PaymentRequestPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	pageContainer   *widget.List
+	backButton      decredmaterial.IconButton
+	accountSelector *components.AccountSelector
+	amountEditor    decredmaterial.Editor
+	memoEditor      decredmaterial.Editor
+	expirySwitch    *decredmaterial.SwitchButtonText
+	createButton    decredmaterial.Button
+}
+
+// NewPaymentRequestPage returns the page creating a payment request to
+// account, or to the first account if account is nil.
+func NewPaymentRequestPage(l *load.Load, account *dcrlibwallet.Account) *PaymentRequestPage {
+	pg := &PaymentRequestPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PaymentRequestPageID),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		createButton: l.Theme.Button(values.String(values.StrCreate)),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.amountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrAmountOptional))
+	pg.memoEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrMemo))
+	pg.amountEditor.Editor.SingleLine, pg.memoEditor.Editor.SingleLine = true, true
+
+	// The items follow the order of paymentRequestExpiries.
+	pg.expirySwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrNever)},
+		{Text: values.String(values.StrOneHour)},
+		{Text: values.String(values.StrOneDay)},
+		{Text: values.String(values.StrOneWeek)},
+	})
+
+	pg.accountSelector = components.NewAccountSelector(l, nil).
+		Title(values.String(values.StrReceivingAddress)).
+		AccountSelected(func(*dcrlibwallet.Account) {}).
+		AccountValidator(func(account *dcrlibwallet.Account) bool {
+			// Filter out imported account and mixed.
+			wal := l.WL.MultiWallet.WalletWithID(account.WalletID)
+			return account.Number != load.MaxInt32 && account.Number != wal.MixedAccountNumber()
+		})
+	if account != nil {
+		pg.accountSelector.SetSelectedAccount(account)
+	}
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestPage) OnNavigatedTo() {
+	if pg.accountSelector.SelectedAccount() == nil {
+		pg.accountSelector.SelectFirstWalletValidAccount(nil)
+	}
+	pg.amountEditor.Editor.Focus()
+}
+
+// create validates the fields and creates the payment request, the errors are
+// shown on the editors of the invalid fields.
+func (pg *PaymentRequestPage) create() {
+	var amount int64
+	if text := strings.TrimSpace(pg.amountEditor.Editor.Text()); text != "" {
+		dcr, err := strconv.ParseFloat(text, 64)
+		atoms, convErr := dcrutil.NewAmount(dcr)
+		if err != nil || convErr != nil || atoms <= 0 {
+			pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+			return
+		}
+		amount = int64(atoms)
+	}
+
+	account := pg.accountSelector.SelectedAccount()
+	if account == nil {
+		return
+	}
+
+	var expiry time.Time
+	if d := paymentRequestExpiries[pg.expirySwitch.SelectedIndex()-1]; d > 0 {
+		expiry = time.Now().Add(d)
+	}
+
+	wal := pg.WL.MultiWallet.WalletWithID(account.WalletID)
+	_, err := pg.WL.Wallet.CreatePaymentRequest(wal, account.Number, amount, pg.memoEditor.Editor.Text(), expiry)
+	if err != nil {
+		if err == wallet.ErrPaymentRequestAddress {
+			pg.Toast.NotifyError(values.String(values.StrNoUnusedAddress))
+			return
+		}
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+	pg.Toast.Notify(values.String(values.StrPaymentRequestCreated))
+	pg.ParentNavigator().CloseCurrentPage()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if _, changed := decredmaterial.HandleEditorEvents(pg.amountEditor.Editor); changed {
+		pg.amountEditor.SetError("")
+	}
+
+	pg.accountSelector.Handle(pg.ParentWindow())
+
+	if pg.createButton.Clicked() {
+		pg.create()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PaymentRequestPage) Layout(gtx C) D {
+	widgets := []layout.Widget{
+		func(gtx C) D {
+			return pg.accountSelector.Layout(pg.ParentWindow(), gtx)
+		},
+		pg.amountEditor.Layout,
+		pg.memoEditor.Layout,
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrExpiresIn)).Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.expirySwitch.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, pg.createButton.Layout)
+		},
+	}
+
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(values.String(values.StrNewPaymentRequest)).Layout)
+						}),
+					)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, i int) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+							rows := make([]layout.FlexChild, len(widgets))
+							for i, w := range widgets {
+								w := w
+								rows[i] = layout.Rigid(func(gtx C) D {
+									return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, w)
+								})
+							}
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx, rows...)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PaymentRequestPage) OnNavigatedFrom() {}
@@ -0,0 +1,401 @@
+package page
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"time"
+
+	"gioui.org/io/clipboard"
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	qrcode "github.com/yeqown/go-qrcode"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+const PaymentRequestsPageID = "PaymentRequests"
+
+// paymentRequestDateLayout is the format of the creation and expiry times of
+// the payment requests.
+const paymentRequestDateLayout = "2006-01-02 15:04"
+
+// paymentRequestItem is a row of the payment requests page.
+type paymentRequestItem struct {
+	wallet.PaymentRequest
+	status wallet.PaymentRequestStatus
+	// qrImage is the QR code of the payment URI, shown when the row is
+	// expanded.
+	qrImage *image.Image
+
+	copyButton   decredmaterial.Button
+	qrButton     decredmaterial.IconButton
+	exportButton decredmaterial.IconButton
+	deleteButton decredmaterial.IconButton
+}
+
+// PaymentRequestsPage lists the payment requests with their status, which is
+// updated as payments to their addresses arrive.
+type PaymentRequestsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	listContainer *widget.List
+	backButton    decredmaterial.IconButton
+	addButton     decredmaterial.Button
+
+	items []*paymentRequestItem
+	// expandedID is the ID of the request whose QR code is shown.
+	expandedID string
+	// loadedAt is the time the statuses were last computed, revision the
+	// wallet.PaymentRequestsRevision of the requests listed. The main page
+	// applies the payments received, the requests are listed again when the
+	// revision changes.
+	loadedAt time.Time
+	revision int
+}
+
+func NewPaymentRequestsPage(l *load.Load) *PaymentRequestsPage {
+	pg := &PaymentRequestsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(PaymentRequestsPageID),
+		listContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		addButton: l.Theme.Button(values.String(values.StrNewPaymentRequest)),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) OnNavigatedTo() {
+	pg.loadRequests()
+	go func() {
+		if err := pg.WL.Wallet.RefreshPaymentRequests(); err != nil {
+			log.Errorf("Error refreshing payment requests: %v", err)
+		}
+		pg.ParentWindow().Reload()
+	}()
+}
+
+func (pg *PaymentRequestsPage) loadRequests() {
+	now := time.Now()
+	pg.revision = pg.WL.Wallet.PaymentRequestsRevision()
+	requests := pg.WL.Wallet.PaymentRequests()
+	items := make([]*paymentRequestItem, len(requests))
+	for i, request := range requests {
+		item := &paymentRequestItem{
+			PaymentRequest: request,
+			status:         request.Status(now),
+			copyButton:     pg.Theme.OutlineButton(values.String(values.StrCopyPaymentURI)),
+			qrButton:       pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ImageCropFree))),
+			exportButton:   pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.FileFileDownload))),
+			deleteButton:   pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ActionDelete))),
+		}
+		for _, btn := range []*decredmaterial.IconButton{&item.qrButton, &item.exportButton, &item.deleteButton} {
+			btn.Inset, btn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+			btn.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
+		}
+		if request.ID == pg.expandedID {
+			item.qrImage = paymentRequestQR(&request)
+		}
+		items[i] = item
+	}
+	pg.items = items
+	pg.loadedAt = now
+}
+
+// paymentRequestQR returns the QR code of the payment URI of r, nil if it
+// can't be generated.
+func paymentRequestQR(r *wallet.PaymentRequest) *image.Image {
+	qrCode, err := qrcode.New(r.URI())
+	if err != nil {
+		log.Error("Error generating payment request qrCode: " + err.Error())
+		return nil
+	}
+
+	var buff bytes.Buffer
+	if err = qrCode.SaveTo(&buff); err != nil {
+		log.Error(err.Error())
+		return nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(buff.Bytes()))
+	if err != nil {
+		log.Error(err.Error())
+		return nil
+	}
+	return &img
+}
+
+// paymentRequestStatus returns the translated status of a payment request.
+func paymentRequestStatus(status wallet.PaymentRequestStatus) string {
+	switch status {
+	case wallet.PaymentRequestPartiallyPaid:
+		return values.String(values.StrPartiallyPaid)
+	case wallet.PaymentRequestPaid:
+		return values.String(values.StrPaid)
+	case wallet.PaymentRequestExpired:
+		return values.String(values.StrExpired)
+	default:
+		return values.String(values.StrUnpaid)
+	}
+}
+
+// notifyPaymentRequests notifies the payments received by the requests
+// updated.
+func notifyPaymentRequests(l *load.Load, updated []wallet.PaymentRequest) {
+	for i := range updated {
+		r := &updated[i]
+		l.Toast.Notify(values.StringF(values.StrPaymentRequestReceived, paymentRequestTitle(r), dcrutil.Amount(r.Received).String()))
+	}
+}
+
+func (pg *PaymentRequestsPage) showExportModal(item *paymentRequestItem) {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrFilePath)).
+		Text(pg.WL.PaymentRequestQRPath(&item.PaymentRequest)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrExport), func(path string, tim *modal.TextInputModal) bool {
+			if err := pg.WL.SavePaymentRequestQR(path, &item.PaymentRequest); err != nil {
+				tim.SetError(components.TranslateErr(err))
+				tim.SetLoading(false)
+				return false
+			}
+			pg.Toast.Notify(values.StringF(values.StrQRCodeExported, path))
+			return true
+		})
+
+	textModal.Title(values.String(values.StrExportQRCode)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *PaymentRequestsPage) showDeleteModal(item *paymentRequestItem) {
+	confirmModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrDeletePaymentRequest)).
+		Body(values.StringF(values.StrDeletePaymentRequestConfirm, paymentRequestTitle(&item.PaymentRequest))).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		PositiveButton(values.String(values.StrRemove), func(isChecked bool) bool {
+			pg.WL.Wallet.DeletePaymentRequest(item.ID)
+			pg.loadRequests()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// paymentRequestTitle returns the memo of r, or its address if it has none.
+func paymentRequestTitle(r *wallet.PaymentRequest) string {
+	if r.Memo != "" {
+		return r.Memo
+	}
+	return r.Address
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if time.Since(pg.loadedAt) > time.Minute || pg.WL.Wallet.PaymentRequestsRevision() != pg.revision {
+		// Requests expire while the page is displayed.
+		pg.loadRequests()
+	}
+
+	if pg.addButton.Clicked() {
+		pg.ParentNavigator().Display(NewPaymentRequestPage(pg.Load, nil))
+	}
+
+	for _, item := range pg.items {
+		if item.qrButton.Button.Clicked() {
+			if pg.expandedID == item.ID {
+				pg.expandedID, item.qrImage = "", nil
+			} else {
+				pg.expandedID = item.ID
+				pg.loadRequests()
+				return
+			}
+		}
+		if item.exportButton.Button.Clicked() {
+			pg.showExportModal(item)
+		}
+		if item.deleteButton.Button.Clicked() {
+			pg.showDeleteModal(item)
+		}
+	}
+}
+
+// handleCopyEvent copies the payment URI of the request whose copy button was
+// clicked.
+func (pg *PaymentRequestsPage) handleCopyEvent(gtx C) {
+	for _, item := range pg.items {
+		if item.copyButton.Clicked() {
+			clipboard.WriteOp{Text: item.URI()}.Add(gtx.Ops)
+			pg.Toast.Notify(values.String(values.StrCopied))
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) Layout(gtx C) D {
+	pg.handleCopyEvent(gtx)
+
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(values.String(values.StrPaymentRequests)).Layout)
+						}),
+						layout.Flexed(1, func(gtx C) D {
+							return layout.E.Layout(gtx, pg.addButton.Layout)
+						}),
+					)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+						if len(pg.items) == 0 {
+							txt := pg.Theme.Body1(values.String(values.StrNoPaymentRequests))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Center.Layout(gtx, txt.Layout)
+						}
+						return pg.Theme.List(pg.listContainer).Layout(gtx, len(pg.items), func(gtx C, i int) D {
+							return pg.requestRow(gtx, pg.items[i], i == len(pg.items)-1)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+func (pg *PaymentRequestsPage) requestRow(gtx C, item *paymentRequestItem, last bool) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return pg.layoutRequest(gtx, item)
+			}, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(item.copyButton.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, item.qrButton.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, item.exportButton.Layout)
+					}),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, item.deleteButton.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if item.qrImage == nil {
+				return D{}
+			}
+			return layout.Center.Layout(gtx, func(gtx C) D {
+				return pg.Theme.ImageIcon(gtx, *item.qrImage, 240)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if last {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+	)
+}
+
+// layoutRequest lays out the memo, the amount, the address, the dates and the
+// status of the request of item.
+func (pg *PaymentRequestsPage) layoutRequest(gtx C, item *paymentRequestItem) D {
+	amount := values.String(values.StrAnyAmount)
+	if item.Amount > 0 {
+		amount = dcrutil.Amount(item.Amount).String()
+	}
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			title := amount
+			if item.Memo != "" {
+				title = fmt.Sprintf("%s - %s", item.Memo, amount)
+			}
+			txt := pg.Theme.Body1(title)
+			txt.Font.Weight = text.SemiBold
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2(item.Address)
+			txt.Color = pg.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			dates := values.StringF(values.StrCreatedAt, item.CreatedAt.Local().Format(paymentRequestDateLayout))
+			if !item.Expiry.IsZero() {
+				dates = fmt.Sprintf("%s  •  %s", dates, values.StringF(values.StrExpiresAt, item.Expiry.Local().Format(paymentRequestDateLayout)))
+			}
+			txt := pg.Theme.Caption(dates)
+			txt.Color = pg.Theme.Color.GrayText3
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			status := paymentRequestStatus(item.status)
+			if item.Received > 0 {
+				status = fmt.Sprintf("%s  •  %s", status, values.StringF(values.StrReceivedOf, dcrutil.Amount(item.Received).String(), amount))
+			}
+			txt := pg.Theme.Caption(status)
+			switch item.status {
+			case wallet.PaymentRequestPaid:
+				txt.Color = pg.Theme.Color.Success
+			case wallet.PaymentRequestPartiallyPaid:
+				txt.Color = pg.Theme.Color.Orange
+			case wallet.PaymentRequestExpired:
+				txt.Color = pg.Theme.Color.Danger
+			default:
+				txt.Color = pg.Theme.Color.GrayText2
+			}
+			return txt.Layout(gtx)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *PaymentRequestsPage) OnNavigatedFrom() {}
@@ -317,7 +317,7 @@ func (pg *AccountMixerPage) showModalPasswordStartAccountMixer() {
 			go func() {
 				err := pg.WL.MultiWallet.StartAccountMixer(pg.wallet.ID, password)
 				if err != nil {
-					pm.SetError(err.Error())
+					pm.SetError(components.TranslateErr(err))
 					pm.SetLoading(false)
 					return
 				}
@@ -202,7 +202,7 @@ func (pg *ManualMixerSetupPage) showModalSetupMixerAcct() {
 				unmixedAcctNumber := pg.unmixedAccountSelector.SelectedAccount().Number
 				err := pg.wallet.SetAccountMixerConfig(mixedAcctNumber, unmixedAcctNumber, password)
 				if err != nil {
-					pm.SetError(err.Error())
+					pm.SetError(components.TranslateErr(err))
 					pm.SetLoading(false)
 					return
 				}
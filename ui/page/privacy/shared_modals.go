@@ -11,7 +11,9 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 )
 
@@ -87,7 +89,7 @@ func showModalSetupMixerAcct(conf *sharedModalConfig, movefundsChecked bool) {
 			go func() {
 				err := conf.wallet.CreateMixerAccounts("mixed", "unmixed", password)
 				if err != nil {
-					pm.SetError(err.Error())
+					pm.SetError(components.TranslateErr(err))
 					pm.SetLoading(false)
 					return
 				}
@@ -129,20 +131,14 @@ func moveFundsFromDefaultToUnmixed(conf *sharedModalConfig, password string) err
 		return err
 	}
 
-	unsignedTx, err := conf.WL.MultiWallet.NewUnsignedTx(sourceAccount.WalletID, sourceAccount.Number)
+	// The frozen coins of the account are not moved.
+	unsignedTx, err := wallet.NewTxAuthor(conf.wallet, sourceAccount.Number)
 	if err != nil {
 		return err
 	}
 
-	// get tx fees
-	feeAndSize, err := unsignedTx.EstimateFeeAndSize()
-	if err != nil {
-		return err
-	}
-
-	// calculate max amount to be sent
-	amountAtom := sourceAccount.Balance.Spendable - feeAndSize.Fee.AtomValue
-	err = unsignedTx.AddSendDestination(destinationAddress, amountAtom, true)
+	// send the whole spendable balance
+	err = unsignedTx.AddSendDestination(destinationAddress, 0, true)
 	if err != nil {
 		return err
 	}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"strconv"
+	"strings"
 	"time"
 
 	"gioui.org/io/clipboard"
@@ -15,6 +17,7 @@ import (
 	"gioui.org/unit"
 	"gioui.org/widget"
 
+	"github.com/decred/dcrd/dcrutil/v4"
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/app"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
@@ -22,6 +25,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 	qrcode "github.com/yeqown/go-qrcode"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 )
@@ -46,12 +50,18 @@ type ReceivePage struct {
 	currentAddress    string
 	qrImage           *image.Image
 	newAddr, copy     decredmaterial.Button
+	viewOnExplorer    decredmaterial.Button
+	copyURI           decredmaterial.Button
+	viewAddresses     decredmaterial.Button
+	paymentRequests   decredmaterial.Button
 	info, more        decredmaterial.IconButton
 	card              decredmaterial.Card
 	receiveAddress    decredmaterial.Label
 	ops               *op.Ops
 	selector          *components.AccountSelector
 	copyAddressButton decredmaterial.Button
+	amountEditor      decredmaterial.Editor
+	requestedAmount   dcrutil.Amount
 
 	backdrop   *widget.Clickable
 	backButton decredmaterial.IconButton
@@ -69,13 +79,17 @@ func NewReceivePage(l *load.Load) *ReceivePage {
 		scrollContainer: &widget.List{
 			List: layout.List{Axis: layout.Vertical},
 		},
-		info:           l.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ActionInfo))),
-		copy:           l.Theme.Button(values.String(values.StrCopy)),
-		more:           l.Theme.IconButton(l.Theme.Icons.NavMoreIcon),
-		newAddr:        l.Theme.Button(values.String(values.StrGenerateAddress)),
-		receiveAddress: l.Theme.Label(values.TextSize20, ""),
-		card:           l.Theme.Card(),
-		backdrop:       new(widget.Clickable),
+		info:            l.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ActionInfo))),
+		copy:            l.Theme.Button(values.String(values.StrCopy)),
+		more:            l.Theme.IconButton(l.Theme.Icons.NavMoreIcon),
+		newAddr:         l.Theme.Button(values.String(values.StrGenerateAddress)),
+		viewOnExplorer:  l.Theme.Button(values.String(values.StrViewOnExplorer)),
+		copyURI:         l.Theme.Button(values.String(values.StrCopyPaymentURI)),
+		viewAddresses:   l.Theme.Button(values.String(values.StrViewAddresses)),
+		paymentRequests: l.Theme.Button(values.String(values.StrPaymentRequests)),
+		receiveAddress:  l.Theme.Label(values.TextSize20, ""),
+		card:            l.Theme.Card(),
+		backdrop:        new(widget.Clickable),
 	}
 
 	pg.info.Inset, pg.info.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
@@ -98,6 +112,25 @@ func NewReceivePage(l *load.Load) *ReceivePage {
 	pg.newAddr.Color = pg.Theme.Color.Text
 	pg.newAddr.Background = pg.Theme.Color.Surface
 	pg.newAddr.HighlightColor = pg.Theme.Color.SurfaceHighlight
+	pg.viewOnExplorer.Inset = pg.newAddr.Inset
+	pg.viewOnExplorer.Color = pg.Theme.Color.Text
+	pg.viewOnExplorer.Background = pg.Theme.Color.Surface
+	pg.viewOnExplorer.HighlightColor = pg.Theme.Color.SurfaceHighlight
+	pg.copyURI.Inset = pg.newAddr.Inset
+	pg.copyURI.Color = pg.Theme.Color.Text
+	pg.copyURI.Background = pg.Theme.Color.Surface
+	pg.copyURI.HighlightColor = pg.Theme.Color.SurfaceHighlight
+	pg.viewAddresses.Inset = pg.newAddr.Inset
+	pg.viewAddresses.Color = pg.Theme.Color.Text
+	pg.viewAddresses.Background = pg.Theme.Color.Surface
+	pg.viewAddresses.HighlightColor = pg.Theme.Color.SurfaceHighlight
+	pg.paymentRequests.Inset = pg.newAddr.Inset
+	pg.paymentRequests.Color = pg.Theme.Color.Text
+	pg.paymentRequests.Background = pg.Theme.Color.Surface
+	pg.paymentRequests.HighlightColor = pg.Theme.Color.SurfaceHighlight
+
+	pg.amountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrRequestedAmount))
+	pg.amountEditor.Editor.SingleLine = true
 
 	pg.receiveAddress.MaxLines = 1
 
@@ -155,8 +188,21 @@ func (pg *ReceivePage) OnNavigatedTo() {
 	}
 }
 
+// paymentURI returns the payment URI of the current address with the requested
+// amount.
+func (pg *ReceivePage) paymentURI() string {
+	return wallet.PaymentURI{Address: pg.currentAddress, Amount: pg.requestedAmount}.String()
+}
+
+// generateQRForAddress encodes the current address in the QR code, or the
+// payment URI if an amount is requested as not all wallets can read URIs.
 func (pg *ReceivePage) generateQRForAddress() {
-	qrCode, err := qrcode.New(pg.currentAddress)
+	content := pg.currentAddress
+	if pg.requestedAmount > 0 {
+		content = pg.paymentURI()
+	}
+
+	qrCode, err := qrcode.New(content)
 	if err != nil {
 		log.Error("Error generating address qrCode: " + err.Error())
 		return
@@ -219,6 +265,7 @@ func (pg *ReceivePage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 									}
 									return D{}
 								}),
+								layout.Rigid(pg.amountLayout),
 								layout.Rigid(func(gtx C) D {
 									if pg.qrImage == nil {
 										return D{}
@@ -280,6 +327,7 @@ func (pg *ReceivePage) layoutMobile(gtx layout.Context) layout.Dimensions {
 								Axis:      layout.Vertical,
 								Alignment: layout.Middle,
 							}.Layout(gtx,
+								layout.Rigid(pg.amountLayout),
 								layout.Rigid(func(gtx C) D {
 									if pg.qrImage == nil {
 										return layout.Dimensions{}
@@ -385,7 +433,20 @@ func (pg *ReceivePage) titleLayout(gtx C) D {
 					if pg.isNewAddr {
 						m := op.Record(gtx.Ops)
 						layout.Inset{Top: values.MarginPadding30, Left: unit.Dp(-152)}.Layout(gtx, func(gtx C) D {
-							return pg.Theme.Shadow().Layout(gtx, pg.newAddr.Layout)
+							return pg.Theme.Shadow().Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+									layout.Rigid(pg.newAddr.Layout),
+									layout.Rigid(pg.copyURI.Layout),
+									layout.Rigid(pg.viewAddresses.Layout),
+									layout.Rigid(pg.paymentRequests.Layout),
+									layout.Rigid(func(gtx C) D {
+										if pg.WL.Wallet.ExplorerTemplates().Address == "" {
+											return D{}
+										}
+										return pg.viewOnExplorer.Layout(gtx)
+									}),
+								)
+							})
 						})
 						op.Defer(gtx.Ops, m.Stop())
 					}
@@ -397,6 +458,15 @@ func (pg *ReceivePage) titleLayout(gtx C) D {
 	)
 }
 
+// amountLayout lays out the editor of the amount requested in the payment URI.
+func (pg *ReceivePage) amountLayout(gtx C) D {
+	return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding350)
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return pg.amountEditor.Layout(gtx)
+	})
+}
+
 func (pg *ReceivePage) addressLayout(gtx C) D {
 	card := decredmaterial.Card{
 		Color: pg.Theme.Color.Gray4,
@@ -458,6 +528,29 @@ func (pg *ReceivePage) HandleUserInteractions() {
 		pg.isNewAddr = false
 	}
 
+	for _, evt := range pg.amountEditor.Editor.Events() {
+		if _, ok := evt.(widget.ChangeEvent); ok {
+			pg.validateRequestedAmount()
+			pg.generateQRForAddress()
+		}
+	}
+
+	if pg.viewAddresses.Clicked() {
+		pg.isNewAddr = false
+		pg.ParentNavigator().Display(NewAddressesPage(pg.Load, pg.selector.SelectedAccount()))
+	}
+
+	if pg.paymentRequests.Clicked() {
+		pg.isNewAddr = false
+		pg.ParentNavigator().Display(NewPaymentRequestsPage(pg.Load))
+	}
+
+	if pg.viewOnExplorer.Clicked() {
+		pg.isNewAddr = false
+		redirectURL := pg.WL.Wallet.ExplorerTemplates().AddressURL(pg.currentAddress)
+		components.ShowExplorerLink(pg.Load, pg.ParentWindow(), values.String(values.StrViewOnExplorer), redirectURL)
+	}
+
 	if pg.infoButton.Button.Clicked() {
 		info := modal.NewInfoModal(pg.Load).
 			Title(values.String(values.StrReceive)+" DCR").
@@ -473,6 +566,26 @@ func (pg *ReceivePage) HandleUserInteractions() {
 	}
 }
 
+// validateRequestedAmount sets the amount requested in the payment URI, which
+// is not set if the amount entered is invalid.
+func (pg *ReceivePage) validateRequestedAmount() {
+	pg.requestedAmount = 0
+	pg.amountEditor.SetError("")
+
+	text := strings.TrimSpace(pg.amountEditor.Editor.Text())
+	if text == "" {
+		return
+	}
+
+	if amount, err := strconv.ParseFloat(text, 64); err == nil && amount > 0 {
+		if pg.requestedAmount, err = dcrutil.NewAmount(amount); err == nil {
+			return
+		}
+	}
+	pg.requestedAmount = 0
+	pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+}
+
 func (pg *ReceivePage) generateNewAddress() (string, error) {
 	selectedAccount := pg.selector.SelectedAccount()
 	selectedWallet := pg.multiWallet.WalletWithID(selectedAccount.WalletID)
@@ -502,6 +615,12 @@ func (pg *ReceivePage) handleCopyEvent(gtx C) {
 		})
 	}
 
+	if pg.copyURI.Clicked() {
+		clipboard.WriteOp{Text: pg.paymentURI()}.Add(gtx.Ops)
+		pg.isNewAddr = false
+		pg.Toast.Notify(values.String(values.StrCopied))
+	}
+
 	if pg.copyAddressButton.Clicked() {
 		clipboard.WriteOp{Text: pg.copyAddressButton.Text}.Add(gtx.Ops)
 		pg.Toast.Notify("Copied")
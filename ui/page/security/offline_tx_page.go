@@ -0,0 +1,347 @@
+package security
+
+import (
+	"errors"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const (
+	SignTransactionPageID      = "SignTransaction"
+	BroadcastTransactionPageID = "BroadcastTransaction"
+)
+
+// OfflineTxPage opens a transaction file exported by a watch-only wallet,
+// either to sign it on the offline computer holding the private keys of the
+// account, or to broadcast it through the watch-only wallet once signed.
+type OfflineTxPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	// signing is set on the page signing transactions, the transactions
+	// are broadcast otherwise.
+	signing bool
+
+	pageContainer    *widget.List
+	backButton       decredmaterial.IconButton
+	pathEditor       decredmaterial.Editor
+	signedPathEditor decredmaterial.Editor
+	openBtn          decredmaterial.Button
+	clearBtn         decredmaterial.Button
+	actionBtn        decredmaterial.Button
+
+	// offlineTx is the opened transaction, spending from account of
+	// wallet.
+	offlineTx *wallet.OfflineTx
+	decoded   *wallet.DecodedOfflineTx
+	wallet    *dcrlibwallet.Wallet
+	account   uint32
+	isBusy    bool
+}
+
+// NewSignTransactionPage returns the page signing the transactions exported
+// by watch-only wallets.
+func NewSignTransactionPage(l *load.Load) *OfflineTxPage {
+	return newOfflineTxPage(l, SignTransactionPageID, true)
+}
+
+// NewBroadcastTransactionPage returns the page broadcasting the transactions
+// signed offline.
+func NewBroadcastTransactionPage(l *load.Load) *OfflineTxPage {
+	return newOfflineTxPage(l, BroadcastTransactionPageID, false)
+}
+
+func newOfflineTxPage(l *load.Load, id string, signing bool) *OfflineTxPage {
+	pg := &OfflineTxPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(id),
+		signing:          signing,
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.pathEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrFilePath))
+	pg.pathEditor.Editor.SingleLine = true
+	pg.pathEditor.Editor.Submit = true
+
+	pg.signedPathEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrSignedTxPath))
+	pg.signedPathEditor.Editor.SingleLine = true
+
+	pg.openBtn = l.Theme.Button(values.String(values.StrOpen))
+	pg.openBtn.Font.Weight = text.Medium
+
+	pg.clearBtn = l.Theme.OutlineButton(values.String(values.StrClear))
+	pg.clearBtn.Font.Weight = text.Medium
+
+	if signing {
+		pg.actionBtn = l.Theme.Button(values.String(values.StrSign))
+	} else {
+		pg.actionBtn = l.Theme.Button(values.String(values.StrBroadcast))
+	}
+	pg.actionBtn.Font.Weight = text.Medium
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *OfflineTxPage) OnNavigatedTo() {
+	pg.pathEditor.Editor.Focus()
+}
+
+func (pg *OfflineTxPage) title() string {
+	if pg.signing {
+		return values.String(values.StrSignTransaction)
+	}
+	return values.String(values.StrBroadcastTransaction)
+}
+
+// open reads and decodes the transaction file, and finds the wallet it spends
+// from.
+func (pg *OfflineTxPage) open() {
+	pg.clearTx()
+
+	offlineTx, err := pg.WL.OpenOfflineTx(strings.TrimSpace(pg.pathEditor.Editor.Text()))
+	if err != nil {
+		pg.pathEditor.SetError(components.TranslateErr(err))
+		return
+	}
+
+	// Only wallets with the private keys sign, the transactions are
+	// broadcast through the watch-only wallet.
+	wal, account, err := wallet.FindOfflineTxAccount(pg.WL.SortedWalletList(), offlineTx, pg.signing)
+	if err != nil {
+		pg.pathEditor.SetError(components.TranslateErr(err))
+		return
+	}
+
+	decoded, err := offlineTx.DecodeForWallet(wal)
+	if err == nil && pg.signing && decoded.Signed {
+		err = errors.New(values.String(values.StrTxAlreadySigned))
+	} else if err == nil && !pg.signing && !decoded.Signed {
+		err = errors.New(values.String(values.StrTxNotSigned))
+	}
+	if err != nil {
+		pg.pathEditor.SetError(components.TranslateErr(err))
+		return
+	}
+
+	pg.offlineTx, pg.decoded = offlineTx, decoded
+	pg.wallet, pg.account = wal, account
+	pg.signedPathEditor.Editor.SetText(pg.WL.OfflineTxPath("signed-" + decoded.Hash[:8]))
+}
+
+func (pg *OfflineTxPage) clearTx() {
+	pg.offlineTx, pg.decoded, pg.wallet = nil, nil, nil
+	pg.pathEditor.SetError("")
+	pg.signedPathEditor.SetError("")
+}
+
+func (pg *OfflineTxPage) showSignModal() {
+	path := strings.TrimSpace(pg.signedPathEditor.Editor.Text())
+
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrSignTransaction)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrSign), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				signed, err := wallet.SignOfflineTx(pg.wallet, pg.account, pg.offlineTx, []byte(password))
+				if err == nil {
+					err = pg.WL.SaveOfflineTx(path, signed)
+				}
+				if err != nil {
+					pm.SetError(components.TranslateErr(err))
+					pm.SetLoading(false)
+					return
+				}
+
+				pm.Dismiss()
+				pg.Toast.Notify(values.StringF(values.StrTxSignedSaved, path))
+				pg.clearTx()
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *OfflineTxPage) broadcast() {
+	if !pg.WL.MultiWallet.IsSynced() {
+		pg.Toast.NotifyError(components.TranslateErr(wallet.ErrNotSynced))
+		return
+	}
+
+	pg.isBusy = true
+	go func() {
+		defer func() {
+			pg.isBusy = false
+		}()
+
+		hash, err := wallet.PublishOfflineTx(pg.wallet, pg.offlineTx)
+		if err != nil {
+			pg.Toast.NotifyError(components.TranslateErr(err))
+			return
+		}
+
+		pg.Toast.Notify(values.StringF(values.StrTxBroadcast, hash))
+		pg.clearTx()
+	}()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *OfflineTxPage) HandleUserInteractions() {
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(pg.pathEditor.Editor)
+	if isChanged {
+		pg.clearTx()
+	}
+	if _, changed := decredmaterial.HandleEditorEvents(pg.signedPathEditor.Editor); changed {
+		pg.signedPathEditor.SetError("")
+	}
+
+	pg.openBtn.SetEnabled(strings.TrimSpace(pg.pathEditor.Editor.Text()) != "")
+	if (pg.openBtn.Clicked() || isSubmit) && pg.openBtn.Enabled() {
+		pg.open()
+	}
+
+	if pg.clearBtn.Clicked() {
+		pg.clearTx()
+		pg.pathEditor.Editor.SetText("")
+	}
+
+	canSave := !pg.signing || strings.TrimSpace(pg.signedPathEditor.Editor.Text()) != ""
+	pg.actionBtn.SetEnabled(pg.offlineTx != nil && !pg.isBusy && canSave)
+	if pg.actionBtn.Clicked() && pg.actionBtn.Enabled() {
+		if pg.signing {
+			pg.showSignModal()
+		} else {
+			pg.broadcast()
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided C
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *OfflineTxPage) Layout(gtx C) D {
+	body := func(gtx C) D {
+		sp := components.SubPage{
+			Load:       pg.Load,
+			Title:      pg.title(),
+			BackButton: pg.backButton,
+			Back: func() {
+				pg.ParentNavigator().CloseCurrentPage()
+			},
+			Body: func(gtx C) D {
+				return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, i int) D {
+					return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, pg.txSection)
+				})
+			},
+		}
+		return sp.Layout(pg.ParentWindow(), gtx)
+	}
+	if pg.Load.GetCurrentAppWidth() <= gtx.Dp(values.StartMobileView) {
+		return components.UniformMobile(gtx, false, false, body)
+	}
+	return components.UniformPadding(gtx, body)
+}
+
+func (pg *OfflineTxPage) txSection(gtx C) D {
+	note := values.String(values.StrBroadcastTxNote)
+	if pg.signing {
+		note = values.String(values.StrSignTxNote)
+	}
+
+	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+		return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					desc := pg.Theme.Caption(note)
+					desc.Color = pg.Theme.Color.GrayText2
+					return layout.Inset{Bottom: values.MarginPadding20}.Layout(gtx, desc.Layout)
+				}),
+				layout.Rigid(pg.pathEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+						return layout.E.Layout(gtx, func(gtx C) D {
+							return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+								layout.Rigid(func(gtx C) D {
+									return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.clearBtn.Layout)
+								}),
+								layout.Rigid(pg.openBtn.Layout),
+							)
+						})
+					})
+				}),
+				layout.Rigid(pg.decodedTx),
+			)
+		})
+	})
+}
+
+// decodedTx lays out the opened transaction and the action on it.
+func (pg *OfflineTxPage) decodedTx(gtx C) D {
+	if pg.decoded == nil {
+		return D{}
+	}
+
+	m := values.MarginPadding10
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: m, Bottom: m}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				txt := pg.Theme.Body2(values.String(values.StrWalletName))
+				txt.Color = pg.Theme.Color.GrayText2
+				return txt.Layout(gtx)
+			}, pg.Theme.Body2(pg.wallet.Name).Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return components.LayoutOfflineTx(gtx, pg.Load, pg.decoded)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !pg.signing {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, pg.signedPathEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+				return layout.E.Layout(gtx, pg.actionBtn.Layout)
+			})
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *OfflineTxPage) OnNavigatedFrom() {}
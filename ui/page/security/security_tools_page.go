@@ -31,6 +31,8 @@ type Security struct {
 	verifyMessage   *decredmaterial.Clickable
 	validateAddress *decredmaterial.Clickable
 	signMsg         *decredmaterial.Clickable
+	signTx          *decredmaterial.Clickable
+	broadcastTx     *decredmaterial.Clickable
 	shadowBox       *decredmaterial.Shadow
 	infoButton      decredmaterial.IconButton
 
@@ -45,6 +47,8 @@ func NewSecurityToolsPage(l *load.Load) *Security {
 		verifyMessage:    l.Theme.NewClickable(true),
 		validateAddress:  l.Theme.NewClickable(true),
 		signMsg:          l.Theme.NewClickable(true),
+		signTx:           l.Theme.NewClickable(true),
+		broadcastTx:      l.Theme.NewClickable(true),
 	}
 
 	pg.shadowBox = l.Theme.Shadow()
@@ -53,6 +57,8 @@ func NewSecurityToolsPage(l *load.Load) *Security {
 	pg.verifyMessage.Radius = decredmaterial.Radius(14)
 	pg.validateAddress.Radius = decredmaterial.Radius(14)
 	pg.signMsg.Radius = decredmaterial.Radius(14)
+	pg.signTx.Radius = decredmaterial.Radius(14)
+	pg.broadcastTx.Radius = decredmaterial.Radius(14)
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
 
@@ -86,6 +92,8 @@ func (pg *Security) Layout(gtx C) D {
 					layout.Rigid(pg.message()),
 					layout.Rigid(pg.address()),
 					layout.Rigid(pg.signMessage()),
+					layout.Rigid(pg.signTransaction()),
+					layout.Rigid(pg.broadcastTransaction()),
 				)
 			},
 			InfoTemplate: modal.SecurityToolsInfoTemplate,
@@ -124,6 +132,18 @@ func (pg *Security) signMessage() layout.Widget {
 	}
 }
 
+func (pg *Security) signTransaction() layout.Widget {
+	return func(gtx C) D {
+		return pg.pageSections(gtx, pg.Theme.Icons.DocumentationIcon, pg.signTx, values.String(values.StrSignTransaction))
+	}
+}
+
+func (pg *Security) broadcastTransaction() layout.Widget {
+	return func(gtx C) D {
+		return pg.pageSections(gtx, pg.Theme.Icons.SendIcon, pg.broadcastTx, values.String(values.StrBroadcastTransaction))
+	}
+}
+
 func (pg *Security) pageSections(gtx C, icon *decredmaterial.Image, action *decredmaterial.Clickable, title string) D {
 	return layout.Inset{Bottom: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 		return decredmaterial.LinearLayout{
@@ -167,6 +187,14 @@ func (pg *Security) HandleUserInteractions() {
 	if pg.signMsg.Clicked() {
 		pg.ParentNavigator().Display(NewSignMessagePage(pg.Load))
 	}
+
+	if pg.signTx.Clicked() {
+		pg.ParentNavigator().Display(NewSignTransactionPage(pg.Load))
+	}
+
+	if pg.broadcastTx.Clicked() {
+		pg.ParentNavigator().Display(NewBroadcastTransactionPage(pg.Load))
+	}
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
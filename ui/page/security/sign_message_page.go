@@ -281,7 +281,7 @@ func (pg *SignMessagePage) HandleUserInteractions() {
 					go func() {
 						sig, err := pg.wallet.SignMessage([]byte(password), address, message)
 						if err != nil {
-							pm.SetError(err.Error())
+							pm.SetError(components.TranslateErr(err))
 							pm.SetLoading(false)
 							return
 						}
@@ -93,7 +93,7 @@ func (pg *SaveSeedPage) OnNavigatedTo() {
 				seed, err := pg.wallet.DecryptSeed([]byte(password))
 				if err != nil {
 					m.SetLoading(false)
-					m.SetError(err.Error())
+					m.SetError(components.TranslateErr(err))
 					return
 				}
 
@@ -170,7 +170,7 @@ func (pg *VerifySeedPage) verifySeed() {
 					}
 
 					m.SetLoading(false)
-					m.SetError(err.Error())
+					m.SetError(components.TranslateErr(err))
 					return
 				}
 				m.Dismiss()
@@ -0,0 +1,324 @@
+package send
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+const AddressBookPageID = "AddressBook"
+
+// contactItem is a row of the address book.
+type contactItem struct {
+	wallet.Contact
+	// ownWallet is the name of the wallet of the address if it is one of
+	// the wallets of the user.
+	ownWallet string
+
+	editButton   decredmaterial.IconButton
+	deleteButton decredmaterial.IconButton
+}
+
+// AddressBookPage lists the contacts of the address book, which are picked as
+// the destination of the send page, and imports and exports them as JSON.
+type AddressBookPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	listContainer layout.List
+	backButton    decredmaterial.IconButton
+	searchEditor  decredmaterial.Editor
+	addButton     decredmaterial.Button
+	importButton  decredmaterial.Button
+	exportButton  decredmaterial.Button
+
+	items []*contactItem
+}
+
+func NewAddressBookPage(l *load.Load) *AddressBookPage {
+	pg := &AddressBookPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(AddressBookPageID),
+		listContainer:    layout.List{Axis: layout.Vertical},
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.searchEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearchContacts), l.Theme.Icons.SearchIcon, true)
+	pg.searchEditor.Editor.SingleLine = true
+
+	pg.addButton = l.Theme.Button(values.String(values.StrAddContact))
+	pg.importButton = l.Theme.OutlineButton(values.String(values.StrImport))
+	pg.exportButton = l.Theme.OutlineButton(values.String(values.StrExport))
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) OnNavigatedTo() {
+	pg.loadContacts()
+}
+
+func (pg *AddressBookPage) loadContacts() {
+	contacts := pg.WL.Wallet.AddressBook()
+	items := make([]*contactItem, len(contacts))
+	for i, contact := range contacts {
+		item := &contactItem{Contact: contact}
+		if own, walletName := pg.WL.Wallet.HaveAddress(contact.Address); own {
+			item.ownWallet = walletName
+		}
+
+		item.editButton = pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ImageEdit)))
+		item.deleteButton = pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ActionDelete)))
+		for _, btn := range []*decredmaterial.IconButton{&item.editButton, &item.deleteButton} {
+			btn.Inset, btn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+			btn.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
+		}
+		items[i] = item
+	}
+	pg.items = items
+}
+
+// contactMatches returns true if the name, the address or the notes of
+// contact contain search, which is lower case.
+func contactMatches(contact wallet.Contact, search string) bool {
+	return search == "" ||
+		strings.Contains(strings.ToLower(contact.Name), search) ||
+		strings.Contains(strings.ToLower(contact.Address), search) ||
+		strings.Contains(strings.ToLower(contact.Notes), search)
+}
+
+func (pg *AddressBookPage) showContactModal(contact *wallet.Contact) {
+	contactModal := newContactModal(pg.Load, contact)
+	contactModal.contactSaved = pg.loadContacts
+	pg.ParentWindow().ShowModal(contactModal)
+}
+
+func (pg *AddressBookPage) showDeleteModal(contact wallet.Contact) {
+	confirmModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrDeleteContact)).
+		Body(values.StringF(values.StrDeleteContactConfirm, contact.Name)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		PositiveButton(values.String(values.StrRemove), func(isChecked bool) bool {
+			pg.WL.Wallet.DeleteContact(contact.Address)
+			pg.loadContacts()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+func (pg *AddressBookPage) showImportModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrFilePath)).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrImport), func(path string, tim *modal.TextInputModal) bool {
+			count, err := pg.WL.ImportAddressBook(path)
+			if err != nil {
+				tim.SetError(components.TranslateErr(err))
+				tim.SetLoading(false)
+				return false
+			}
+			pg.loadContacts()
+			pg.Toast.Notify(values.StringF(values.StrContactsImported, count))
+			return true
+		})
+
+	textModal.Title(values.String(values.StrImportAddressBook)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+func (pg *AddressBookPage) showExportModal() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrFilePath)).
+		Text(pg.WL.AddressBookPath()).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrExport), func(path string, tim *modal.TextInputModal) bool {
+			if err := pg.WL.ExportAddressBook(path); err != nil {
+				tim.SetError(components.TranslateErr(err))
+				tim.SetLoading(false)
+				return false
+			}
+			pg.Toast.Notify(values.StringF(values.StrAddressBookExported, path))
+			return true
+		})
+
+	textModal.Title(values.String(values.StrExportAddressBook)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if pg.addButton.Clicked() {
+		pg.showContactModal(nil)
+	}
+
+	if pg.importButton.Clicked() {
+		pg.showImportModal()
+	}
+
+	if pg.exportButton.Clicked() {
+		pg.showExportModal()
+	}
+
+	for _, item := range pg.items {
+		if item.editButton.Button.Clicked() {
+			contact := item.Contact
+			pg.showContactModal(&contact)
+		}
+		if item.deleteButton.Button.Clicked() {
+			pg.showDeleteModal(item.Contact)
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) Layout(gtx C) D {
+	search := strings.ToLower(strings.TrimSpace(pg.searchEditor.Editor.Text()))
+	visible := make([]*contactItem, 0, len(pg.items))
+	for _, item := range pg.items {
+		if contactMatches(item.Contact, search) {
+			visible = append(visible, item)
+		}
+	}
+
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(values.String(values.StrAddressBook)).Layout)
+						}),
+						layout.Flexed(1, func(gtx C) D {
+							return layout.E.Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+									layout.Rigid(pg.importButton.Layout),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.exportButton.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.addButton.Layout)
+									}),
+								)
+							})
+						}),
+					)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.searchEditor.Layout)
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+						if len(visible) == 0 {
+							txt := pg.Theme.Body1(values.String(values.StrNoContacts))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Center.Layout(gtx, txt.Layout)
+						}
+						return pg.listContainer.Layout(gtx, len(visible), func(gtx C, i int) D {
+							return pg.contactRow(gtx, visible[i], i == len(visible)-1)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+func (pg *AddressBookPage) contactRow(gtx C, item *contactItem, last bool) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return layoutContact(gtx, pg.Load, item.Contact, item.ownWallet)
+			}, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(item.editButton.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, item.deleteButton.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if last {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+	)
+}
+
+// layoutContact lays out the name, the address and the notes of contact, with
+// a warning if the address belongs to ownWallet.
+func layoutContact(gtx C, l *load.Load, contact wallet.Contact, ownWallet string) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			txt := l.Theme.Body1(contact.Name)
+			txt.Font.Weight = text.SemiBold
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := l.Theme.Body2(contact.Address)
+			txt.Color = l.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if contact.Notes == "" {
+				return D{}
+			}
+			txt := l.Theme.Caption(contact.Notes)
+			txt.Color = l.Theme.Color.GrayText3
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if ownWallet == "" {
+				return D{}
+			}
+			txt := l.Theme.Caption(values.StringF(values.StrOwnWalletAddress, ownWallet))
+			txt.Color = l.Theme.Color.Danger
+			return txt.Layout(gtx)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *AddressBookPage) OnNavigatedFrom() {}
@@ -0,0 +1,341 @@
+package send
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const ConsolidatePageID = "Consolidate"
+
+// ConsolidatePage is a wizard merging the small outputs of an account into a
+// single output at the low fee rate. The outputs are found first, then the
+// consolidation is previewed before being signed.
+type ConsolidatePage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	wallet  *dcrlibwallet.Wallet
+	account *dcrlibwallet.Account
+
+	pageContainer       *widget.List
+	backButton          decredmaterial.IconButton
+	thresholdEditor     decredmaterial.Editor
+	destinationSwitch   *decredmaterial.SwitchButtonText
+	destinationSelector *components.AccountSelector
+	findButton          decredmaterial.Button
+	previousButton      decredmaterial.Button
+	consolidateButton   decredmaterial.Button
+
+	// consolidation is set once the outputs are found, it is previewed until
+	// it is confirmed.
+	consolidation *wallet.Consolidation
+}
+
+func NewConsolidatePage(l *load.Load, account *dcrlibwallet.Account) *ConsolidatePage {
+	pg := &ConsolidatePage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ConsolidatePageID),
+		wallet:           l.WL.MultiWallet.WalletWithID(account.WalletID),
+		account:          account,
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.thresholdEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrConsolidationThreshold))
+	pg.thresholdEditor.Editor.SingleLine = true
+	pg.thresholdEditor.Editor.SetText(strconv.FormatFloat(wallet.DefaultConsolidationThreshold.ToCoin(), 'f', -1, 64))
+
+	pg.destinationSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrSameAccount)},
+		{Text: values.String(values.StrOtherAccount)},
+	})
+
+	pg.destinationSelector = components.NewAccountSelector(l, pg.wallet).
+		Title(values.String(values.StrConsolidateInto)).
+		AccountValidator(func(account *dcrlibwallet.Account) bool {
+			return account.Number != load.MaxInt32
+		})
+
+	pg.findButton = l.Theme.Button(values.String(values.StrFindCoins))
+	pg.previousButton = l.Theme.OutlineButton(values.String(values.StrBack))
+	pg.consolidateButton = l.Theme.Button(values.String(values.StrConsolidate))
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ConsolidatePage) OnNavigatedTo() {
+	pg.destinationSelector.SelectFirstWalletValidAccount(pg.wallet)
+}
+
+// findCoins plans the consolidation of the outputs up to the threshold.
+func (pg *ConsolidatePage) findCoins() {
+	value, err := strconv.ParseFloat(strings.TrimSpace(pg.thresholdEditor.Editor.Text()), 64)
+	threshold, amountErr := dcrutil.NewAmount(value)
+	if err != nil || amountErr != nil || threshold <= 0 {
+		pg.thresholdEditor.SetError(values.String(values.StrInvalidAmount))
+		return
+	}
+
+	utxos, err := pg.wallet.UnspentOutputs(pg.account.Number)
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+
+	pg.consolidation = wallet.PlanConsolidation(utxos, threshold, wallet.ReadCoinControl(pg.wallet), wallet.FeeRateLow)
+	if pg.consolidation == nil {
+		pg.thresholdEditor.SetError(values.StringF(values.StrNoCoinsToConsolidate, threshold.String()))
+	}
+}
+
+// destinationAccount returns the account receiving the consolidated output.
+func (pg *ConsolidatePage) destinationAccount() *dcrlibwallet.Account {
+	if pg.destinationSwitch.SelectedIndex() == 2 {
+		return pg.destinationSelector.SelectedAccount()
+	}
+	return pg.account
+}
+
+// consolidate signs and publishes the consolidation transaction with password.
+func (pg *ConsolidatePage) consolidate(password string) error {
+	address, err := pg.wallet.CurrentAddress(pg.destinationAccount().Number)
+	if err != nil {
+		return err
+	}
+
+	txAuthor, err := wallet.NewTxAuthor(pg.wallet, pg.account.Number)
+	if err != nil {
+		return err
+	}
+	if err = txAuthor.SetFeeRate(wallet.FeeRateLow); err != nil {
+		return err
+	}
+	txAuthor.UseInputs(pg.consolidation.Keys())
+	if err = txAuthor.AddSendDestination(address, 0, true); err != nil {
+		return err
+	}
+
+	_, err = txAuthor.Broadcast([]byte(password))
+	return err
+}
+
+func (pg *ConsolidatePage) showPasswordModal() {
+	if !pg.WL.MultiWallet.IsSynced() {
+		pg.Toast.NotifyError(components.TranslateErr(wallet.ErrNotSynced))
+		return
+	}
+
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(values.String(values.StrConsolidateCoins)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				if err := pg.consolidate(password); err != nil {
+					pm.SetError(components.TranslateErr(err))
+					pm.SetLoading(false)
+					return
+				}
+
+				// The outputs selected for the send page are spent.
+				delete(pg.SelectedUTXO[pg.account.WalletID], pg.account.Number)
+				pm.Dismiss()
+				pg.Toast.Notify(values.String(values.StrCoinsConsolidated))
+				pg.ParentNavigator().CloseCurrentPage()
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ConsolidatePage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	isSubmit, isChanged := decredmaterial.HandleEditorEvents(pg.thresholdEditor.Editor)
+	if isChanged {
+		pg.thresholdEditor.SetError("")
+	}
+
+	if pg.findButton.Clicked() || isSubmit {
+		pg.findCoins()
+	}
+
+	if pg.previousButton.Clicked() {
+		pg.consolidation = nil
+	}
+
+	if pg.consolidateButton.Clicked() {
+		pg.showPasswordModal()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ConsolidatePage) Layout(gtx C) D {
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							title := fmt.Sprintf("%s - %s", values.String(values.StrConsolidateCoins), pg.account.Name)
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(title).Layout)
+						}),
+					)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, i int) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+							if pg.consolidation == nil {
+								return pg.settingsLayout(gtx)
+							}
+							return pg.previewLayout(gtx)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+// settingsLayout lays out the first step of the wizard, where the outputs to
+// consolidate and the destination are chosen.
+func (pg *ConsolidatePage) settingsLayout(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.thresholdEditor.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(pg.Theme.Body1(values.String(values.StrConsolidateInto)).Layout),
+					layout.Flexed(1, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.destinationSwitch.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.destinationSwitch.SelectedIndex() != 2 {
+				return D{}
+			}
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return pg.destinationSelector.Layout(pg.ParentWindow(), gtx)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			return layout.E.Layout(gtx, pg.findButton.Layout)
+		}),
+	)
+}
+
+// previewLayout lays out the second step of the wizard, the preview of the
+// consolidation and of its savings.
+func (pg *ConsolidatePage) previewLayout(gtx C) D {
+	c := pg.consolidation
+	rows := []struct {
+		label, value string
+	}{
+		{values.String(values.StrCoinsToConsolidate), fmt.Sprintf("%d", len(c.Inputs))},
+		{values.String(values.StrDustCoins), fmt.Sprintf("%d", c.Dust)},
+		{values.String(values.StrTotal), c.Total.String()},
+		{values.String(values.StrConsolidationFee), c.Fee.String()},
+		{values.String(values.StrConsolidatedAmount), c.Output().String()},
+		{values.String(values.StrConsolidateInto), pg.destinationAccount().Name},
+		{values.String(values.StrFutureFeesSaved), c.FutureFee.String()},
+		{values.String(values.StrNetSavings), c.Savings().String()},
+	}
+
+	children := make([]layout.FlexChild, 0, len(rows)+3)
+	for _, row := range rows {
+		row := row
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						txt := pg.Theme.Body2(row.label)
+						txt.Color = pg.Theme.Color.GrayText2
+						return txt.Layout(gtx)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						return layout.E.Layout(gtx, pg.Theme.Body1(row.value).Layout)
+					}),
+				)
+			})
+		}))
+	}
+
+	warnings := make([]string, 0, 2)
+	if len(c.Inputs) == wallet.MaxConsolidationInputs {
+		warnings = append(warnings, values.StringF(values.StrConsolidationLimit, wallet.MaxConsolidationInputs))
+	}
+	if c.Savings() < 0 {
+		warnings = append(warnings, values.String(values.StrConsolidationNotWorth))
+	}
+	for _, warning := range warnings {
+		txt := pg.Theme.Body2(warning)
+		txt.Color = pg.Theme.Color.Danger
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, txt.Layout)
+		}))
+	}
+
+	children = append(children, layout.Rigid(func(gtx C) D {
+		return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(pg.previousButton.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.consolidateButton.Layout)
+					}),
+				)
+			})
+		})
+	}))
+
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ConsolidatePage) OnNavigatedFrom() {}
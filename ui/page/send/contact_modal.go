@@ -0,0 +1,170 @@
+package send
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// contactModal adds a contact to the address book or edits one.
+type contactModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	// address is the address of the contact edited, it is empty when a
+	// contact is added.
+	address string
+
+	nameEditor    decredmaterial.Editor
+	addressEditor decredmaterial.Editor
+	notesEditor   decredmaterial.Editor
+	cancelBtn     decredmaterial.Button
+	saveBtn       decredmaterial.Button
+
+	// ownWallet is the name of the wallet of the address entered, if it is
+	// one of the wallets of the user.
+	ownWallet    string
+	contactSaved func()
+}
+
+func newContactModal(l *load.Load, contact *wallet.Contact) *contactModal {
+	cm := &contactModal{
+		Load:  l,
+		Modal: l.Theme.ModalFloatTitle("contact_modal"),
+
+		nameEditor:    l.Theme.Editor(new(widget.Editor), values.String(values.StrContactName)),
+		addressEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrAddress)),
+		notesEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrNotes)),
+		cancelBtn:     l.Theme.OutlineButton(values.String(values.StrCancel)),
+		saveBtn:       l.Theme.Button(values.String(values.StrSave)),
+		contactSaved:  func() {},
+	}
+
+	cm.nameEditor.Editor.SingleLine = true
+	cm.addressEditor.Editor.SingleLine = true
+
+	if contact != nil {
+		cm.address = contact.Address
+		cm.nameEditor.Editor.SetText(contact.Name)
+		cm.addressEditor.Editor.SetText(contact.Address)
+		cm.notesEditor.Editor.SetText(contact.Notes)
+		cm.checkOwnAddress()
+	}
+
+	return cm
+}
+
+func (cm *contactModal) OnResume() {
+	cm.nameEditor.Editor.Focus()
+}
+
+func (cm *contactModal) OnDismiss() {}
+
+// checkOwnAddress finds the wallet of the address entered, the address of a
+// wallet of the user being likely a mistake.
+func (cm *contactModal) checkOwnAddress() {
+	cm.ownWallet = ""
+	address := strings.TrimSpace(cm.addressEditor.Editor.Text())
+	if cm.WL.MultiWallet.IsAddressValid(address) {
+		if own, walletName := cm.WL.Wallet.HaveAddress(address); own {
+			cm.ownWallet = walletName
+		}
+	}
+}
+
+func (cm *contactModal) save() {
+	contact := wallet.Contact{
+		Name:    cm.nameEditor.Editor.Text(),
+		Address: cm.addressEditor.Editor.Text(),
+		Notes:   cm.notesEditor.Editor.Text(),
+	}
+
+	err := cm.WL.Wallet.SaveContact(cm.address, contact)
+	switch {
+	case err == wallet.ErrContactName:
+		cm.nameEditor.SetError(values.String(values.StrContactNameRequired))
+	case err == wallet.ErrContactExists:
+		cm.addressEditor.SetError(values.String(values.StrContactExists))
+	case err != nil:
+		cm.addressEditor.SetError(values.String(values.StrInvalidAddress))
+	default:
+		cm.contactSaved()
+		cm.Dismiss()
+	}
+}
+
+func (cm *contactModal) Handle() {
+	if _, changed := decredmaterial.HandleEditorEvents(cm.nameEditor.Editor); changed {
+		cm.nameEditor.SetError("")
+	}
+	if _, changed := decredmaterial.HandleEditorEvents(cm.addressEditor.Editor); changed {
+		cm.addressEditor.SetError("")
+		cm.checkOwnAddress()
+	}
+
+	cm.saveBtn.SetEnabled(strings.TrimSpace(cm.nameEditor.Editor.Text()) != "" &&
+		strings.TrimSpace(cm.addressEditor.Editor.Text()) != "")
+
+	if cm.saveBtn.Clicked() {
+		cm.save()
+	}
+
+	if cm.cancelBtn.Clicked() || cm.Modal.BackdropClicked(true) {
+		cm.Dismiss()
+	}
+}
+
+func (cm *contactModal) Layout(gtx layout.Context) layout.Dimensions {
+	title := values.String(values.StrAddContact)
+	if cm.address != "" {
+		title = values.String(values.StrEditContact)
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := cm.Theme.H6(title)
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		cm.nameEditor.Layout,
+		cm.addressEditor.Layout,
+		func(gtx C) D {
+			if cm.ownWallet == "" {
+				return D{}
+			}
+			txt := cm.Theme.Body2(values.StringF(values.StrOwnWalletAddress, cm.ownWallet))
+			txt.Color = cm.Theme.Color.Danger
+			return txt.Layout(gtx)
+		},
+		cm.notesEditor.Layout,
+		func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				netType := "Mainnet"
+				if cm.WL.Wallet.Net == dcrlibwallet.Testnet3 {
+					netType = "Testnet"
+				}
+				txt := cm.Theme.Caption(netType)
+				txt.Color = cm.Theme.Color.GrayText2
+				return txt.Layout(gtx)
+			}, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, cm.cancelBtn.Layout)
+					}),
+					layout.Rigid(cm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return cm.Modal.Layout(gtx, w)
+}
@@ -0,0 +1,124 @@
+package send
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// contactPickerModal picks a contact of the address book as the destination of
+// a payment.
+type contactPickerModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	contacts      []wallet.Contact
+	clickables    []*decredmaterial.Clickable
+	listContainer layout.List
+	searchEditor  decredmaterial.Editor
+	manageBtn     decredmaterial.Button
+	cancelBtn     decredmaterial.Button
+
+	contactPicked func(wallet.Contact)
+	manage        func()
+}
+
+func newContactPickerModal(l *load.Load) *contactPickerModal {
+	cpm := &contactPickerModal{
+		Load:          l,
+		Modal:         l.Theme.ModalFloatTitle("contact_picker_modal"),
+		contacts:      l.WL.Wallet.AddressBook(),
+		listContainer: layout.List{Axis: layout.Vertical},
+		manageBtn:     l.Theme.OutlineButton(values.String(values.StrManageAddressBook)),
+		cancelBtn:     l.Theme.OutlineButton(values.String(values.StrCancel)),
+	}
+
+	cpm.clickables = make([]*decredmaterial.Clickable, len(cpm.contacts))
+	for i := range cpm.contacts {
+		cpm.clickables[i] = l.Theme.NewClickable(true)
+	}
+
+	cpm.searchEditor = l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearchContacts), l.Theme.Icons.SearchIcon, true)
+	cpm.searchEditor.Editor.SingleLine = true
+
+	return cpm
+}
+
+func (cpm *contactPickerModal) OnResume() {
+	cpm.searchEditor.Editor.Focus()
+}
+
+func (cpm *contactPickerModal) OnDismiss() {}
+
+func (cpm *contactPickerModal) Handle() {
+	for i, clickable := range cpm.clickables {
+		if clickable.Clicked() {
+			cpm.contactPicked(cpm.contacts[i])
+			cpm.Dismiss()
+		}
+	}
+
+	if cpm.manageBtn.Clicked() {
+		cpm.Dismiss()
+		cpm.manage()
+	}
+
+	if cpm.cancelBtn.Clicked() || cpm.Modal.BackdropClicked(true) {
+		cpm.Dismiss()
+	}
+}
+
+func (cpm *contactPickerModal) Layout(gtx layout.Context) layout.Dimensions {
+	search := strings.ToLower(strings.TrimSpace(cpm.searchEditor.Editor.Text()))
+	var visible []int
+	for i, contact := range cpm.contacts {
+		if contactMatches(contact, search) {
+			visible = append(visible, i)
+		}
+	}
+
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := cpm.Theme.H6(values.String(values.StrAddressBook))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		cpm.searchEditor.Layout,
+		func(gtx C) D {
+			if len(visible) == 0 {
+				txt := cpm.Theme.Body1(values.String(values.StrNoContacts))
+				txt.Color = cpm.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}
+			gtx.Constraints.Max.Y = gtx.Dp(values.MarginPadding350)
+			return cpm.listContainer.Layout(gtx, len(visible), func(gtx C, i int) D {
+				index := visible[i]
+				return cpm.clickables[index].Layout(gtx, func(gtx C) D {
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.UniformInset(values.MarginPadding8).Layout(gtx, func(gtx C) D {
+						return layoutContact(gtx, cpm.Load, cpm.contacts[index], "")
+					})
+				})
+			})
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, cpm.cancelBtn.Layout)
+					}),
+					layout.Rigid(cpm.manageBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return cpm.Modal.Layout(gtx, w)
+}
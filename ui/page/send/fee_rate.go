@@ -0,0 +1,95 @@
+package send
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// feeRateSelector selects the fee rate of the transaction among the presets of
+// the wallet package or a custom rate entered in atoms/kB.
+type feeRateSelector struct {
+	*load.Load
+
+	rateChanged  func()
+	presetSwitch *decredmaterial.SwitchButtonText
+	customEditor decredmaterial.Editor
+}
+
+func newFeeRateSelector(l *load.Load) *feeRateSelector {
+	fs := &feeRateSelector{
+		Load: l,
+	}
+
+	// The items follow the order of wallet.FeePresets, the custom rate is
+	// the last item.
+	fs.presetSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrFeeRateLow)},
+		{Text: values.String(values.StrFeeRateNormal)},
+		{Text: values.String(values.StrFeeRateHigh)},
+		{Text: values.String(values.StrFeeRateCustom)},
+	})
+
+	fs.customEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrCustomFeeRate))
+	fs.customEditor.Editor.SingleLine = true
+
+	return fs
+}
+
+// isCustom returns true if the rate is entered rather than a preset.
+func (fs *feeRateSelector) isCustom() bool {
+	return fs.presetSwitch.SelectedIndex() > len(wallet.FeePresets)
+}
+
+// feeRate returns the selected fee rate, ok is false if the custom rate is
+// invalid.
+func (fs *feeRateSelector) feeRate() (rate dcrutil.Amount, ok bool) {
+	if !fs.isCustom() {
+		return wallet.FeePresets[fs.presetSwitch.SelectedIndex()-1].Rate, true
+	}
+
+	text := strings.TrimSpace(fs.customEditor.Editor.Text())
+	atoms, err := strconv.ParseInt(text, 10, 64)
+	if err == nil {
+		err = wallet.ValidateFeeRate(dcrutil.Amount(atoms))
+	}
+	if err != nil {
+		if text != "" {
+			fs.customEditor.SetError(values.StringF(values.StrInvalidFeeRate, int64(wallet.FeeRateLow), int64(wallet.MaxFeeRate)))
+		}
+		return 0, false
+	}
+
+	fs.customEditor.SetError("")
+	return dcrutil.Amount(atoms), true
+}
+
+func (fs *feeRateSelector) handle() {
+	if fs.presetSwitch.Changed() {
+		if fs.isCustom() {
+			fs.customEditor.Editor.Focus()
+		}
+		fs.rateChanged()
+	}
+
+	for _, evt := range fs.customEditor.Editor.Events() {
+		if _, ok := evt.(widget.ChangeEvent); ok && fs.customEditor.Editor.Focused() {
+			fs.rateChanged()
+		}
+	}
+}
+
+// styleWidgets sets the appropriate colors for the fee rate widgets.
+func (fs *feeRateSelector) styleWidgets() {
+	fs.presetSwitch.Active, fs.presetSwitch.Inactive = fs.Theme.Color.Surface, color.NRGBA{}
+	fs.presetSwitch.ActiveTextColor, fs.presetSwitch.InactiveTextColor = fs.Theme.Color.GrayText1, fs.Theme.Color.Text
+	fs.customEditor.EditorStyle.Color = fs.Theme.Color.Text
+}
@@ -33,6 +33,8 @@ func (pg *Page) initLayoutWidgets() {
 	pg.nextButton.Inset = layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding15}
 	pg.nextButton.SetEnabled(false)
 
+	pg.addRecipientButton = pg.Theme.OutlineButton(values.String(values.StrAddRecipient))
+
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
 	pg.backButton.Icon = pg.Theme.Icons.ContentClear
 
@@ -91,15 +93,32 @@ func (pg *Page) topNav(gtx layout.Context) layout.Dimensions {
 
 func (pg *Page) getMoreItem() []moreItem {
 	return []moreItem{
-		// TODO: temp removal till issue #658 is resolved and V1.0 is release
-		// {
-		// 	text:   "Advanced mode",
-		// 	button: pg.Theme.NewClickable(true),
-		// 	id:     UTXOPageID,
-		// 	action: func() {
-		// 		pg.ChangeFragment(NewUTXOPage(pg.Load, pg.sourceAccountSelector.SelectedAccount()))
-		// 	},
-		// },
+		{
+			text:   values.String(values.StrCoinControl),
+			button: pg.Theme.NewClickable(true),
+			id:     UTXOPageID,
+			action: func() {
+				pg.moreOptionIsOpen = false
+				pg.ParentNavigator().Display(NewUTXOPage(pg.Load, pg.sourceAccountSelector.SelectedAccount()))
+			},
+		},
+		{
+			text:   values.String(values.StrImportRecipients),
+			button: pg.Theme.NewClickable(true),
+			action: func() {
+				pg.moreOptionIsOpen = false
+				pg.showImportRecipientsDialog()
+			},
+		},
+		{
+			text:   values.String(values.StrScheduledPayments),
+			button: pg.Theme.NewClickable(true),
+			id:     ScheduledPaymentsPageID,
+			action: func() {
+				pg.moreOptionIsOpen = false
+				pg.ParentNavigator().Display(NewScheduledPaymentsPage(pg.Load))
+			},
+		},
 		{
 			text:   values.String(values.StrClearAll),
 			button: pg.Theme.NewClickable(true),
@@ -155,7 +174,7 @@ func (pg *Page) Layout(gtx layout.Context) layout.Dimensions {
 func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 	pageContent := []func(gtx C) D{
 		func(gtx C) D {
-			return pg.pageSections(gtx, values.String(values.StrFrom), false, func(gtx C) D {
+			return pg.pageSections(gtx, values.String(values.StrFrom), nil, func(gtx C) D {
 				return pg.sourceAccountSelector.Layout(pg.ParentWindow(), gtx)
 			})
 		},
@@ -214,7 +233,7 @@ func (pg *Page) layoutDesktop(gtx layout.Context) layout.Dimensions {
 func (pg *Page) layoutMobile(gtx layout.Context) layout.Dimensions {
 	pageContent := []func(gtx C) D{
 		func(gtx C) D {
-			return pg.pageSections(gtx, values.String(values.StrFrom), false, func(gtx C) D {
+			return pg.pageSections(gtx, values.String(values.StrFrom), nil, func(gtx C) D {
 				return pg.sourceAccountSelector.Layout(pg.ParentWindow(), gtx)
 			})
 		},
@@ -271,7 +290,9 @@ func (pg *Page) layoutMobile(gtx layout.Context) layout.Dimensions {
 	return dims
 }
 
-func (pg *Page) pageSections(gtx layout.Context, title string, showAccountSwitch bool, body layout.Widget) layout.Dimensions {
+// pageSections lays out a section of the page with its title, the optional
+// headerActions drawn at the end of the title and the body.
+func (pg *Page) pageSections(gtx layout.Context, title string, headerActions layout.Widget, body layout.Widget) layout.Dimensions {
 	return pg.Theme.Card().Layout(gtx, func(gtx C) D {
 		return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
 			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -284,12 +305,12 @@ func (pg *Page) pageSections(gtx layout.Context, title string, showAccountSwitch
 							return inset.Layout(gtx, pg.Theme.Body1(title).Layout)
 						}),
 						layout.Flexed(1, func(gtx C) D {
-							if showAccountSwitch {
+							if headerActions != nil {
 								return layout.E.Layout(gtx, func(gtx C) D {
 									inset := layout.Inset{
 										Top: values.MarginPaddingMinus5,
 									}
-									return inset.Layout(gtx, pg.sendDestination.accountSwitch.Layout)
+									return inset.Layout(gtx, headerActions)
 								})
 							}
 							return layout.Dimensions{}
@@ -302,27 +323,79 @@ func (pg *Page) pageSections(gtx layout.Context, title string, showAccountSwitch
 	})
 }
 
+// toSection lays out a section for each recipient followed by the button to
+// add a recipient.
 func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
-	return pg.pageSections(gtx, values.String(values.StrTo), true, func(gtx C) D {
+	children := make([]layout.FlexChild, 0, len(pg.recipients)+1)
+	for i := range pg.recipients {
+		i := i
+		children = append(children, layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+				return pg.recipientSection(gtx, i)
+			})
+		}))
+	}
+	children = append(children, layout.Rigid(func(gtx C) D {
+		return layout.E.Layout(gtx, pg.addRecipientButton.Layout)
+	}))
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
+
+func (pg *Page) recipientSection(gtx layout.Context, index int) layout.Dimensions {
+	r := pg.recipients[index]
+	title := values.String(values.StrTo)
+	if len(pg.recipients) > 1 {
+		title = values.StringF(values.StrRecipientNumber, index+1)
+	}
+
+	headerActions := func(gtx C) D {
+		return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+			layout.Rigid(r.destination.accountSwitch.Layout),
+			layout.Rigid(func(gtx C) D {
+				if index == 0 {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, r.removeButton.Layout)
+			}),
+		)
+	}
+
+	return pg.pageSections(gtx, title, headerActions, func(gtx C) D {
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				return layout.Inset{
 					Bottom: values.MarginPadding16,
 				}.Layout(gtx, func(gtx C) D {
-					if !pg.sendDestination.sendToAddress {
-						return pg.sendDestination.destinationAccountSelector.Layout(pg.ParentWindow(), gtx)
+					if !r.destination.sendToAddress {
+						return r.destination.destinationAccountSelector.Layout(pg.ParentWindow(), gtx)
 					}
-					return pg.sendDestination.destinationAddressEditor.Layout(gtx)
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Flexed(1, r.destination.destinationAddressEditor.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, r.destination.addressBookButton.Layout)
+						}),
+					)
 				})
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.exchangeRate != -1 && pg.usdExchangeSet {
+				description := r.destination.paymentDescription()
+				if description == "" {
+					return layout.Dimensions{}
+				}
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					txt := pg.Theme.Body2(description)
+					txt.Color = pg.Theme.Color.GrayText2
+					return txt.Layout(gtx)
+				})
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.fiat != nil && pg.fiatExchangeSet {
 					return layout.Flex{
 						Axis:      layout.Horizontal,
 						Alignment: layout.Middle,
 					}.Layout(gtx,
 						layout.Flexed(0.45, func(gtx C) D {
-							return pg.amount.dcrAmountEditor.Layout(gtx)
+							return r.amount.dcrAmountEditor.Layout(gtx)
 						}),
 						layout.Flexed(0.1, func(gtx C) D {
 							return layout.Center.Layout(gtx, func(gtx C) D {
@@ -331,14 +404,14 @@ func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 							})
 						}),
 						layout.Flexed(0.45, func(gtx C) D {
-							return pg.amount.usdAmountEditor.Layout(gtx)
+							return r.amount.fiatAmountEditor.Layout(gtx)
 						}),
 					)
 				}
-				return pg.amount.dcrAmountEditor.Layout(gtx)
+				return r.amount.dcrAmountEditor.Layout(gtx)
 			}),
 			layout.Rigid(func(gtx C) D {
-				if pg.exchangeRateMessage == "" {
+				if index != 0 || pg.exchangeRateMessage == "" {
 					return layout.Dimensions{}
 				}
 				return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
@@ -377,8 +450,8 @@ func (pg *Page) toSection(gtx layout.Context) layout.Dimensions {
 func (pg *Page) feeSection(gtx layout.Context) layout.Dimensions {
 	collapsibleHeader := func(gtx C) D {
 		feeText := pg.txFee
-		if pg.exchangeRate != -1 && pg.usdExchangeSet {
-			feeText = fmt.Sprintf("%s (%s)", pg.txFee, pg.txFeeUSD)
+		if pg.fiat != nil && pg.fiatExchangeSet {
+			feeText = fmt.Sprintf("%s (%s)", pg.txFee, pg.txFeeFiat)
 		}
 		return pg.Theme.Body1(feeText).Layout(gtx)
 	}
@@ -394,8 +467,7 @@ func (pg *Page) feeSection(gtx layout.Context) layout.Dimensions {
 				return layout.UniformInset(values.MarginPadding15).Layout(gtx, func(gtx C) D {
 					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 						layout.Rigid(func(gtx C) D {
-							//TODO
-							return pg.contentRow(gtx, values.String(values.StrEstimatedTime), "10 minutes (2 blocks)")
+							return pg.contentRow(gtx, values.String(values.StrEstimatedTime), pg.estConfirmation)
 						}),
 						layout.Rigid(func(gtx C) D {
 							inset := layout.Inset{
@@ -407,7 +479,12 @@ func (pg *Page) feeSection(gtx layout.Context) layout.Dimensions {
 							})
 						}),
 						layout.Rigid(func(gtx C) D {
-							return pg.contentRow(gtx, values.String(values.StrFee)+" "+values.String(values.StrRate), "10 atoms/Byte")
+							return pg.contentRow(gtx, values.String(values.StrFee)+" "+values.String(values.StrRate), pg.feeRateText)
+						}),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Top: values.MarginPadding5}.Layout(gtx, func(gtx C) D {
+								return pg.contentRow(gtx, values.String(values.StrCoinSelection), pg.coinSelectionText)
+							})
 						}),
 					)
 				})
@@ -418,8 +495,18 @@ func (pg *Page) feeSection(gtx layout.Context) layout.Dimensions {
 		Bottom: values.MarginPadding75,
 	}
 	return inset.Layout(gtx, func(gtx C) D {
-		return pg.pageSections(gtx, values.String(values.StrFee), false, func(gtx C) D {
-			return pg.txFeeCollapsible.Layout(gtx, collapsibleHeader, collapsibleBody)
+		return pg.pageSections(gtx, values.String(values.StrFee), pg.feeRate.presetSwitch.Layout, func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if !pg.feeRate.isCustom() {
+						return layout.Dimensions{}
+					}
+					return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, pg.feeRate.customEditor.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return pg.txFeeCollapsible.Layout(gtx, collapsibleHeader, collapsibleBody)
+				}),
+			)
 		})
 	})
 }
@@ -442,8 +529,8 @@ func (pg *Page) balanceSection(gtx layout.Context) layout.Dimensions {
 								}
 								return inset.Layout(gtx, func(gtx C) D {
 									totalCostText := pg.totalCost
-									if pg.exchangeRate != -1 && pg.usdExchangeSet {
-										totalCostText = fmt.Sprintf("%s (%s)", pg.totalCost, pg.totalCostUSD)
+									if pg.fiat != nil && pg.fiatExchangeSet {
+										totalCostText = fmt.Sprintf("%s (%s)", pg.totalCost, pg.totalCostFiat)
 									}
 									return pg.contentRow(gtx, values.String(values.StrTotalCost), totalCostText)
 								})
@@ -0,0 +1,123 @@
+package send
+
+import (
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// offlineTxModal exports the unsigned transaction of a watch-only wallet, to
+// be signed by a wallet with the private keys on an offline computer.
+type offlineTxModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	offlineTx *wallet.OfflineTx
+	decoded   *wallet.DecodedOfflineTx
+
+	pathEditor decredmaterial.Editor
+	cancelBtn  decredmaterial.Button
+	exportBtn  decredmaterial.Button
+
+	txExported  func()
+	isExporting bool
+}
+
+func newOfflineTxModal(l *load.Load, offlineTx *wallet.OfflineTx, decoded *wallet.DecodedOfflineTx) *offlineTxModal {
+	om := &offlineTxModal{
+		Load:      l,
+		Modal:     l.Theme.ModalFloatTitle("offline_tx_modal"),
+		offlineTx: offlineTx,
+		decoded:   decoded,
+
+		pathEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrFilePath)),
+		cancelBtn:  l.Theme.OutlineButton(values.String(values.StrCancel)),
+		exportBtn:  l.Theme.Button(values.String(values.StrExport)),
+	}
+
+	om.pathEditor.Editor.SingleLine = true
+	om.pathEditor.Editor.SetText(l.WL.OfflineTxPath("unsigned-" + decoded.Hash[:8]))
+	om.Modal.ShowScrollbar(true)
+
+	return om
+}
+
+func (om *offlineTxModal) OnResume() {}
+
+func (om *offlineTxModal) OnDismiss() {}
+
+func (om *offlineTxModal) export() {
+	path := strings.TrimSpace(om.pathEditor.Editor.Text())
+	om.isExporting = true
+	om.Modal.SetDisabled(true)
+	go func() {
+		defer func() {
+			om.isExporting = false
+			om.Modal.SetDisabled(false)
+		}()
+
+		if err := om.WL.SaveOfflineTx(path, om.offlineTx); err != nil {
+			om.pathEditor.SetError(components.TranslateErr(err))
+			return
+		}
+
+		om.Toast.Notify(values.StringF(values.StrUnsignedTxExported, path))
+		om.txExported()
+		om.Dismiss()
+	}()
+}
+
+func (om *offlineTxModal) Handle() {
+	if _, changed := decredmaterial.HandleEditorEvents(om.pathEditor.Editor); changed {
+		om.pathEditor.SetError("")
+	}
+
+	om.exportBtn.SetEnabled(!om.isExporting && strings.TrimSpace(om.pathEditor.Editor.Text()) != "")
+
+	if om.exportBtn.Clicked() {
+		om.export()
+	}
+
+	if (om.cancelBtn.Clicked() || om.Modal.BackdropClicked(true)) && !om.isExporting {
+		om.Dismiss()
+	}
+}
+
+func (om *offlineTxModal) Layout(gtx layout.Context) layout.Dimensions {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := om.Theme.H6(values.String(values.StrExportUnsignedTx))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			txt := om.Theme.Body2(values.String(values.StrExportUnsignedTxNote))
+			txt.Color = om.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		},
+		func(gtx C) D {
+			return components.LayoutOfflineTx(gtx, om.Load, om.decoded)
+		},
+		om.pathEditor.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, om.cancelBtn.Layout)
+					}),
+					layout.Rigid(om.exportBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return om.Modal.Layout(gtx, w)
+}
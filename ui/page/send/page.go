@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +18,7 @@ import (
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const (
@@ -47,14 +47,19 @@ type Page struct {
 	pageContainer *widget.List
 
 	sourceAccountSelector *components.AccountSelector
-	sendDestination       *destination
-	amount                *sendAmount
-
-	backButton    decredmaterial.IconButton
-	infoButton    decredmaterial.IconButton
-	moreOption    decredmaterial.IconButton
-	retryExchange decredmaterial.Button
-	nextButton    decredmaterial.Button
+	recipients            []*recipient
+	// sendDestination and amount are those of the first recipient, which
+	// cannot be removed.
+	sendDestination *destination
+	amount          *sendAmount
+	feeRate         *feeRateSelector
+
+	backButton         decredmaterial.IconButton
+	infoButton         decredmaterial.IconButton
+	moreOption         decredmaterial.IconButton
+	retryExchange      decredmaterial.Button
+	nextButton         decredmaterial.Button
+	addRecipientButton decredmaterial.Button
 
 	txFeeCollapsible *decredmaterial.Collapsible
 	shadowBox        *decredmaterial.Shadow
@@ -65,8 +70,8 @@ type Page struct {
 	moreOptionIsOpen       bool
 	isFetchingExchangeRate bool
 
-	exchangeRate        float64
-	usdExchangeSet      bool
+	fiat                *wallet.FiatConverter
+	fiatExchangeSet     bool
 	exchangeRateMessage string
 	confirmTxModal      *sendConfirmModal
 
@@ -74,29 +79,36 @@ type Page struct {
 }
 
 type authoredTxData struct {
-	txAuthor            *dcrlibwallet.TxAuthor
-	destinationAddress  string
-	destinationAccount  *dcrlibwallet.Account
-	sourceAccount       *dcrlibwallet.Account
-	txFee               string
-	txFeeUSD            string
-	estSignedSize       string
-	totalCost           string
-	totalCostUSD        string
-	balanceAfterSend    string
-	balanceAfterSendUSD string
-	sendAmount          string
-	sendAmountUSD       string
+	txAuthor             *wallet.TxAuthor
+	destinations         []*txDestination
+	sourceAccount        *dcrlibwallet.Account
+	txFee                string
+	txFeeFiat            string
+	estSignedSize        string
+	feeRateText          string
+	estConfirmation      string
+	coinSelectionText    string
+	totalCost            string
+	totalCostFiat        string
+	balanceAfterSend     string
+	balanceAfterSendFiat string
+	sendAmount           string
+	sendAmountFiat       string
+}
+
+// txDestination is an output of the authored transaction.
+type txDestination struct {
+	address    string
+	account    *dcrlibwallet.Account
+	amountAtom int64
+	amount     string
+	amountFiat string
 }
 
 func NewSendPage(l *load.Load) *Page {
 	pg := &Page{
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(SendPageID),
-		sendDestination:  newSendDestination(l),
-		amount:           newSendAmount(l),
-
-		exchangeRate: -1,
 
 		authoredTxData: &authoredTxData{},
 		shadowBox:      l.Theme.Shadow(),
@@ -106,52 +118,153 @@ func NewSendPage(l *load.Load) *Page {
 	// Source account picker
 	pg.sourceAccountSelector = components.NewAccountSelector(l, nil).
 		Title(values.String(values.StrSendingAcct)).
+		IncludeWatchOnly().
 		AccountSelected(func(selectedAccount *dcrlibwallet.Account) {
 			pg.validateAndConstructTx()
 		}).
 		AccountValidator(func(account *dcrlibwallet.Account) bool {
 			wal := pg.Load.WL.MultiWallet.WalletWithID(account.WalletID)
 
-			// Imported and watch only wallet accounts are invalid for sending
-			accountIsValid := account.Number != load.MaxInt32 && !wal.IsWatchingOnlyWallet()
+			// Imported accounts are invalid for sending, watch only wallets
+			// export their transactions to be signed offline.
+			accountIsValid := account.Number != load.MaxInt32
 
 			if wal.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) &&
 				!wal.ReadBoolConfigValueForKey(load.SpendUnmixedFundsKey, false) {
 				// Spending unmixed fund isn't permitted for the selected wallet
 
 				// only mixed accounts can send to address for wallet with privacy setup
-				if pg.sendDestination.accountSwitch.SelectedIndex() == 1 {
+				if pg.sendsToAddress() {
 					accountIsValid = account.Number == wal.MixedAccountNumber()
 				}
 			}
 			return accountIsValid
 		})
 
-	pg.sendDestination.destinationAccountSelector.AccountSelected(func(selectedAccount *dcrlibwallet.Account) {
+	first := pg.addRecipient()
+	pg.sendDestination = first.destination
+	pg.amount = first.amount
+
+	pg.feeRate = newFeeRateSelector(l)
+	pg.feeRate.rateChanged = pg.validateAndConstructTxAmountOnly
+
+	pg.initLayoutWidgets()
+
+	return pg
+}
+
+// addRecipient adds an empty recipient to the transaction.
+func (pg *Page) addRecipient() *recipient {
+	r := newRecipient(pg.Load)
+
+	r.destination.destinationAccountSelector.AccountSelected(func(selectedAccount *dcrlibwallet.Account) {
 		pg.validateAndConstructTx()
 		pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil) // refresh source account
 	})
 
-	pg.sendDestination.addressChanged = func() {
+	r.destination.addressChanged = func() {
 		// refresh selected account when addressChanged is called
 		pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
 		pg.validateAndConstructTx()
 	}
 
-	pg.amount.amountChanged = func() {
+	r.destination.paymentURIEntered = func(uri *wallet.PaymentURI) {
+		pg.setPaymentURI(r, uri)
+	}
+
+	r.amount.amountChanged = func() {
+		// The balance of the source account is shared by all recipients, a
+		// change of any amount may resolve it.
+		for _, other := range pg.recipients {
+			if other.amount.amountErrorText == values.String(values.StrInsufficentFund) ||
+				other.amount.amountErrorText == values.String(values.StrInsufficientFundSendMax) {
+				other.amount.setError("")
+			}
+		}
 		pg.validateAndConstructTxAmountOnly()
 	}
 
-	pg.initLayoutWidgets()
+	if pg.fiat != nil {
+		r.amount.setFiatConverter(pg.fiat)
+	}
+	if len(pg.recipients) > 0 {
+		// The selector of the first recipient is initialized when the page
+		// is displayed.
+		r.destination.destinationAccountSelector.SelectFirstWalletValidAccount(nil)
+	}
 
-	return pg
+	pg.recipients = append(pg.recipients, r)
+	return r
+}
+
+// removeRecipient removes the recipient at index, the first recipient is never
+// removed.
+func (pg *Page) removeRecipient(index int) {
+	if index < 1 || index >= len(pg.recipients) {
+		return
+	}
+	pg.recipients = append(pg.recipients[:index], pg.recipients[index+1:]...)
+	pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
+	pg.validateAndConstructTx()
+}
+
+// setRecipients replaces the recipients with those imported from a file.
+func (pg *Page) setRecipients(recipients []wallet.Recipient) {
+	pg.resetFields()
+	for i, rcpt := range recipients {
+		r := pg.recipients[0]
+		if i > 0 {
+			r = pg.addRecipient()
+		}
+		r.destination.setAddress(rcpt.Address)
+		r.amount.setAmount(int64(rcpt.Amount))
+	}
+	pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
+	pg.validateAndConstructTx()
+}
+
+// OpenPaymentURI replaces the recipients with the recipient of the payment URI
+// s, with the requested amount if any.
+func (pg *Page) OpenPaymentURI(s string) error {
+	uri, err := wallet.ParsePaymentURI(s)
+	if err != nil {
+		return err
+	}
+	pg.resetFields()
+	pg.setPaymentURI(pg.recipients[0], uri)
+	return nil
+}
+
+// setPaymentURI prefills the recipient r with the address and the amount of
+// uri.
+func (pg *Page) setPaymentURI(r *recipient, uri *wallet.PaymentURI) {
+	r.destination.setPaymentURI(uri)
+	if uri.Amount > 0 {
+		r.amount.SendMax = false
+		r.amount.setAmount(int64(uri.Amount))
+	}
+	pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
+	pg.validateAndConstructTx()
+}
+
+// sendsToAddress returns true if any recipient is an address rather than an
+// account of the wallets.
+func (pg *Page) sendsToAddress() bool {
+	for _, r := range pg.recipients {
+		if r.destination.accountSwitch.SelectedIndex() == 1 {
+			return true
+		}
+	}
+	return false
 }
 
 // RestyleWidgets restyles select widgets to match the current theme. This is
 // especially necessary when the dark mode setting is changed.
 func (pg *Page) RestyleWidgets() {
-	pg.amount.styleWidgets()
-	pg.sendDestination.styleWidgets()
+	for _, r := range pg.recipients {
+		r.styleWidgets()
+	}
+	pg.feeRate.styleWidgets()
 }
 
 // OnNavigatedTo is called when the page is about to be displayed and
@@ -163,16 +276,19 @@ func (pg *Page) OnNavigatedTo() {
 
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
 	pg.sourceAccountSelector.ListenForTxNotifications(pg.ctx, pg.ParentWindow())
-	pg.sendDestination.destinationAccountSelector.SelectFirstWalletValidAccount(nil)
+	for _, r := range pg.recipients {
+		r.destination.destinationAccountSelector.SelectFirstWalletValidAccount(nil)
+	}
 	pg.sourceAccountSelector.SelectFirstWalletValidAccount(nil)
 	pg.sendDestination.destinationAddressEditor.Editor.Focus()
+	// The coins to spend may have been selected on the UTXO page.
+	pg.validateAndConstructTx()
 
-	currencyExchangeValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	if currencyExchangeValue == values.USDExchangeValue {
-		pg.usdExchangeSet = true
+	if _, _, ok := pg.WL.ExchangeRateSetting(); ok {
+		pg.fiatExchangeSet = true
 		go pg.fetchExchangeRate()
 	} else {
-		pg.usdExchangeSet = false
+		pg.fiatExchangeSet = false
 	}
 }
 
@@ -180,7 +296,9 @@ func (pg *Page) OnNavigatedTo() {
 // to enable restyling UI elements where necessary.
 // Satisfies the load.DarkModeChangeHandler interface.
 func (pg *Page) OnDarkModeChanged(isDarkModeOn bool) {
-	pg.amount.styleWidgets()
+	for _, r := range pg.recipients {
+		r.amount.styleWidgets()
+	}
 }
 
 func (pg *Page) fetchExchangeRate() {
@@ -190,30 +308,25 @@ func (pg *Page) fetchExchangeRate() {
 	maxAttempts := 5
 	delayBtwAttempts := 2 * time.Second
 	pg.isFetchingExchangeRate = true
-	desc := "for getting dcrUsdtBittrex exchange rate value"
+	desc := "for getting the dcr exchange rate value"
 	pg.exchangeRateMessage = "fetching exchange rate..."
 
-	var dcrUsdtBittrex load.DCRUSDTBittrex
-	attempts, err := components.RetryFunc(maxAttempts, delayBtwAttempts, desc, func() error {
-		return load.GetUSDExchangeValue(&dcrUsdtBittrex)
+	var rate *wallet.ExchangeRate
+	attempts, err := components.RetryFunc(maxAttempts, delayBtwAttempts, desc, func() (err error) {
+		rate, err = pg.WL.FetchExchangeRate(pg.ctx)
+		return err
 	})
 	if err != nil {
 		pg.exchangeRateMessage = "Exchange rate not fetched. Kindly check internet connection."
-		log.Printf("error fetching usd exchange rate value after %d attempts: %v", attempts, err)
-	} else if dcrUsdtBittrex.LastTradeRate == "" {
-		log.Printf("no error while fetching usd exchange rate in %d tries, but no rate was fetched", attempts)
-		pg.exchangeRateMessage = "Exchange rate not fetched."
+		log.Printf("error fetching exchange rate value after %d attempts: %v", attempts, err)
 	} else {
-		log.Printf("exchange rate value fetched: %s", dcrUsdtBittrex.LastTradeRate)
+		log.Printf("exchange rate value fetched from %s: %f %s", rate.Provider, rate.Rate, rate.Currency)
 		pg.exchangeRateMessage = ""
-		exchangeRate, err := strconv.ParseFloat(dcrUsdtBittrex.LastTradeRate, 64)
-		if err != nil {
-			pg.exchangeRateMessage = err.Error()
-		} else {
-			pg.exchangeRate = exchangeRate
-			pg.amount.setExchangeRate(exchangeRate)
-			pg.validateAndConstructTx() // convert estimates to usd
+		pg.fiat = load.NewFiatConverter(rate)
+		for _, r := range pg.recipients {
+			r.amount.setFiatConverter(pg.fiat)
 		}
+		pg.validateAndConstructTx() // convert estimates to fiat
 	}
 	pg.isFetchingExchangeRate = false
 	pg.ParentWindow().Reload()
@@ -227,8 +340,18 @@ func (pg *Page) validateAndConstructTx() {
 	}
 }
 
+// validateAndConstructTxAmountOnly estimates the fee of the transaction when
+// only the amounts are valid, sending to the selected accounts in place of the
+// invalid addresses.
 func (pg *Page) validateAndConstructTxAmountOnly() {
-	if !pg.sendDestination.validate() && pg.amount.amountIsValid() {
+	amountsAreValid := true
+	addressesAreValid := true
+	for _, r := range pg.recipients {
+		amountsAreValid = r.amount.amountIsValid() && amountsAreValid
+		addressesAreValid = r.destination.validate() && addressesAreValid
+	}
+
+	if !addressesAreValid && amountsAreValid {
 		pg.constructTx(true)
 	} else {
 		pg.validateAndConstructTx()
@@ -236,50 +359,96 @@ func (pg *Page) validateAndConstructTxAmountOnly() {
 }
 
 func (pg *Page) validate() bool {
-	amountIsValid := pg.amount.amountIsValid()
-	addressIsValid := pg.sendDestination.validate()
-
-	validForSending := amountIsValid && addressIsValid
+	validForSending := true
+	for _, r := range pg.recipients {
+		validForSending = r.validate() && validForSending
+	}
+	_, validFeeRate := pg.feeRate.feeRate()
 
-	return validForSending
+	return validForSending && validFeeRate
 }
 
 func (pg *Page) constructTx(useDefaultParams bool) {
-	destinationAddress, err := pg.sendDestination.destinationAddress(useDefaultParams)
-	if err != nil {
-		pg.feeEstimationError(err.Error())
+	feeRate, ok := pg.feeRate.feeRate()
+	if !ok {
+		pg.clearEstimates()
 		return
 	}
-	destinationAccount := pg.sendDestination.destinationAccount(useDefaultParams)
 
-	amountAtom, SendMax, err := pg.amount.validAmount()
+	sourceAccount := pg.sourceAccountSelector.SelectedAccount()
+	unsignedTx, err := wallet.NewTxAuthor(pg.WL.MultiWallet.WalletWithID(sourceAccount.WalletID), sourceAccount.Number)
+	if err == nil {
+		err = unsignedTx.SetFeeRate(feeRate)
+	}
 	if err != nil {
-		pg.feeEstimationError(err.Error())
+		pg.feeEstimationError(pg.amount, err)
 		return
 	}
 
-	sourceAccount := pg.sourceAccountSelector.SelectedAccount()
-	unsignedTx, err := pg.WL.MultiWallet.NewUnsignedTx(sourceAccount.WalletID, sourceAccount.Number)
-	if err != nil {
-		pg.feeEstimationError(err.Error())
-		return
+	// The outputs selected on the UTXO page are spent in place of those of
+	// the coin selection.
+	selectedUTXOs := pg.SelectedUTXO[sourceAccount.WalletID][sourceAccount.Number]
+	if len(selectedUTXOs) > 0 {
+		keys := make([]string, 0, len(selectedUTXOs))
+		for key := range selectedUTXOs {
+			keys = append(keys, key)
+		}
+		unsignedTx.UseInputs(keys)
 	}
 
-	err = unsignedTx.AddSendDestination(destinationAddress, amountAtom, SendMax)
-	if err != nil {
-		pg.feeEstimationError(err.Error())
-		return
+	var amountAtom int64
+	var sendMaxDestination *txDestination
+	destinations := make([]*txDestination, 0, len(pg.recipients))
+	for _, r := range pg.recipients {
+		useDefaultAddress := useDefaultParams && !r.destination.validate()
+		destinationAddress, err := r.destination.destinationAddress(useDefaultAddress)
+		if err != nil {
+			pg.feeEstimationError(r.amount, err)
+			return
+		}
+
+		destinationAmount, sendMax, err := r.amount.validAmount()
+		if err != nil {
+			pg.feeEstimationError(r.amount, err)
+			return
+		}
+
+		err = unsignedTx.AddSendDestination(destinationAddress, destinationAmount, sendMax)
+		if err != nil {
+			pg.feeEstimationError(r.amount, err)
+			return
+		}
+
+		output := &txDestination{
+			address:    destinationAddress,
+			account:    r.destination.destinationAccount(useDefaultAddress),
+			amountAtom: destinationAmount,
+		}
+		if sendMax {
+			sendMaxDestination = output
+		} else {
+			amountAtom += destinationAmount
+		}
+		destinations = append(destinations, output)
 	}
 
 	feeAndSize, err := unsignedTx.EstimateFeeAndSize()
 	if err != nil {
-		pg.feeEstimationError(err.Error())
+		pg.feeEstimationError(pg.amount, err)
 		return
 	}
 
 	feeAtom := feeAndSize.Fee.AtomValue
-	if SendMax {
-		amountAtom = sourceAccount.Balance.Spendable - feeAtom
+	if sendMaxDestination != nil {
+		// The max amount spends the selected outputs only, if any, and
+		// leaves out the frozen ones.
+		maxAmount, err := unsignedTx.SendMaxAmount()
+		if err != nil {
+			pg.feeEstimationError(pg.sendMaxAmount(), err)
+			return
+		}
+		sendMaxDestination.amountAtom = int64(maxAmount)
+		amountAtom += sendMaxDestination.amountAtom
 	}
 
 	totalSendingAmount := dcrutil.Amount(amountAtom + feeAtom)
@@ -288,38 +457,70 @@ func (pg *Page) constructTx(useDefaultParams bool) {
 	// populate display data
 	pg.txFee = dcrutil.Amount(feeAtom).String()
 	pg.estSignedSize = fmt.Sprintf("%d bytes", feeAndSize.EstimatedSignedSize)
+	pg.feeRateText = values.StringF(values.StrFeeRateAtomsKB, int64(feeRate))
+	blocks, confirmationTime := unsignedTx.EstimatedConfirmation()
+	pg.estConfirmation = values.StringF(values.StrConfirmationTime, int(confirmationTime.Minutes()), blocks)
+	if len(selectedUTXOs) > 0 {
+		pg.coinSelectionText = values.StringF(values.StrCoinsSelected, len(selectedUTXOs))
+	} else {
+		pg.coinSelectionText = coinSelectionName(wallet.ReadCoinSelection(pg.WL.MultiWallet.WalletWithID(sourceAccount.WalletID), sourceAccount.Number))
+	}
 	pg.totalCost = totalSendingAmount.String()
 	pg.balanceAfterSend = balanceAfterSend.String()
 	pg.sendAmount = dcrutil.Amount(amountAtom).String()
-	pg.destinationAddress = destinationAddress
-	pg.destinationAccount = destinationAccount
+	pg.destinations = destinations
 	pg.sourceAccount = sourceAccount
-
-	if SendMax {
-		// TODO: this workaround ignores the change events from the
-		// amount input to avoid construct tx cycle.
-		pg.amount.setAmount(amountAtom)
+	for _, output := range destinations {
+		output.amount = dcrutil.Amount(output.amountAtom).String()
 	}
 
-	if pg.exchangeRate != -1 && pg.usdExchangeSet {
-		pg.txFeeUSD = fmt.Sprintf("$%.4f", load.DCRToUSD(pg.exchangeRate, feeAndSize.Fee.DcrValue))
-		pg.totalCostUSD = load.FormatUSDBalance(pg.Printer, load.DCRToUSD(pg.exchangeRate, totalSendingAmount.ToCoin()))
-		pg.balanceAfterSendUSD = load.FormatUSDBalance(pg.Printer, load.DCRToUSD(pg.exchangeRate, balanceAfterSend.ToCoin()))
+	for i, r := range pg.recipients {
+		if r.amount.SendMax {
+			// TODO: this workaround ignores the change events from the
+			// amount input to avoid construct tx cycle.
+			r.amount.setAmount(destinations[i].amountAtom)
+		}
+	}
 
-		usdAmount := load.DCRToUSD(pg.exchangeRate, dcrutil.Amount(amountAtom).ToCoin())
-		pg.sendAmountUSD = load.FormatUSDBalance(pg.Printer, usdAmount)
+	if pg.fiat != nil && pg.fiatExchangeSet {
+		pg.txFeeFiat = pg.fiat.FormatPrecise(pg.fiat.ToFiat(feeAndSize.Fee.DcrValue), pg.fiat.Decimals()+2)
+		pg.totalCostFiat = pg.fiat.FormatAmount(totalSendingAmount)
+		pg.balanceAfterSendFiat = pg.fiat.FormatAmount(balanceAfterSend)
+		pg.sendAmountFiat = pg.fiat.FormatAmount(dcrutil.Amount(amountAtom))
+		for _, output := range destinations {
+			output.amountFiat = pg.fiat.FormatAmount(dcrutil.Amount(output.amountAtom))
+		}
 	}
 
 	pg.txAuthor = unsignedTx
 }
 
-func (pg *Page) feeEstimationError(err string) {
-	if err == dcrlibwallet.ErrInsufficientBalance {
-		pg.amount.setError(values.String(values.StrInsufficentFund))
-	} else if strings.Contains(err, invalidAmountErr) {
-		pg.amount.setError(invalidAmountErr)
+// sendMaxAmount returns the amount of the recipient receiving the max amount,
+// or the first amount if none does.
+func (pg *Page) sendMaxAmount() *sendAmount {
+	for _, r := range pg.recipients {
+		if r.amount.SendMax {
+			return r.amount
+		}
+	}
+	return pg.amount
+}
+
+// feeEstimationError displays err on the amount of the recipient that caused
+// it, or on the first amount if it is not specific to a recipient.
+func (pg *Page) feeEstimationError(amount *sendAmount, err error) {
+	if wallet.ErrorCodeOf(err) == wallet.ErrCodeInsufficientFunds {
+		if amount.SendMax {
+			amount.setError(values.String(values.StrInsufficentFund))
+		} else {
+			// Sending the whole balance requires the fee to be deducted
+			// from the amount.
+			amount.setError(values.String(values.StrInsufficientFundSendMax))
+		}
+	} else if strings.Contains(strings.ToLower(err.Error()), strings.ToLower(invalidAmountErr)) {
+		amount.setError(invalidAmountErr)
 	} else {
-		pg.amount.setError(err)
+		amount.setError(err.Error())
 		pg.Toast.NotifyError(values.StringF(values.StrTxEstimateErr, err))
 	}
 
@@ -329,20 +530,80 @@ func (pg *Page) feeEstimationError(err string) {
 func (pg *Page) clearEstimates() {
 	pg.txAuthor = nil
 	pg.txFee = " - "
-	pg.txFeeUSD = " - "
+	pg.txFeeFiat = " - "
 	pg.estSignedSize = " - "
+	pg.feeRateText = " - "
+	pg.estConfirmation = " - "
+	pg.coinSelectionText = " - "
 	pg.totalCost = " - "
-	pg.totalCostUSD = " - "
+	pg.totalCostFiat = " - "
 	pg.balanceAfterSend = " - "
-	pg.balanceAfterSendUSD = " - "
+	pg.balanceAfterSendFiat = " - "
 	pg.sendAmount = " - "
-	pg.sendAmountUSD = " - "
+	pg.sendAmountFiat = " - "
+}
+
+// showContactPicker picks the address of dst from the address book.
+func (pg *Page) showContactPicker(dst *destination) {
+	pickerModal := newContactPickerModal(pg.Load)
+	pickerModal.contactPicked = func(contact wallet.Contact) {
+		dst.setAddress(contact.Address)
+		dst.paymentURI = nil
+		pg.validateAndConstructTx()
+	}
+	pickerModal.manage = func() {
+		pg.ParentNavigator().Display(NewAddressBookPage(pg.Load))
+	}
+	pg.ParentWindow().ShowModal(pickerModal)
+}
+
+// showOfflineTxModal shows the unsigned transaction of a watch-only wallet to
+// export it for offline signing.
+func (pg *Page) showOfflineTxModal() {
+	offlineTx, err := pg.txAuthor.OfflineTx()
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+	decoded, err := offlineTx.DecodeForWallet(pg.WL.MultiWallet.WalletWithID(pg.sourceAccount.WalletID))
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+
+	offlineTxModal := newOfflineTxModal(pg.Load, offlineTx, decoded)
+	offlineTxModal.txExported = func() {
+		delete(pg.SelectedUTXO[pg.sourceAccount.WalletID], pg.sourceAccount.Number)
+		pg.resetFields()
+		pg.clearEstimates()
+	}
+	pg.ParentWindow().ShowModal(offlineTxModal)
 }
 
 func (pg *Page) resetFields() {
-	pg.sendDestination.clearAddressInput()
+	pg.recipients = pg.recipients[:1]
+	pg.recipients[0].resetFields()
+}
 
-	pg.amount.resetFields()
+func (pg *Page) showImportRecipientsDialog() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrRecipientsCSVPath)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrImport), func(path string, tim *modal.TextInputModal) bool {
+			recipients, err := pg.WL.ReadRecipientsCSV(path)
+			if err != nil {
+				tim.SetError(components.TranslateErr(err))
+				tim.SetLoading(false)
+				return false
+			}
+			pg.setRecipients(recipients)
+			pg.Toast.Notify(values.StringF(values.StrRecipientsImported, len(recipients)))
+			return true
+		})
+
+	textModal.Title(values.String(values.StrImportRecipients)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
 }
 
 // HandleUserInteractions is called just before Layout() to determine
@@ -352,8 +613,22 @@ func (pg *Page) resetFields() {
 // Part of the load.Page interface.
 func (pg *Page) HandleUserInteractions() {
 	pg.nextButton.SetEnabled(pg.validate())
-	pg.sendDestination.handle()
-	pg.amount.handle()
+	for _, r := range pg.recipients {
+		r.handle()
+	}
+	pg.feeRate.handle()
+
+	for pg.addRecipientButton.Clicked() {
+		r := pg.addRecipient()
+		r.destination.destinationAddressEditor.Editor.Focus()
+		pg.validateAndConstructTx()
+	}
+
+	for i := len(pg.recipients) - 1; i > 0; i-- {
+		if pg.recipients[i].removeButton.Button.Clicked() {
+			pg.removeRecipient(i)
+		}
+	}
 
 	if pg.backButton.Button.Clicked() {
 		pg.ParentNavigator().CloseCurrentPage()
@@ -378,11 +653,15 @@ func (pg *Page) HandleUserInteractions() {
 	}
 
 	for pg.nextButton.Clicked() {
-		if pg.txAuthor != nil {
+		if pg.txAuthor != nil && pg.WL.MultiWallet.WalletWithID(pg.sourceAccount.WalletID).IsWatchingOnlyWallet() {
+			pg.showOfflineTxModal()
+		} else if pg.txAuthor != nil {
 			pg.confirmTxModal = newSendConfirmModal(pg.Load, pg.authoredTxData)
-			pg.confirmTxModal.exchangeRateSet = pg.exchangeRate != -1 && pg.usdExchangeSet
+			pg.confirmTxModal.exchangeRateSet = pg.fiat != nil && pg.fiatExchangeSet
 
 			pg.confirmTxModal.txSent = func() {
+				// The selected outputs are spent.
+				delete(pg.SelectedUTXO[pg.sourceAccount.WalletID], pg.sourceAccount.Number)
 				pg.resetFields()
 				pg.clearEstimates()
 			}
@@ -391,6 +670,12 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	}
 
+	for _, r := range pg.recipients {
+		if r.destination.addressBookButton.Button.Clicked() {
+			pg.showContactPicker(r.destination)
+		}
+	}
+
 	for pg.backdrop.Clicked() {
 		pg.moreOptionIsOpen = false
 	}
@@ -401,13 +686,15 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	}
 
+	// The amount of the first recipient is only focused automatically when
+	// it is the only recipient, not to steal the focus from the others.
 	modalShown := pg.confirmTxModal != nil && pg.confirmTxModal.IsShown()
+	autoFocus := !modalShown && len(pg.recipients) == 1
 
-	currencyValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	if currencyValue != values.USDExchangeValue {
+	if !pg.fiatExchangeSet {
 		switch {
 		case !pg.sendDestination.sendToAddress:
-			if !pg.amount.dcrAmountEditor.Editor.Focused() && !modalShown {
+			if !pg.amount.dcrAmountEditor.Editor.Focused() && autoFocus {
 				pg.amount.dcrAmountEditor.Editor.Focus()
 			}
 		default:
@@ -422,11 +709,11 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	} else {
 		switch {
-		case !pg.sendDestination.sendToAddress && !(pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.usdAmountEditor.Editor.Focused()):
-			if !modalShown {
+		case !pg.sendDestination.sendToAddress && !(pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.fiatAmountEditor.Editor.Focused()):
+			if autoFocus {
 				pg.amount.dcrAmountEditor.Editor.Focus()
 			}
-		case !pg.sendDestination.sendToAddress && (pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.usdAmountEditor.Editor.Focused()):
+		case !pg.sendDestination.sendToAddress && (pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.fiatAmountEditor.Editor.Focused()):
 		default:
 			if pg.sendDestination.accountSwitch.Changed() {
 				if !pg.sendDestination.validate() {
@@ -438,42 +725,44 @@ func (pg *Page) HandleUserInteractions() {
 		}
 	}
 
-	// if destination switch is equal to Address
-	if pg.sendDestination.sendToAddress {
-		if pg.sendDestination.validate() {
-			if currencyValue != values.USDExchangeValue {
-				if len(pg.amount.dcrAmountEditor.Editor.Text()) == 0 {
-					pg.amount.SendMax = false
-				}
-			} else {
-				if len(pg.amount.dcrAmountEditor.Editor.Text()) == 0 {
-					pg.amount.usdAmountEditor.Editor.SetText("")
-					pg.amount.SendMax = false
-				}
-			}
+	for _, r := range pg.recipients {
+		// if destination switch is equal to Address
+		if r.destination.sendToAddress && !r.destination.validate() {
+			continue
 		}
-	} else {
-		if currencyValue != values.USDExchangeValue {
-			if len(pg.amount.dcrAmountEditor.Editor.Text()) == 0 {
-				pg.amount.SendMax = false
-			}
-		} else {
-			if len(pg.amount.dcrAmountEditor.Editor.Text()) == 0 {
-				pg.amount.usdAmountEditor.Editor.SetText("")
-				pg.amount.SendMax = false
+		if len(r.amount.dcrAmountEditor.Editor.Text()) == 0 {
+			if pg.fiatExchangeSet {
+				r.amount.fiatAmountEditor.Editor.SetText("")
 			}
+			r.amount.SendMax = false
 		}
 	}
 
-	if len(pg.amount.dcrAmountEditor.Editor.Text()) > 0 && pg.sourceAccountSelector.Changed() {
-		pg.amount.validateDCRAmount()
+	if pg.sourceAccountSelector.Changed() {
+		for _, r := range pg.recipients {
+			if len(r.amount.dcrAmountEditor.Editor.Text()) > 0 {
+				r.amount.validateDCRAmount()
+			}
+		}
 		pg.validateAndConstructTxAmountOnly()
 	}
 
-	if pg.amount.IsMaxClicked() {
-		pg.amount.setError("")
-		pg.amount.SendMax = true
-		pg.amount.amountChanged()
+	for _, r := range pg.recipients {
+		if r.amount.IsMaxClicked() {
+			if r.amount.SendMax {
+				// Max toggles the max amount off.
+				r.amount.resetFields()
+				pg.validateAndConstructTxAmountOnly()
+				continue
+			}
+			// Only one recipient can receive the max amount.
+			for _, other := range pg.recipients {
+				other.amount.SendMax = false
+			}
+			r.amount.setError("")
+			r.amount.SendMax = true
+			r.amount.amountChanged()
+		}
 	}
 }
 
@@ -493,23 +782,14 @@ func (pg *Page) HandleKeyPress(evt *key.Event) {
 		return
 	}
 
-	currencyValue := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
-	if currencyValue != values.USDExchangeValue {
-		switch {
-		case !pg.sendDestination.sendToAddress:
-			decredmaterial.SwitchEditors(evt, pg.amount.dcrAmountEditor.Editor)
-		default:
-			decredmaterial.SwitchEditors(evt, pg.sendDestination.destinationAddressEditor.Editor, pg.amount.dcrAmountEditor.Editor)
-		}
-	} else {
-		switch {
-		case !pg.sendDestination.sendToAddress && !(pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.usdAmountEditor.Editor.Focused()):
-		case !pg.sendDestination.sendToAddress && (pg.amount.dcrAmountEditor.Editor.Focused() || pg.amount.usdAmountEditor.Editor.Focused()):
-			decredmaterial.SwitchEditors(evt, pg.amount.usdAmountEditor.Editor, pg.amount.dcrAmountEditor.Editor)
-		default:
-			decredmaterial.SwitchEditors(evt, pg.sendDestination.destinationAddressEditor.Editor, pg.amount.dcrAmountEditor.Editor, pg.amount.usdAmountEditor.Editor)
-		}
+	var editors []*widget.Editor
+	for _, r := range pg.recipients {
+		editors = append(editors, r.editors(pg.fiat != nil && pg.fiatExchangeSet)...)
+	}
+	if pg.feeRate.isCustom() {
+		editors = append(editors, pg.feeRate.customEditor.Editor)
 	}
+	decredmaterial.SwitchEditors(evt, editors...)
 }
 
 // OnNavigatedFrom is called when the page is about to be removed from
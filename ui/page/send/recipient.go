@@ -0,0 +1,66 @@
+package send
+
+import (
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// recipient is a destination of the transaction with the amount sent to it.
+type recipient struct {
+	destination *destination
+	amount      *sendAmount
+
+	removeButton decredmaterial.IconButton
+}
+
+func newRecipient(l *load.Load) *recipient {
+	r := &recipient{
+		destination: newSendDestination(l),
+		amount:      newSendAmount(l),
+	}
+
+	r.removeButton = l.Theme.IconButton(l.Theme.Icons.ContentClear)
+	r.removeButton.Size = values.MarginPadding20
+	r.removeButton.Inset = layout.UniformInset(values.MarginPadding4)
+
+	return r
+}
+
+func (r *recipient) validate() bool {
+	amountIsValid := r.amount.amountIsValid()
+	addressIsValid := r.destination.validate()
+
+	return amountIsValid && addressIsValid
+}
+
+// editors returns the editors of the recipient in tab order.
+func (r *recipient) editors(withFiat bool) []*widget.Editor {
+	var editors []*widget.Editor
+	if r.destination.sendToAddress {
+		editors = append(editors, r.destination.destinationAddressEditor.Editor)
+	}
+	editors = append(editors, r.amount.dcrAmountEditor.Editor)
+	if withFiat {
+		editors = append(editors, r.amount.fiatAmountEditor.Editor)
+	}
+	return editors
+}
+
+func (r *recipient) resetFields() {
+	r.destination.clearAddressInput()
+	r.amount.resetFields()
+}
+
+func (r *recipient) handle() {
+	r.destination.handle()
+	r.amount.handle()
+}
+
+func (r *recipient) styleWidgets() {
+	r.amount.styleWidgets()
+	r.destination.styleWidgets()
+}
@@ -0,0 +1,376 @@
+package send
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+const ScheduledPaymentPageID = "ScheduledPayment"
+
+// scheduleDateLayout is the format of the time of the first payment.
+const scheduleDateLayout = "2006-01-02 15:04"
+
+// ScheduledPaymentPage adds a scheduled payment or edits one.
+type ScheduledPaymentPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	// payment is the scheduled payment edited, its ID is empty when a
+	// payment is added.
+	payment wallet.ScheduledPayment
+
+	pageContainer     *widget.List
+	backButton        decredmaterial.IconButton
+	labelEditor       decredmaterial.Editor
+	addressEditor     decredmaterial.Editor
+	addressBookButton decredmaterial.IconButton
+	amountEditor      decredmaterial.Editor
+	currencySwitch    *decredmaterial.SwitchButtonText
+	accountSelector   *components.AccountSelector
+	scheduleSwitch    *decredmaterial.SwitchButtonText
+	cronEditor        decredmaterial.Editor
+	startEditor       decredmaterial.Editor
+	saveButton        decredmaterial.Button
+
+	// fiatCurrency is the currency amounts are entered in when selected, it
+	// is empty if currency conversion is disabled.
+	fiatCurrency string
+}
+
+// NewScheduledPaymentPage returns a page editing payment, or adding a
+// scheduled payment if payment is nil.
+func NewScheduledPaymentPage(l *load.Load, payment *wallet.ScheduledPayment) *ScheduledPaymentPage {
+	pg := &ScheduledPaymentPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ScheduledPaymentPageID),
+		pageContainer: &widget.List{
+			List: layout.List{Axis: layout.Vertical},
+		},
+		saveButton: l.Theme.Button(values.String(values.StrSave)),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	pg.labelEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrLabel))
+	pg.addressEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrDestAddr))
+	pg.amountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrAmount))
+	pg.cronEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrCronExpression))
+	pg.startEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrFirstPayment))
+	for _, editor := range []*decredmaterial.Editor{&pg.labelEditor, &pg.addressEditor, &pg.amountEditor, &pg.cronEditor, &pg.startEditor} {
+		editor.Editor.SingleLine = true
+	}
+
+	pg.addressBookButton = l.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.CommunicationContacts)))
+	pg.addressBookButton.Inset, pg.addressBookButton.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+
+	if _, currency, ok := l.WL.ExchangeRateSetting(); ok {
+		pg.fiatCurrency = strings.ToUpper(currency)
+	}
+	if payment != nil && payment.IsFiat() {
+		// Keep the currency of the payment if the setting changed since.
+		pg.fiatCurrency = payment.FiatCurrency
+	}
+	currencies := []decredmaterial.SwitchItem{{Text: "DCR"}}
+	if pg.fiatCurrency != "" {
+		currencies = append(currencies, decredmaterial.SwitchItem{Text: pg.fiatCurrency})
+	}
+	pg.currencySwitch = l.Theme.SwitchButtonText(currencies)
+
+	// The items follow the order of wallet.ScheduleKinds.
+	pg.scheduleSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrDaily)},
+		{Text: values.String(values.StrWeekly)},
+		{Text: values.String(values.StrMonthly)},
+		{Text: values.String(values.StrCron)},
+	})
+
+	pg.accountSelector = components.NewAccountSelector(l, nil).
+		Title(values.String(values.StrPayFrom)).
+		AccountSelected(func(*dcrlibwallet.Account) {}).
+		AccountValidator(func(account *dcrlibwallet.Account) bool {
+			wal := l.WL.MultiWallet.WalletWithID(account.WalletID)
+			if account.Number == load.MaxInt32 || wal.IsWatchingOnlyWallet() {
+				return false
+			}
+			// Only the mixed account sends to addresses if spending unmixed
+			// funds isn't permitted.
+			if wal.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) &&
+				!wal.ReadBoolConfigValueForKey(load.SpendUnmixedFundsKey, false) {
+				return account.Number == wal.MixedAccountNumber()
+			}
+			return true
+		})
+
+	if payment == nil {
+		start := time.Now().Truncate(time.Hour).Add(time.Hour)
+		pg.payment = wallet.ScheduledPayment{Schedule: wallet.Schedule{Kind: wallet.ScheduleMonthly}, Start: start}
+	} else {
+		pg.payment = *payment
+		pg.labelEditor.Editor.SetText(payment.Label)
+		pg.addressEditor.Editor.SetText(payment.Address)
+		if payment.IsFiat() {
+			pg.currencySwitch.SetSelectedIndex(2)
+			pg.amountEditor.Editor.SetText(strconv.FormatFloat(payment.FiatAmount, 'f', -1, 64))
+		} else {
+			pg.amountEditor.Editor.SetText(strconv.FormatFloat(dcrutil.Amount(payment.Amount).ToCoin(), 'f', -1, 64))
+		}
+		pg.cronEditor.Editor.SetText(payment.Schedule.Cron)
+
+		if wal := l.WL.MultiWallet.WalletWithID(payment.WalletID); wal != nil {
+			if account, err := wal.GetAccount(payment.Account); err == nil {
+				pg.accountSelector.SetSelectedAccount(account)
+			}
+		}
+	}
+
+	for i, kind := range wallet.ScheduleKinds {
+		if kind == pg.payment.Schedule.Kind {
+			pg.scheduleSwitch.SetSelectedIndex(i + 1)
+		}
+	}
+	pg.startEditor.Editor.SetText(pg.payment.Start.Local().Format(scheduleDateLayout))
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentPage) OnNavigatedTo() {
+	pg.accountSelector.SelectFirstWalletValidAccount(nil)
+	pg.labelEditor.Editor.Focus()
+}
+
+// scheduleKind returns the kind of schedule selected.
+func (pg *ScheduledPaymentPage) scheduleKind() wallet.ScheduleKind {
+	return wallet.ScheduleKinds[pg.scheduleSwitch.SelectedIndex()-1]
+}
+
+// isFiat returns true if the amount is entered in the fiat currency.
+func (pg *ScheduledPaymentPage) isFiat() bool {
+	return pg.currencySwitch.SelectedIndex() == 2
+}
+
+func (pg *ScheduledPaymentPage) showContactPicker() {
+	pickerModal := newContactPickerModal(pg.Load)
+	pickerModal.contactPicked = func(contact wallet.Contact) {
+		pg.addressEditor.Editor.SetText(contact.Address)
+		pg.addressEditor.SetError("")
+		if strings.TrimSpace(pg.labelEditor.Editor.Text()) == "" {
+			pg.labelEditor.Editor.SetText(contact.Name)
+		}
+	}
+	pickerModal.manage = func() {
+		pg.ParentNavigator().Display(NewAddressBookPage(pg.Load))
+	}
+	pg.ParentWindow().ShowModal(pickerModal)
+}
+
+// save validates the fields and saves the scheduled payment, the errors are
+// shown on the editors of the invalid fields.
+func (pg *ScheduledPaymentPage) save() {
+	payment := pg.payment
+	payment.Label = strings.TrimSpace(pg.labelEditor.Editor.Text())
+	payment.Address = strings.TrimSpace(pg.addressEditor.Editor.Text())
+	valid := true
+
+	if payment.Label == "" {
+		pg.labelEditor.SetError(values.String(values.StrLabelRequired))
+		valid = false
+	}
+	if !pg.WL.MultiWallet.IsAddressValid(payment.Address) {
+		pg.addressEditor.SetError(values.String(values.StrInvalidAddress))
+		valid = false
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(pg.amountEditor.Editor.Text()), 64)
+	payment.Amount, payment.FiatAmount, payment.FiatCurrency = 0, 0, ""
+	if pg.isFiat() {
+		payment.FiatAmount, payment.FiatCurrency = amount, pg.fiatCurrency
+	} else if atoms, convErr := dcrutil.NewAmount(amount); convErr == nil {
+		payment.Amount = int64(atoms)
+	}
+	if err != nil || amount <= 0 || (!pg.isFiat() && payment.Amount <= 0) {
+		pg.amountEditor.SetError(values.String(values.StrInvalidAmount))
+		valid = false
+	}
+
+	payment.Schedule = wallet.Schedule{Kind: pg.scheduleKind()}
+	if payment.Schedule.Kind == wallet.ScheduleCron {
+		payment.Schedule.Cron = strings.Join(strings.Fields(pg.cronEditor.Editor.Text()), " ")
+		if err := payment.Schedule.Validate(); err != nil {
+			pg.cronEditor.SetError(values.String(values.StrInvalidCronExpression))
+			valid = false
+		}
+	}
+
+	start, err := time.ParseInLocation(scheduleDateLayout, strings.TrimSpace(pg.startEditor.Editor.Text()), time.Local)
+	if err != nil {
+		pg.startEditor.SetError(values.String(values.StrInvalidDateTime))
+		valid = false
+	}
+	payment.Start = start
+
+	account := pg.accountSelector.SelectedAccount()
+	if account == nil || !valid {
+		return
+	}
+	payment.WalletID, payment.Account = account.WalletID, account.Number
+
+	if err := pg.WL.Wallet.SaveScheduledPayment(payment); err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+	pg.ParentNavigator().CloseCurrentPage()
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	for _, editor := range []*decredmaterial.Editor{&pg.labelEditor, &pg.addressEditor, &pg.amountEditor, &pg.cronEditor, &pg.startEditor} {
+		if _, changed := decredmaterial.HandleEditorEvents(editor.Editor); changed {
+			editor.SetError("")
+		}
+	}
+
+	if pg.scheduleSwitch.Changed() && pg.scheduleKind() == wallet.ScheduleCron {
+		pg.cronEditor.Editor.Focus()
+	}
+
+	pg.accountSelector.Handle(pg.ParentWindow())
+
+	if pg.addressBookButton.Button.Clicked() {
+		pg.showContactPicker()
+	}
+
+	if pg.saveButton.Clicked() {
+		pg.save()
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentPage) Layout(gtx C) D {
+	title := values.String(values.StrAddScheduledPayment)
+	if pg.payment.ID != "" {
+		title = values.String(values.StrEditScheduledPayment)
+	}
+
+	widgets := []layout.Widget{
+		pg.labelEditor.Layout,
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.addressEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.addressBookButton.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, pg.amountEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.currencySwitch.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return pg.accountSelector.Layout(pg.ParentWindow(), gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(pg.Theme.Label(values.TextSize16, values.String(values.StrSchedule)).Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, pg.scheduleSwitch.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			if pg.scheduleKind() != wallet.ScheduleCron {
+				return D{}
+			}
+			return pg.cronEditor.Layout(gtx)
+		},
+		pg.startEditor.Layout,
+		func(gtx C) D {
+			txt := pg.Theme.Caption(values.String(values.StrScheduledPaymentNote))
+			txt.Color = pg.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.E.Layout(gtx, pg.saveButton.Layout)
+		},
+	}
+
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(title).Layout)
+						}),
+					)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.List(pg.pageContainer).Layout(gtx, 1, func(gtx C, i int) D {
+					return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+						return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+							return layout.Flex{Axis: layout.Vertical}.Layout(gtx, scheduledPaymentRows(widgets)...)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+// scheduledPaymentRows spaces the rows of the form.
+func scheduledPaymentRows(widgets []layout.Widget) []layout.FlexChild {
+	rows := make([]layout.FlexChild, len(widgets))
+	for i, w := range widgets {
+		w := w
+		rows[i] = layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, w)
+		})
+	}
+	return rows
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentPage) OnNavigatedFrom() {}
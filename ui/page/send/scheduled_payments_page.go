@@ -0,0 +1,380 @@
+package send
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/godcr/app"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/exp/shiny/materialdesign/icons"
+)
+
+const ScheduledPaymentsPageID = "ScheduledPayments"
+
+// scheduledPaymentItem is a row of the scheduled payments page.
+type scheduledPaymentItem struct {
+	wallet.ScheduledPayment
+	// recipient is the name of the contact of the address, or the address.
+	recipient string
+	// source is the wallet and account the payment is made from.
+	source string
+	// dueRuns is the number of runs due, more than one if runs were missed,
+	// and latestRun the time of the latest.
+	dueRuns   int
+	latestRun time.Time
+
+	payButton    decredmaterial.Button
+	skipButton   decredmaterial.Button
+	editButton   decredmaterial.IconButton
+	deleteButton decredmaterial.IconButton
+}
+
+// ScheduledPaymentsPage lists the scheduled payments, and pays the payments
+// that are due once confirmed with the spending passphrase.
+type ScheduledPaymentsPage struct {
+	*load.Load
+	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
+	// that helps this Page satisfy the app.Page interface. It also defines
+	// helper methods for accessing the PageNavigator that displayed this page
+	// and the root WindowNavigator.
+	*app.GenericPageModal
+
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
+	listContainer layout.List
+	backButton    decredmaterial.IconButton
+	addButton     decredmaterial.Button
+
+	items []*scheduledPaymentItem
+	// loadedAt is the time the due runs were last computed.
+	loadedAt time.Time
+}
+
+func NewScheduledPaymentsPage(l *load.Load) *ScheduledPaymentsPage {
+	pg := &ScheduledPaymentsPage{
+		Load:             l,
+		GenericPageModal: app.NewGenericPageModal(ScheduledPaymentsPageID),
+		listContainer:    layout.List{Axis: layout.Vertical},
+		addButton:        l.Theme.Button(values.String(values.StrAddScheduledPayment)),
+	}
+
+	pg.backButton, _ = components.SubpageHeaderButtons(l)
+
+	return pg
+}
+
+// OnNavigatedTo is called when the page is about to be displayed and
+// may be used to initialize page features that are only relevant when
+// the page is displayed.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentsPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.loadPayments()
+}
+
+func (pg *ScheduledPaymentsPage) loadPayments() {
+	now := time.Now()
+	payments := pg.WL.Wallet.ScheduledPayments()
+	items := make([]*scheduledPaymentItem, len(payments))
+	for i, payment := range payments {
+		item := &scheduledPaymentItem{
+			ScheduledPayment: payment,
+			recipient:        payment.Address,
+			payButton:        pg.Theme.Button(values.String(values.StrPayNow)),
+			skipButton:       pg.Theme.OutlineButton(values.String(values.StrSkip)),
+		}
+		item.dueRuns, item.latestRun = payment.DueRuns(now)
+		if contact, ok := pg.WL.Wallet.Contact(payment.Address); ok {
+			item.recipient = contact.Name
+		}
+		if wal := pg.WL.MultiWallet.WalletWithID(payment.WalletID); wal != nil {
+			item.source = wal.Name
+			if name, err := wal.AccountName(payment.Account); err == nil {
+				item.source = fmt.Sprintf("%s - %s", wal.Name, name)
+			}
+		}
+
+		item.editButton = pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ImageEdit)))
+		item.deleteButton = pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ActionDelete)))
+		for _, btn := range []*decredmaterial.IconButton{&item.editButton, &item.deleteButton} {
+			btn.Inset, btn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+			btn.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
+		}
+		items[i] = item
+	}
+	pg.items = items
+	pg.loadedAt = now
+}
+
+// formatScheduledAmount returns the amount of payment with its currency.
+func formatScheduledAmount(payment wallet.ScheduledPayment) string {
+	if payment.IsFiat() {
+		return strconv.FormatFloat(payment.FiatAmount, 'f', -1, 64) + " " + payment.FiatCurrency
+	}
+	return dcrutil.Amount(payment.Amount).String()
+}
+
+// scheduleDescription returns the translated description of schedule.
+func scheduleDescription(schedule wallet.Schedule) string {
+	switch schedule.Kind {
+	case wallet.ScheduleDaily:
+		return values.String(values.StrDaily)
+	case wallet.ScheduleWeekly:
+		return values.String(values.StrWeekly)
+	case wallet.ScheduleMonthly:
+		return values.String(values.StrMonthly)
+	default:
+		return values.StringF(values.StrScheduleCronDesc, schedule.Cron)
+	}
+}
+
+// pay sends the payment of item, at the current exchange rate if it is in
+// fiat.
+func (pg *ScheduledPaymentsPage) pay(item *scheduledPaymentItem, password string) error {
+	var fiat *wallet.FiatConverter
+	if item.IsFiat() {
+		rate, err := pg.WL.FetchExchangeRate(pg.ctx)
+		if err != nil {
+			if err == wallet.ErrUnknownProvider {
+				return errors.New(values.String(values.StrNoExchangeRate))
+			}
+			return err
+		}
+		fiat = load.NewFiatConverter(rate)
+	}
+
+	_, err := pg.WL.Wallet.PayScheduledPayment(item.ScheduledPayment, item.latestRun, fiat, []byte(password))
+	if err == wallet.ErrNoExchangeRate {
+		return errors.New(values.String(values.StrNoExchangeRate))
+	}
+	return err
+}
+
+func (pg *ScheduledPaymentsPage) showPayModal(item *scheduledPaymentItem) {
+	if !pg.WL.MultiWallet.IsSynced() {
+		pg.Toast.NotifyError(components.TranslateErr(wallet.ErrNotSynced))
+		return
+	}
+
+	passwordModal := modal.NewPasswordModal(pg.Load).
+		Title(item.Label).
+		Description(values.StringF(values.StrSendAmountTo, formatScheduledAmount(item.ScheduledPayment), item.recipient)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButton(values.String(values.StrConfirm), func(password string, pm *modal.PasswordModal) bool {
+			go func() {
+				if err := pg.pay(item, password); err != nil {
+					pm.SetError(components.TranslateErr(err))
+					pm.SetLoading(false)
+					return
+				}
+
+				pm.Dismiss()
+				pg.Toast.Notify(values.StringF(values.StrScheduledPaymentPaid, item.Label))
+				pg.loadPayments()
+				pg.ParentWindow().Reload()
+			}()
+			return false
+		})
+	pg.ParentWindow().ShowModal(passwordModal)
+}
+
+func (pg *ScheduledPaymentsPage) showSkipModal(item *scheduledPaymentItem) {
+	confirmModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrSkipScheduledPayment)).
+		Body(values.StringF(values.StrSkipScheduledPaymentConfirm, item.Label)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrSkip), func(isChecked bool) bool {
+			pg.WL.Wallet.MarkScheduledPaymentRun(item.ID, item.latestRun, "")
+			pg.loadPayments()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+func (pg *ScheduledPaymentsPage) showDeleteModal(item *scheduledPaymentItem) {
+	confirmModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrDeleteScheduledPayment)).
+		Body(values.StringF(values.StrDeleteScheduledPaymentConfirm, item.Label)).
+		NegativeButton(values.String(values.StrCancel), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		PositiveButton(values.String(values.StrRemove), func(isChecked bool) bool {
+			pg.WL.Wallet.DeleteScheduledPayment(item.ID)
+			pg.loadPayments()
+			return true
+		})
+	pg.ParentWindow().ShowModal(confirmModal)
+}
+
+// HandleUserInteractions is called just before Layout() to determine
+// if any user interaction recently occurred on the page and may be
+// used to update the page's UI components shortly before they are
+// displayed.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentsPage) HandleUserInteractions() {
+	if pg.backButton.Button.Clicked() {
+		pg.ParentNavigator().CloseCurrentPage()
+	}
+
+	if time.Since(pg.loadedAt) > time.Minute {
+		// Payments become due while the page is displayed.
+		pg.loadPayments()
+	}
+
+	if pg.addButton.Clicked() {
+		pg.ParentNavigator().Display(NewScheduledPaymentPage(pg.Load, nil))
+	}
+
+	for _, item := range pg.items {
+		if item.payButton.Clicked() {
+			pg.showPayModal(item)
+		}
+		if item.skipButton.Clicked() {
+			pg.showSkipModal(item)
+		}
+		if item.editButton.Button.Clicked() {
+			payment := item.ScheduledPayment
+			pg.ParentNavigator().Display(NewScheduledPaymentPage(pg.Load, &payment))
+		}
+		if item.deleteButton.Button.Clicked() {
+			pg.showDeleteModal(item)
+		}
+	}
+}
+
+// Layout draws the page UI components into the provided layout context
+// to be eventually drawn on screen.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentsPage) Layout(gtx C) D {
+	return components.UniformPadding(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Bottom: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.backButton.Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.Theme.H6(values.String(values.StrScheduledPayments)).Layout)
+						}),
+						layout.Flexed(1, func(gtx C) D {
+							return layout.E.Layout(gtx, pg.addButton.Layout)
+						}),
+					)
+				})
+			}),
+			layout.Flexed(1, func(gtx C) D {
+				return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+					return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+						if len(pg.items) == 0 {
+							txt := pg.Theme.Body1(values.String(values.StrNoScheduledPayments))
+							txt.Color = pg.Theme.Color.GrayText3
+							return layout.Center.Layout(gtx, txt.Layout)
+						}
+						return pg.listContainer.Layout(gtx, len(pg.items), func(gtx C, i int) D {
+							return pg.paymentRow(gtx, pg.items[i], i == len(pg.items)-1)
+						})
+					})
+				})
+			}),
+		)
+	})
+}
+
+func (pg *ScheduledPaymentsPage) paymentRow(gtx C, item *scheduledPaymentItem, last bool) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			return components.EndToEndRow(gtx, func(gtx C) D {
+				return pg.layoutPayment(gtx, item)
+			}, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						if item.dueRuns == 0 {
+							return D{}
+						}
+						return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+							layout.Rigid(item.skipButton.Layout),
+							layout.Rigid(func(gtx C) D {
+								return layout.Inset{Left: values.MarginPadding8, Right: values.MarginPadding8}.Layout(gtx, item.payButton.Layout)
+							}),
+						)
+					}),
+					layout.Rigid(item.editButton.Layout),
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, item.deleteButton.Layout)
+					}),
+				)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if last {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, pg.Theme.Separator().Layout)
+		}),
+	)
+}
+
+// layoutPayment lays out the label, the amount, the recipient, the source
+// and the schedule of the payment of item, with its due runs.
+func (pg *ScheduledPaymentsPage) layoutPayment(gtx C, item *scheduledPaymentItem) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body1(fmt.Sprintf("%s - %s", item.Label, formatScheduledAmount(item.ScheduledPayment)))
+			txt.Font.Weight = text.SemiBold
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Body2(item.recipient)
+			txt.Color = pg.Theme.Color.GrayText2
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			txt := pg.Theme.Caption(fmt.Sprintf("%s  •  %s", item.source, scheduleDescription(item.Schedule)))
+			txt.Color = pg.Theme.Color.GrayText3
+			return txt.Layout(gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if item.dueRuns == 0 {
+				next := item.NextRun()
+				if next.IsZero() {
+					return D{}
+				}
+				txt := pg.Theme.Caption(values.StringF(values.StrNextPayment, next.Local().Format(scheduleDateLayout)))
+				txt.Color = pg.Theme.Color.GrayText3
+				return txt.Layout(gtx)
+			}
+
+			due := values.StringF(values.StrPaymentDue, item.NextRun().Local().Format(scheduleDateLayout))
+			if item.dueRuns > 1 {
+				due = fmt.Sprintf("%s. %s", due, values.StringF(values.StrMissedPayments, item.dueRuns))
+			}
+			txt := pg.Theme.Caption(due)
+			txt.Color = pg.Theme.Color.Danger
+			return txt.Layout(gtx)
+		}),
+	)
+}
+
+// OnNavigatedFrom is called when the page is about to be removed from
+// the displayed window. This method should ideally be used to disable
+// features that are irrelevant when the page is NOT displayed.
+// NOTE: The page may be re-displayed on the app's window, in which case
+// OnNavigatedTo() will be called again. This method should not destroy UI
+// components unless they'll be recreated in the OnNavigatedTo() method.
+// Part of the load.Page interface.
+func (pg *ScheduledPaymentsPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
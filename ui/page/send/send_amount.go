@@ -11,6 +11,7 @@ import (
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const invalidAmountErr = "Invalid amount" //TODO: use localized strings
@@ -18,24 +19,23 @@ const invalidAmountErr = "Invalid amount" //TODO: use localized strings
 type sendAmount struct {
 	*load.Load
 
-	dcrAmountEditor decredmaterial.Editor
-	usdAmountEditor decredmaterial.Editor
+	dcrAmountEditor  decredmaterial.Editor
+	fiatAmountEditor decredmaterial.Editor
 
-	SendMax               bool
-	dcrSendMaxChangeEvent bool
-	usdSendMaxChangeEvent bool
-	amountChanged         func()
+	SendMax                bool
+	dcrSendMaxChangeEvent  bool
+	fiatSendMaxChangeEvent bool
+	amountChanged          func()
 
 	amountErrorText string
 
-	exchangeRate float64
+	fiat *wallet.FiatConverter
 }
 
 func newSendAmount(l *load.Load) *sendAmount {
 
 	sa := &sendAmount{
-		Load:         l,
-		exchangeRate: -1,
+		Load: l,
 	}
 
 	sa.dcrAmountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrAmount)+" (DCR)")
@@ -47,14 +47,14 @@ func newSendAmount(l *load.Load) *sendAmount {
 	sa.dcrAmountEditor.CustomButton.Text = values.String(values.StrMax)
 	sa.dcrAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
-	sa.usdAmountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrAmount)+" (DCR)")
-	sa.usdAmountEditor.Editor.SetText("")
-	sa.usdAmountEditor.HasCustomButton = true
-	sa.usdAmountEditor.Editor.SingleLine = true
+	sa.fiatAmountEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrAmount))
+	sa.fiatAmountEditor.Editor.SetText("")
+	sa.fiatAmountEditor.HasCustomButton = true
+	sa.fiatAmountEditor.Editor.SingleLine = true
 
-	sa.usdAmountEditor.CustomButton.Inset = layout.UniformInset(values.MarginPadding2)
-	sa.usdAmountEditor.CustomButton.Text = values.String(values.StrMax)
-	sa.usdAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
+	sa.fiatAmountEditor.CustomButton.Inset = layout.UniformInset(values.MarginPadding2)
+	sa.fiatAmountEditor.CustomButton.Text = values.String(values.StrMax)
+	sa.fiatAmountEditor.CustomButton.CornerRadius = values.MarginPadding0
 
 	sa.styleWidgets()
 
@@ -67,14 +67,15 @@ func (sa *sendAmount) styleWidgets() {
 	sa.dcrAmountEditor.CustomButton.Color = sa.Theme.Color.Surface
 	sa.dcrAmountEditor.EditorStyle.Color = sa.Theme.Color.Text
 
-	sa.usdAmountEditor.CustomButton.Background = sa.Theme.Color.Gray1
-	sa.usdAmountEditor.CustomButton.Color = sa.Theme.Color.Surface
-	sa.usdAmountEditor.EditorStyle.Color = sa.Theme.Color.Text
+	sa.fiatAmountEditor.CustomButton.Background = sa.Theme.Color.Gray1
+	sa.fiatAmountEditor.CustomButton.Color = sa.Theme.Color.Surface
+	sa.fiatAmountEditor.EditorStyle.Color = sa.Theme.Color.Text
 }
 
-func (sa *sendAmount) setExchangeRate(exchangeRate float64) {
-	sa.exchangeRate = exchangeRate
-	sa.validateDCRAmount() // convert dcr input to usd
+func (sa *sendAmount) setFiatConverter(fiat *wallet.FiatConverter) {
+	sa.fiat = fiat
+	sa.fiatAmountEditor.Hint = fmt.Sprintf("%s (%s)", values.String(values.StrAmount), fiat.Code())
+	sa.validateDCRAmount() // convert dcr input to fiat
 }
 
 func (sa *sendAmount) setAmount(amount int64) {
@@ -83,11 +84,11 @@ func (sa *sendAmount) setAmount(amount int64) {
 	sa.dcrSendMaxChangeEvent = sa.SendMax
 	sa.dcrAmountEditor.Editor.SetText(fmt.Sprintf("%.8f", dcrutil.Amount(amount).ToCoin()))
 
-	if sa.exchangeRate != -1 {
-		usdAmount := load.DCRToUSD(sa.exchangeRate, dcrutil.Amount(amount).ToCoin())
+	if sa.fiat != nil {
+		fiatAmount := sa.fiat.ToFiat(dcrutil.Amount(amount).ToCoin())
 
-		sa.usdSendMaxChangeEvent = true
-		sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount))
+		sa.fiatSendMaxChangeEvent = true
+		sa.fiatAmountEditor.Editor.SetText(sa.fiat.EditorText(fiatAmount))
 	}
 }
 
@@ -115,31 +116,31 @@ func (sa *sendAmount) validateDCRAmount() {
 	if sa.inputsNotEmpty(sa.dcrAmountEditor.Editor) {
 		dcrAmount, err := strconv.ParseFloat(sa.dcrAmountEditor.Editor.Text(), 64)
 		if err != nil {
-			// empty usd input
-			sa.usdAmountEditor.Editor.SetText("")
+			// empty fiat input
+			sa.fiatAmountEditor.Editor.SetText("")
 			sa.amountErrorText = invalidAmountErr
 			// todo: invalid decimal places error
 			return
 		}
 
-		if sa.exchangeRate != -1 {
-			usdAmount := load.DCRToUSD(sa.exchangeRate, dcrAmount)
-			sa.usdAmountEditor.Editor.SetText(fmt.Sprintf("%.2f", usdAmount)) // 2 decimal places
+		if sa.fiat != nil {
+			fiatAmount := sa.fiat.ToFiat(dcrAmount)
+			sa.fiatAmountEditor.Editor.SetText(sa.fiat.EditorText(fiatAmount))
 		}
 
 		return
 	}
 
-	// empty usd input since this is empty
-	sa.usdAmountEditor.Editor.SetText("")
+	// empty fiat input since this is empty
+	sa.fiatAmountEditor.Editor.SetText("")
 }
 
-// validateUSDAmount is called when usd text changes
-func (sa *sendAmount) validateUSDAmount() bool {
+// validateFiatAmount is called when fiat text changes
+func (sa *sendAmount) validateFiatAmount() bool {
 
 	sa.amountErrorText = ""
-	if sa.inputsNotEmpty(sa.usdAmountEditor.Editor) {
-		usdAmount, err := strconv.ParseFloat(sa.usdAmountEditor.Editor.Text(), 64)
+	if sa.inputsNotEmpty(sa.fiatAmountEditor.Editor) {
+		fiatAmount, err := strconv.ParseFloat(sa.fiatAmountEditor.Editor.Text(), 64)
 		if err != nil {
 			// empty dcr input
 			sa.dcrAmountEditor.Editor.SetText("")
@@ -147,8 +148,8 @@ func (sa *sendAmount) validateUSDAmount() bool {
 			return false
 		}
 
-		if sa.exchangeRate != -1 {
-			dcrAmount := load.USDToDCR(sa.exchangeRate, usdAmount)
+		if sa.fiat != nil {
+			dcrAmount := sa.fiat.ToDCR(fiatAmount)
 			sa.dcrAmountEditor.Editor.SetText(fmt.Sprintf("%.8f", dcrAmount)) // 8 decimal places
 		}
 
@@ -182,7 +183,7 @@ func (sa *sendAmount) resetFields() {
 func (sa *sendAmount) clearAmount() {
 	sa.amountErrorText = ""
 	sa.dcrAmountEditor.Editor.SetText("")
-	sa.usdAmountEditor.Editor.SetText("")
+	sa.fiatAmountEditor.Editor.SetText("")
 }
 
 func (sa *sendAmount) handle() {
@@ -190,18 +191,18 @@ func (sa *sendAmount) handle() {
 
 	if sa.amountErrorText != "" {
 		sa.dcrAmountEditor.LineColor = sa.Theme.Color.Danger
-		sa.usdAmountEditor.LineColor = sa.Theme.Color.Danger
+		sa.fiatAmountEditor.LineColor = sa.Theme.Color.Danger
 	} else {
 		sa.dcrAmountEditor.LineColor = sa.Theme.Color.Gray2
-		sa.usdAmountEditor.LineColor = sa.Theme.Color.Gray2
+		sa.fiatAmountEditor.LineColor = sa.Theme.Color.Gray2
 	}
 
 	if sa.SendMax {
 		sa.dcrAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
-		sa.usdAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
+		sa.fiatAmountEditor.CustomButton.Background = sa.Theme.Color.Primary
 	} else if len(sa.dcrAmountEditor.Editor.Text()) < 1 || !sa.SendMax {
 		sa.dcrAmountEditor.CustomButton.Background = sa.Theme.Color.Gray1
-		sa.usdAmountEditor.CustomButton.Background = sa.Theme.Color.Gray1
+		sa.fiatAmountEditor.CustomButton.Background = sa.Theme.Color.Gray1
 	}
 
 	for _, evt := range sa.dcrAmountEditor.Editor.Events() {
@@ -220,16 +221,16 @@ func (sa *sendAmount) handle() {
 		}
 	}
 
-	for _, evt := range sa.usdAmountEditor.Editor.Events() {
-		if sa.usdAmountEditor.Editor.Focused() {
+	for _, evt := range sa.fiatAmountEditor.Editor.Events() {
+		if sa.fiatAmountEditor.Editor.Focused() {
 			switch evt.(type) {
 			case widget.ChangeEvent:
-				if sa.usdSendMaxChangeEvent {
-					sa.usdSendMaxChangeEvent = false
+				if sa.fiatSendMaxChangeEvent {
+					sa.fiatSendMaxChangeEvent = false
 					continue
 				}
 				sa.SendMax = false
-				sa.validateUSDAmount()
+				sa.validateFiatAmount()
 				sa.amountChanged()
 			}
 		}
@@ -237,7 +238,7 @@ func (sa *sendAmount) handle() {
 }
 
 func (sa *sendAmount) IsMaxClicked() bool {
-	if sa.dcrAmountEditor.CustomButton.Clicked() || sa.usdAmountEditor.CustomButton.Clicked() {
+	if sa.dcrAmountEditor.CustomButton.Clicked() || sa.fiatAmountEditor.CustomButton.Clicked() {
 		return true
 	}
 	return false
@@ -13,6 +13,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type sendConfirmModal struct {
@@ -65,17 +66,27 @@ func (scm *sendConfirmModal) broadcastTransaction() {
 		return
 	}
 
+	// The transactions composed offline are queued until peers connect.
+	if scm.WL.MultiWallet.IsConnectedToDecredNetwork() && !scm.WL.MultiWallet.IsSynced() {
+		scm.Toast.NotifyError(components.TranslateErr(wallet.ErrNotSynced))
+		return
+	}
+
 	scm.isSending = true
 	scm.Modal.SetDisabled(true)
 	go func() {
-		_, err := scm.authoredTxData.txAuthor.Broadcast([]byte(password))
+		queued, err := scm.WL.Wallet.BroadcastOrQueue(scm.authoredTxData.txAuthor, []byte(password))
 		scm.isSending = false
 		scm.Modal.SetDisabled(false)
 		if err != nil {
-			scm.Toast.NotifyError(err.Error())
+			scm.Toast.NotifyError(components.TranslateErr(err))
 			return
 		}
-		scm.Toast.Notify(values.String(values.StrTxSent))
+		if queued {
+			scm.Toast.Notify(values.String(values.StrTxQueued))
+		} else {
+			scm.Toast.Notify(values.String(values.StrTxSent))
+		}
 
 		scm.txSent()
 		scm.Dismiss()
@@ -127,7 +138,7 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 								layout.Flexed(1, func(gtx C) D {
 									if scm.exchangeRateSet {
 										return layout.E.Layout(gtx, func(gtx C) D {
-											txt := scm.Theme.Body1(scm.sendAmountUSD)
+											txt := scm.Theme.Body1(scm.sendAmountFiat)
 											txt.Color = scm.Theme.Color.GrayText2
 											return txt.Layout(gtx)
 										})
@@ -139,45 +150,14 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 					)
 				}),
 				layout.Rigid(func(gtx C) D {
-					return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
-						layout.Rigid(func(gtx C) D {
-							icon := decredmaterial.NewIcon(scm.Theme.Icons.NavigationArrowForward)
-							icon.Color = scm.Theme.Color.Gray1
-							return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
-								return icon.Layout(gtx, values.MarginPadding15)
-							})
-						}),
-						layout.Rigid(func(gtx C) D {
-							if scm.destinationAccount != nil {
-								return layout.E.Layout(gtx, func(gtx C) D {
-									return layout.Flex{}.Layout(gtx,
-										layout.Rigid(func(gtx C) D {
-											return scm.Theme.Body2(scm.destinationAccount.Name).Layout(gtx)
-										}),
-										layout.Rigid(func(gtx C) D {
-											card := scm.Theme.Card()
-											card.Radius = decredmaterial.Radius(0)
-											card.Color = scm.Theme.Color.Gray4
-											inset := layout.Inset{
-												Left: values.MarginPadding5,
-											}
-											return inset.Layout(gtx, func(gtx C) D {
-												return card.Layout(gtx, func(gtx C) D {
-													return layout.UniformInset(values.MarginPadding2).Layout(gtx, func(gtx C) D {
-														destinationWallet := scm.WL.MultiWallet.WalletWithID(scm.destinationAccount.WalletID)
-														txt := scm.Theme.Caption(destinationWallet.Name)
-														txt.Color = scm.Theme.Color.GrayText1
-														return txt.Layout(gtx)
-													})
-												})
-											})
-										}),
-									)
-								})
-							}
-							return scm.Theme.Body2(scm.destinationAddress).Layout(gtx)
-						}),
-					)
+					destinations := make([]layout.FlexChild, 0, len(scm.destinations))
+					for _, output := range scm.destinations {
+						output := output
+						destinations = append(destinations, layout.Rigid(func(gtx C) D {
+							return scm.destinationRow(gtx, output)
+						}))
+					}
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx, destinations...)
 				}),
 			)
 		},
@@ -194,16 +174,20 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 					return layout.Inset{Top: values.MarginPadding8, Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
 						txFeeText := scm.txFee
 						if scm.exchangeRateSet {
-							txFeeText = fmt.Sprintf("%s (%s)", scm.txFee, scm.txFeeUSD)
+							txFeeText = fmt.Sprintf("%s (%s)", scm.txFee, scm.txFeeFiat)
 						}
 
-						return scm.contentRow(gtx, values.String(values.StrFee), txFeeText, "")
+						feeLabel := values.String(values.StrFee)
+						if len(scm.destinations) > 1 {
+							feeLabel = values.StringF(values.StrTotalFeeRecipients, len(scm.destinations))
+						}
+						return scm.contentRow(gtx, feeLabel, txFeeText, "")
 					})
 				}),
 				layout.Rigid(func(gtx C) D {
 					totalCostText := scm.totalCost
 					if scm.exchangeRateSet {
-						totalCostText = fmt.Sprintf("%s (%s)", scm.totalCost, scm.totalCostUSD)
+						totalCostText = fmt.Sprintf("%s (%s)", scm.totalCost, scm.totalCostFiat)
 					}
 
 					return scm.contentRow(gtx, values.String(values.StrTotalCost), totalCostText, "")
@@ -259,6 +243,60 @@ func (scm *sendConfirmModal) Layout(gtx layout.Context) D {
 	return scm.Modal.Layout(gtx, w)
 }
 
+// destinationRow lays out an output of the transaction, with its amount if the
+// transaction has several outputs.
+func (scm *sendConfirmModal) destinationRow(gtx layout.Context, output *txDestination) layout.Dimensions {
+	return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			icon := decredmaterial.NewIcon(scm.Theme.Icons.NavigationArrowForward)
+			icon.Color = scm.Theme.Color.Gray1
+			return layout.Inset{Right: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				return icon.Layout(gtx, values.MarginPadding15)
+			})
+		}),
+		layout.Rigid(func(gtx C) D {
+			if output.account != nil {
+				return layout.E.Layout(gtx, func(gtx C) D {
+					return layout.Flex{}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return scm.Theme.Body2(output.account.Name).Layout(gtx)
+						}),
+						layout.Rigid(func(gtx C) D {
+							card := scm.Theme.Card()
+							card.Radius = decredmaterial.Radius(0)
+							card.Color = scm.Theme.Color.Gray4
+							inset := layout.Inset{
+								Left: values.MarginPadding5,
+							}
+							return inset.Layout(gtx, func(gtx C) D {
+								return card.Layout(gtx, func(gtx C) D {
+									return layout.UniformInset(values.MarginPadding2).Layout(gtx, func(gtx C) D {
+										destinationWallet := scm.WL.MultiWallet.WalletWithID(output.account.WalletID)
+										txt := scm.Theme.Caption(destinationWallet.Name)
+										txt.Color = scm.Theme.Color.GrayText1
+										return txt.Layout(gtx)
+									})
+								})
+							})
+						}),
+					)
+				})
+			}
+			return scm.Theme.Body2(output.address).Layout(gtx)
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			if len(scm.destinations) < 2 {
+				return layout.Dimensions{}
+			}
+			amount := output.amount
+			if scm.exchangeRateSet {
+				amount = fmt.Sprintf("%s (%s)", output.amount, output.amountFiat)
+			}
+			return layout.E.Layout(gtx, scm.Theme.Body2(amount).Layout)
+		}),
+	)
+}
+
 func (scm *sendConfirmModal) contentRow(gtx layout.Context, leftValue, rightValue, walletName string) layout.Dimensions {
 	return layout.Flex{}.Layout(gtx,
 		layout.Rigid(func(gtx C) D {
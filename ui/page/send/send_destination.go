@@ -5,6 +5,7 @@ import (
 	"image/color"
 	"strings"
 
+	"gioui.org/layout"
 	"gioui.org/widget"
 
 	"github.com/planetdecred/dcrlibwallet"
@@ -12,17 +13,25 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+	"golang.org/x/exp/shiny/materialdesign/icons"
 )
 
 type destination struct {
 	*load.Load
 
 	addressChanged             func()
+	paymentURIEntered          func(*wallet.PaymentURI)
 	destinationAddressEditor   decredmaterial.Editor
 	destinationAccountSelector *components.AccountSelector
 
 	sendToAddress bool
 	accountSwitch *decredmaterial.SwitchButtonText
+	// addressBookButton picks the address from the address book.
+	addressBookButton decredmaterial.IconButton
+
+	// paymentURI is the payment URI the address was read from.
+	paymentURI *wallet.PaymentURI
 }
 
 func newSendDestination(l *load.Load) *destination {
@@ -34,6 +43,10 @@ func newSendDestination(l *load.Load) *destination {
 	dst.destinationAddressEditor.Editor.SingleLine = true
 	dst.destinationAddressEditor.Editor.SetText("")
 
+	dst.addressBookButton = l.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.CommunicationContacts)))
+	dst.addressBookButton.Size = values.MarginPadding20
+	dst.addressBookButton.Inset = layout.UniformInset(values.MarginPadding4)
+
 	dst.accountSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
 		{Text: values.String(values.StrAddress)},
 		{Text: values.String(values.StrMyAcct)},
@@ -99,6 +112,13 @@ func (dst *destination) validateDestinationAddress() (bool, string) {
 		return false, address
 	}
 
+	if wallet.IsPaymentURI(address) {
+		// The address of a valid URI replaces the URI as soon as it is
+		// entered.
+		dst.destinationAddressEditor.SetError(values.String(values.StrInvalidPaymentURI))
+		return false, address
+	}
+
 	if dst.WL.MultiWallet.IsAddressValid(address) {
 		dst.destinationAddressEditor.SetError("")
 		return true, address
@@ -120,6 +140,46 @@ func (dst *destination) validate() bool {
 func (dst *destination) clearAddressInput() {
 	dst.destinationAddressEditor.SetError("")
 	dst.destinationAddressEditor.Editor.SetText("")
+	dst.paymentURI = nil
+}
+
+// setPaymentURI sends to the address of uri and keeps uri for its label and
+// message to be displayed.
+func (dst *destination) setPaymentURI(uri *wallet.PaymentURI) {
+	dst.setAddress(uri.Address)
+	dst.paymentURI = uri
+}
+
+// setAddress switches the destination to address and sets it.
+func (dst *destination) setAddress(address string) {
+	dst.accountSwitch.SetSelectedIndex(1) // address
+	dst.sendToAddress = true
+	dst.destinationAddressEditor.Editor.SetText(address)
+}
+
+// paymentDescription returns the label and message of the payment URI the
+// address was read from, if the address was not changed since, or the name of
+// the contact of the address.
+func (dst *destination) paymentDescription() string {
+	if !dst.sendToAddress {
+		return ""
+	}
+	address := strings.TrimSpace(dst.destinationAddressEditor.Editor.Text())
+	uri := dst.paymentURI
+	if uri == nil || uri.Address != address {
+		if contact, ok := dst.WL.Wallet.Contact(address); ok {
+			return values.StringF(values.StrContactDescription, contact.Name)
+		}
+		return ""
+	}
+	switch {
+	case uri.Label != "" && uri.Message != "":
+		return uri.Label + ": " + uri.Message
+	case uri.Label != "":
+		return uri.Label
+	default:
+		return uri.Message
+	}
 }
 
 func (dst *destination) handle() {
@@ -133,6 +193,11 @@ func (dst *destination) handle() {
 		if dst.destinationAddressEditor.Editor.Focused() {
 			switch evt.(type) {
 			case widget.ChangeEvent:
+				text := dst.destinationAddressEditor.Editor.Text()
+				if uri, err := wallet.ParsePaymentURI(text); err == nil {
+					dst.paymentURIEntered(uri)
+					continue
+				}
 				dst.addressChanged()
 			}
 		}
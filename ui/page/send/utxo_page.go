@@ -2,6 +2,9 @@ package send
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"gioui.org/io/clipboard"
 	"gioui.org/layout"
@@ -13,6 +16,7 @@ import (
 	"github.com/planetdecred/godcr/app"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -22,6 +26,15 @@ import (
 
 const UTXOPageID = "unspentTransactionOutput"
 
+// utxoItem is a row of the UTXO list.
+type utxoItem struct {
+	utxo        *wallet.UnspentOutput
+	checkbox    decredmaterial.CheckBoxStyle
+	frozen      decredmaterial.CheckBoxStyle
+	labelButton *decredmaterial.Clickable
+	copyButton  decredmaterial.IconButton
+}
+
 type UTXOPage struct {
 	*load.Load
 	// GenericPageModal defines methods such as ID() and OnAttachedToNavigator()
@@ -30,41 +43,70 @@ type UTXOPage struct {
 	// and the root WindowNavigator.
 	*app.GenericPageModal
 
-	utxoListContainer      layout.List
-	backButton             decredmaterial.IconButton
-	useUTXOButton          decredmaterial.Button
-	unspentOutputs         **wallet.UnspentOutputs
-	unspentOutputsSelected *map[int]map[int32]map[string]*wallet.UnspentOutput
-	checkboxes             []decredmaterial.CheckBoxStyle
-	copyButtons            []decredmaterial.IconButton
-	selectAllChexBox       decredmaterial.CheckBoxStyle
-	separator              decredmaterial.Line
+	utxoListContainer layout.List
+	backButton        decredmaterial.IconButton
+	useUTXOButton     decredmaterial.Button
+	consolidateButton decredmaterial.Button
+	selectAllChexBox  decredmaterial.CheckBoxStyle
+	hideFrozen        decredmaterial.CheckBoxStyle
+	searchEditor      decredmaterial.Editor
+	sortSwitch        *decredmaterial.SwitchButtonText
+	selectionSwitch   *decredmaterial.SwitchButtonText
+	separator         decredmaterial.Line
+
+	// selections are the coin selection strategies of selectionSwitch.
+	selections []wallet.CoinSelection
+	coins      wallet.CoinControl
+	items      []*utxoItem
+	// visibleItems are the items left by the filters, in the sort order.
+	visibleItems []*utxoItem
 
 	txnFee            string
 	txnAmount         string
 	txnAmountAfterFee string
 
-	selectedWalletID  int
-	selectedAccountID int32
+	wallet  *dcrlibwallet.Wallet
+	account *dcrlibwallet.Account
 }
 
 func NewUTXOPage(l *load.Load, account *dcrlibwallet.Account) *UTXOPage {
 	pg := &UTXOPage{
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(UTXOPageID),
-		unspentOutputs:   &l.WL.UnspentOutputs,
 		utxoListContainer: layout.List{
 			Axis: layout.Vertical,
 		},
-		unspentOutputsSelected: &l.SelectedUTXO,
-		selectAllChexBox:       l.Theme.CheckBox(new(widget.Bool), ""),
-		separator:              l.Theme.Separator(),
-		selectedWalletID:       account.WalletID,
-		selectedAccountID:      account.Number,
+		selectAllChexBox: l.Theme.CheckBox(new(widget.Bool), ""),
+		hideFrozen:       l.Theme.CheckBox(new(widget.Bool), values.String(values.StrHideFrozen)),
+		separator:        l.Theme.Separator(),
+		wallet:           l.WL.MultiWallet.WalletWithID(account.WalletID),
+		account:          account,
 	}
 
 	pg.backButton, _ = components.SubpageHeaderButtons(pg.Load)
-	pg.useUTXOButton = l.Theme.Button("OK")
+	pg.useUTXOButton = l.Theme.Button(values.String(values.StrUseSelectedCoins))
+	pg.consolidateButton = l.Theme.OutlineButton(values.String(values.StrConsolidateCoins))
+
+	pg.searchEditor = l.Theme.Editor(new(widget.Editor), values.String(values.StrSearchCoins))
+	pg.searchEditor.Editor.SingleLine = true
+
+	pg.sortSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrLargestFirst)},
+		{Text: values.String(values.StrSmallestFirst)},
+		{Text: values.String(values.StrNewestFirst)},
+		{Text: values.String(values.StrOldestFirst)},
+	})
+
+	var selectionItems []decredmaterial.SwitchItem
+	for _, selection := range wallet.CoinSelections {
+		// The privacy selection only spends mixed outputs.
+		if selection == wallet.CoinSelectionPrivacy && account.Number != pg.wallet.MixedAccountNumber() {
+			continue
+		}
+		pg.selections = append(pg.selections, selection)
+		selectionItems = append(selectionItems, decredmaterial.SwitchItem{Text: coinSelectionName(selection)})
+	}
+	pg.selectionSwitch = l.Theme.SwitchButtonText(selectionItems)
 
 	return pg
 }
@@ -74,7 +116,109 @@ func NewUTXOPage(l *load.Load, account *dcrlibwallet.Account) *UTXOPage {
 // the page is displayed.
 // Part of the load.Page interface.
 func (pg *UTXOPage) OnNavigatedTo() {
+	current := wallet.ReadCoinSelection(pg.wallet, pg.account.Number)
+	for i, selection := range pg.selections {
+		if selection == current {
+			pg.selectionSwitch.SetSelectedIndex(i + 1)
+		}
+	}
+
+	pg.coins = wallet.ReadCoinControl(pg.wallet)
+	pg.loadUTXOs()
+}
+
+// loadUTXOs lists the spendable outputs of the account, the outputs selected
+// before that are not spendable anymore are unselected.
+func (pg *UTXOPage) loadUTXOs() {
+	utxos, err := pg.wallet.UnspentOutputs(pg.account.Number)
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+
+	selected := pg.selectedUTXOs()
+	spendable := make(map[string]bool, len(utxos))
+	pg.items = make([]*utxoItem, len(utxos))
+	for i, utxo := range utxos {
+		item := &utxoItem{
+			utxo: &wallet.UnspentOutput{
+				UTXO:     *utxo,
+				Amount:   dcrutil.Amount(utxo.Amount).String(),
+				DateTime: time.Unix(utxo.ReceiveTime, 0).UTC().Format("2006-01-02 15:04"),
+			},
+			checkbox:    pg.Theme.CheckBox(new(widget.Bool), ""),
+			frozen:      pg.Theme.CheckBox(new(widget.Bool), ""),
+			labelButton: pg.Theme.NewClickable(true),
+		}
+		item.checkbox.CheckBox.Value = selected[utxo.OutputKey] != nil
+		item.frozen.CheckBox.Value = pg.coins.Frozen(utxo.OutputKey)
+
+		icoBtn := pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ContentContentCopy)))
+		icoBtn.Inset, icoBtn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
+		icoBtn.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
+		item.copyButton = icoBtn
+
+		pg.items[i] = item
+		spendable[utxo.OutputKey] = true
+	}
+
+	for key := range selected {
+		if !spendable[key] {
+			delete(selected, key)
+		}
+	}
 
+	pg.filterUTXOs()
+	pg.calculateAmountAndFeeUTXO()
+}
+
+// selectedUTXOs returns the outputs of the account selected to be spent by the
+// send page.
+func (pg *UTXOPage) selectedUTXOs() map[string]*wallet.UnspentOutput {
+	if pg.SelectedUTXO == nil {
+		pg.SelectedUTXO = make(map[int]map[int32]map[string]*wallet.UnspentOutput)
+	}
+	if pg.SelectedUTXO[pg.account.WalletID] == nil {
+		pg.SelectedUTXO[pg.account.WalletID] = make(map[int32]map[string]*wallet.UnspentOutput)
+	}
+	if pg.SelectedUTXO[pg.account.WalletID][pg.account.Number] == nil {
+		pg.SelectedUTXO[pg.account.WalletID][pg.account.Number] = make(map[string]*wallet.UnspentOutput)
+	}
+	return pg.SelectedUTXO[pg.account.WalletID][pg.account.Number]
+}
+
+// filterUTXOs sets the visible items to the items matching the search and the
+// frozen filter, in the selected order.
+func (pg *UTXOPage) filterUTXOs() {
+	search := strings.ToLower(strings.TrimSpace(pg.searchEditor.Editor.Text()))
+	pg.visibleItems = pg.visibleItems[:0]
+	for _, item := range pg.items {
+		utxo := item.utxo.UTXO
+		if pg.hideFrozen.CheckBox.Value && item.frozen.CheckBox.Value {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(utxo.Addresses), search) &&
+			!strings.Contains(strings.ToLower(pg.coins.Label(utxo.OutputKey)), search) &&
+			!strings.Contains(utxo.OutputKey, search) {
+			continue
+		}
+		pg.visibleItems = append(pg.visibleItems, item)
+	}
+
+	less := func(i, j int) bool {
+		a, b := pg.visibleItems[i].utxo.UTXO, pg.visibleItems[j].utxo.UTXO
+		switch pg.sortSwitch.SelectedIndex() {
+		case 2: // smallest first
+			return a.Amount < b.Amount
+		case 3: // newest first
+			return a.ReceiveTime > b.ReceiveTime
+		case 4: // oldest first
+			return a.ReceiveTime < b.ReceiveTime
+		default: // largest first
+			return a.Amount > b.Amount
+		}
+	}
+	sort.SliceStable(pg.visibleItems, less)
 }
 
 // HandleUserInteractions is called just before Layout() to determine
@@ -83,89 +227,105 @@ func (pg *UTXOPage) OnNavigatedTo() {
 // displayed.
 // Part of the load.Page interface.
 func (pg *UTXOPage) HandleUserInteractions() {
-	if len(pg.checkboxes) != len((*pg.unspentOutputs).List) {
-		pg.checkboxes = make([]decredmaterial.CheckBoxStyle, len((*pg.unspentOutputs).List))
-		pg.copyButtons = make([]decredmaterial.IconButton, len((*pg.unspentOutputs).List))
-
-		for i := 0; i < len((*pg.unspentOutputs).List); i++ {
-			utxo := (*pg.unspentOutputs).List[i]
-			pg.checkboxes[i] = pg.Theme.CheckBox(new(widget.Bool), "")
-			if (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] != nil {
-				pg.checkboxes[i].CheckBox.Value = true
-			}
-			icoBtn := pg.Theme.IconButton(decredmaterial.MustIcon(widget.NewIcon(icons.ContentContentCopy)))
-			icoBtn.Inset, icoBtn.Size = layout.UniformInset(values.MarginPadding5), values.MarginPadding20
-			icoBtn.ChangeColorStyle(&values.ColorStyle{Background: pg.Theme.Color.Gray4})
-			pg.copyButtons[i] = icoBtn
-		}
-		pg.calculateAmountAndFeeUTXO()
+	if pg.backButton.Button.Clicked() {
+		// The selection is only kept with the use button.
+		delete(pg.SelectedUTXO[pg.account.WalletID], pg.account.Number)
+		pg.ParentNavigator().CloseCurrentPage()
 	}
 
-	if pg.backButton.Button.Clicked() {
-		pg.clearPageData()
+	if pg.useUTXOButton.Clicked() {
 		pg.ParentNavigator().CloseCurrentPage()
 	}
 
-	/*if pg.useUTXOButton.Button.Clicked() {
-		//TODO
-		//pg.ChangePage(send.PageID)
+	if pg.consolidateButton.Clicked() {
+		pg.ParentNavigator().Display(NewConsolidatePage(pg.Load, pg.account))
+	}
+
+	if pg.selectionSwitch.Changed() {
+		wallet.SaveCoinSelection(pg.wallet, pg.account.Number, pg.selections[pg.selectionSwitch.SelectedIndex()-1])
+	}
+
+	if pg.sortSwitch.Changed() || pg.hideFrozen.CheckBox.Changed() {
+		pg.filterUTXOs()
+	}
+
+	for _, evt := range pg.searchEditor.Editor.Events() {
+		if _, ok := evt.(widget.ChangeEvent); ok {
+			pg.filterUTXOs()
+		}
 	}
-	*/
 
 	if pg.selectAllChexBox.CheckBox.Changed() {
-		for i, utxo := range (*pg.unspentOutputs).List {
-			if pg.selectAllChexBox.CheckBox.Value {
-				pg.checkboxes[i].CheckBox.Value = true
-				(*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] = utxo
+		selected := pg.selectedUTXOs()
+		for _, item := range pg.visibleItems {
+			item.checkbox.CheckBox.Value = pg.selectAllChexBox.CheckBox.Value
+			if item.checkbox.CheckBox.Value {
+				selected[item.utxo.UTXO.OutputKey] = item.utxo
 			} else {
-				delete((*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID], utxo.UTXO.OutputKey)
-				pg.checkboxes[i].CheckBox.Value = false
+				delete(selected, item.utxo.UTXO.OutputKey)
 			}
 		}
 		pg.calculateAmountAndFeeUTXO()
 	}
+
+	for _, item := range pg.items {
+		pg.handleItem(item)
+	}
 }
 
-func (pg *UTXOPage) handlerCheckboxes(cb *decredmaterial.CheckBoxStyle, utxo *wallet.UnspentOutput) {
-	if cb.CheckBox.Changed() {
-		if cb.CheckBox.Value {
-			(*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID][utxo.UTXO.OutputKey] = utxo
+func (pg *UTXOPage) handleItem(item *utxoItem) {
+	key := item.utxo.UTXO.OutputKey
+	if item.checkbox.CheckBox.Changed() {
+		if item.checkbox.CheckBox.Value {
+			pg.selectedUTXOs()[key] = item.utxo
 		} else {
-			delete((*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID], utxo.UTXO.OutputKey)
+			delete(pg.selectedUTXOs(), key)
 		}
 		pg.calculateAmountAndFeeUTXO()
 	}
-}
 
-func (pg *UTXOPage) calculateAmountAndFeeUTXO() {
-	unsignedTx, err := pg.WL.MultiWallet.NewUnsignedTx(pg.selectedWalletID, pg.selectedAccountID)
-	if err != nil {
-		return
+	if item.frozen.CheckBox.Changed() {
+		info := pg.coins[key]
+		info.Frozen = item.frozen.CheckBox.Value
+		pg.coins[key] = info
+		wallet.SaveCoinControl(pg.wallet, pg.coins)
+		pg.filterUTXOs()
 	}
 
-	var utxoKeys []string
+	if item.labelButton.Clicked() {
+		textModal := modal.NewTextInputModal(pg.Load).
+			Hint(values.String(values.StrLabel)).
+			Text(pg.coins.Label(key)).
+			AllowEmpty(true).
+			PositiveButtonStyle(pg.Theme.Color.Primary, pg.Theme.Color.InvText).
+			PositiveButton(values.String(values.StrSave), func(label string, tim *modal.TextInputModal) bool {
+				info := pg.coins[key]
+				info.Label = strings.TrimSpace(label)
+				pg.coins[key] = info
+				wallet.SaveCoinControl(pg.wallet, pg.coins)
+				pg.filterUTXOs()
+				return true
+			})
+		textModal.Title(values.String(values.StrCoinLabel)).
+			NegativeButton(values.String(values.StrCancel), func() {})
+		pg.ParentWindow().ShowModal(textModal)
+	}
+}
+
+func (pg *UTXOPage) calculateAmountAndFeeUTXO() {
+	selected := pg.selectedUTXOs()
 	var totalAmount int64
-	for utxoKey, utxo := range (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID] {
-		utxoKeys = append(utxoKeys, utxoKey)
+	for _, utxo := range selected {
 		totalAmount += utxo.UTXO.Amount
 	}
 
-	err = unsignedTx.UseInputs(utxoKeys)
-	if err != nil {
-		return
-	}
-	feeAndSize, err := unsignedTx.EstimateFeeAndSize()
-	if err != nil {
-		return
+	var fee dcrutil.Amount
+	if len(selected) > 0 {
+		fee = wallet.EstimateSpendFee(len(selected), wallet.FeeRateLow)
 	}
 	pg.txnAmount = dcrutil.Amount(totalAmount).String()
-	pg.txnFee = dcrutil.Amount(feeAndSize.Fee.AtomValue).String()
-	pg.txnAmountAfterFee = dcrutil.Amount(totalAmount - feeAndSize.Fee.AtomValue).String()
-}
-
-func (pg *UTXOPage) clearPageData() {
-	pg.checkboxes = nil
-	pg.txnFee = ""
+	pg.txnFee = fee.String()
+	pg.txnAmountAfterFee = (dcrutil.Amount(totalAmount) - fee).String()
 }
 
 // Layout draws the page UI components into the provided layout context
@@ -182,8 +342,14 @@ func (pg *UTXOPage) Layout(gtx C) D {
 					layout.Rigid(func(gtx C) D {
 						return layout.Inset{
 							Left: values.MarginPadding10,
-							// Top:  values.MarginPaddingMinus10,
-						}.Layout(gtx, pg.Theme.H6("Coin Control").Layout)
+						}.Layout(gtx, pg.Theme.H6(values.String(values.StrCoinControl)).Layout)
+					}),
+					layout.Flexed(1, func(gtx C) D {
+						// Watch-only wallets cannot sign the consolidation.
+						if pg.wallet.IsWatchingOnlyWallet() {
+							return D{}
+						}
+						return layout.E.Layout(gtx, pg.consolidateButton.Layout)
 					}),
 				)
 			}),
@@ -194,17 +360,39 @@ func (pg *UTXOPage) Layout(gtx C) D {
 							return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
 								return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
 									layout.Flexed(0.25, func(gtx C) D {
-										utxos := (*pg.unspentOutputsSelected)[pg.selectedWalletID][pg.selectedAccountID]
-										return pg.textData(gtx, "Selected:  ", fmt.Sprintf("%d", len(utxos)))
+										return pg.textData(gtx, values.String(values.StrSelected)+":  ", fmt.Sprintf("%d", len(pg.selectedUTXOs())))
 									}),
 									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "Amount:  ", pg.txnAmount)
+										return pg.textData(gtx, values.String(values.StrAmount)+":  ", pg.txnAmount)
 									}),
 									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "Fee:  ", pg.txnFee)
+										return pg.textData(gtx, values.String(values.StrFee)+":  ", pg.txnFee)
 									}),
 									layout.Flexed(0.25, func(gtx C) D {
-										return pg.textData(gtx, "After Fee:  ", pg.txnAmountAfterFee)
+										return pg.textData(gtx, values.String(values.StrAfterFee)+":  ", pg.txnAmountAfterFee)
+									}),
+								)
+							})
+						}),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, pg.Theme.Body1(values.String(values.StrCoinSelection)).Layout)
+									}),
+									layout.Rigid(pg.selectionSwitch.Layout),
+								)
+							})
+						}),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Bottom: values.MarginPadding15}.Layout(gtx, func(gtx C) D {
+								return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
+									layout.Flexed(1, pg.searchEditor.Layout),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.sortSwitch.Layout)
+									}),
+									layout.Rigid(func(gtx C) D {
+										return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.hideFrozen.Layout)
 									}),
 								)
 							})
@@ -214,13 +402,8 @@ func (pg *UTXOPage) Layout(gtx C) D {
 							return pg.utxoRowHeader(gtx)
 						}),
 						layout.Flexed(1, func(gtx C) D {
-							if len(pg.checkboxes) == 0 {
-								return D{}
-							}
-							return pg.utxoListContainer.Layout(gtx, len((*pg.unspentOutputs).List), func(gtx C, index int) D {
-								utxo := (*pg.unspentOutputs).List[index]
-								pg.handlerCheckboxes(&pg.checkboxes[index], utxo)
-								return pg.utxoRow(gtx, utxo, index)
+							return pg.utxoListContainer.Layout(gtx, len(pg.visibleItems), func(gtx C, index int) D {
+								return pg.utxoRow(gtx, pg.visibleItems[index])
 							})
 						}),
 						layout.Rigid(func(gtx C) D {
@@ -252,32 +435,44 @@ func (pg *UTXOPage) utxoRowHeader(gtx C) D {
 			layout.Rigid(pg.selectAllChexBox.Layout),
 			layout.Rigid(func(gtx C) D {
 				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
-				txt.Text = "Amount"
+				txt.Text = values.String(values.StrAmount)
 				return txt.Layout(gtx)
 			}),
 			layout.Rigid(func(gtx C) D {
 				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding200)
-				txt.Text = "Address"
+				txt.Text = values.String(values.StrAddress)
 				return txt.Layout(gtx)
 			}),
 			layout.Rigid(func(gtx C) D {
-				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding100)
-				txt.Text = "Date (UTC)"
+				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
+				txt.Text = values.String(values.StrLabel)
+				return txt.Layout(gtx)
+			}),
+			layout.Rigid(func(gtx C) D {
+				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
+				txt.Text = values.String(values.StrDateUTC)
 				txt.Alignment = text.End
 				return txt.Layout(gtx)
 			}),
 			layout.Rigid(func(gtx C) D {
-				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding100)
-				txt.Text = "Confirmations"
+				gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding120)
+				txt.Text = values.String(values.StrConfirmations)
 				return txt.Layout(gtx)
 			}),
+			layout.Rigid(func(gtx C) D {
+				return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
+					txt.Text = values.String(values.StrFrozen)
+					return txt.Layout(gtx)
+				})
+			}),
 		)
 	})
 }
 
-func (pg *UTXOPage) utxoRow(gtx C, data *wallet.UnspentOutput, index int) D {
+func (pg *UTXOPage) utxoRow(gtx C, item *utxoItem) D {
+	data := item.utxo
 	return layout.Flex{Axis: layout.Horizontal, Alignment: layout.Middle}.Layout(gtx,
-		layout.Rigid(pg.checkboxes[index].Layout),
+		layout.Rigid(item.checkbox.Layout),
 		layout.Rigid(func(gtx C) D {
 			txt := pg.Theme.Body2(data.Amount)
 			txt.MaxLines = 1
@@ -292,25 +487,42 @@ func (pg *UTXOPage) utxoRow(gtx C, data *wallet.UnspentOutput, index int) D {
 			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding200)
 			return txt.Layout(gtx)
 		}),
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Max.X = gtx.Dp(values.MarginPadding150)
+			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
+			return item.labelButton.Layout(gtx, func(gtx C) D {
+				label := pg.coins.Label(data.UTXO.OutputKey)
+				txt := pg.Theme.Body2(label)
+				if label == "" {
+					txt = pg.Theme.Body2("+ " + values.String(values.StrLabel))
+					txt.Color = pg.Theme.Color.GrayText3
+				}
+				txt.MaxLines = 1
+				return txt.Layout(gtx)
+			})
+		}),
 		layout.Rigid(func(gtx C) D {
 			txt := pg.Theme.Body2(data.DateTime)
 			txt.MaxLines = 1
 			txt.Alignment = text.End
-			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding100)
+			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
 			return txt.Layout(gtx)
 		}),
 		layout.Rigid(func(gtx C) D {
 			txt := pg.Theme.Body2(fmt.Sprintf("%d", data.UTXO.Confirmations))
 			txt.MaxLines = 1
 			txt.Alignment = text.End
-			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding100)
+			gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding120)
 			return txt.Layout(gtx)
 		}),
 		layout.Rigid(func(gtx C) D {
-			if pg.copyButtons[index].Button.Clicked() {
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, item.frozen.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if item.copyButton.Button.Clicked() {
 				clipboard.WriteOp{Text: data.UTXO.Addresses}.Add(gtx.Ops)
 			}
-			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, pg.copyButtons[index].Layout)
+			return layout.Inset{Left: values.MarginPadding10}.Layout(gtx, item.copyButton.Layout)
 		}),
 	)
 }
@@ -323,3 +535,19 @@ func (pg *UTXOPage) utxoRow(gtx C, data *wallet.UnspentOutput, index int) D {
 // components unless they'll be recreated in the OnNavigatedTo() method.
 // Part of the load.Page interface.
 func (pg *UTXOPage) OnNavigatedFrom() {}
+
+// coinSelectionName returns the translated name of selection.
+func coinSelectionName(selection wallet.CoinSelection) string {
+	switch selection {
+	case wallet.CoinSelectionLargestFirst:
+		return values.String(values.StrLargestFirst)
+	case wallet.CoinSelectionSmallestFirst:
+		return values.String(values.StrSmallestFirst)
+	case wallet.CoinSelectionPrivacy:
+		return values.String(values.StrCoinSelectionPrivacy)
+	case wallet.CoinSelectionMinimizeChange:
+		return values.String(values.StrMinimizeChange)
+	default:
+		return values.String(values.StrCoinSelectionRandom)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/page/send"
 	"github.com/planetdecred/godcr/ui/preference"
 	"github.com/planetdecred/godcr/ui/values"
 	"github.com/planetdecred/godcr/wallet"
@@ -40,6 +41,9 @@ type SettingsPage struct {
 	changeStartupPass   *decredmaterial.Clickable
 	language            *decredmaterial.Clickable
 	currency            *decredmaterial.Clickable
+	importPriceHistory  *decredmaterial.Clickable
+	blockExplorer       *decredmaterial.Clickable
+	addressBook         *decredmaterial.Clickable
 
 	chevronRightIcon *decredmaterial.Icon
 	backButton       decredmaterial.IconButton
@@ -93,6 +97,9 @@ func NewSettingsPage(l *load.Load) *SettingsPage {
 		changeStartupPass:   l.Theme.NewClickable(false),
 		language:            l.Theme.NewClickable(false),
 		currency:            l.Theme.NewClickable(false),
+		importPriceHistory:  l.Theme.NewClickable(false),
+		blockExplorer:       l.Theme.NewClickable(false),
+		addressBook:         l.Theme.NewClickable(false),
 	}
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(l)
@@ -194,11 +201,41 @@ func (pg *SettingsPage) general() layout.Widget {
 						title:     values.String(values.StrCurrencyConversion),
 						clickable: pg.currency,
 						icon:      pg.chevronRightIcon,
-						label:     pg.Theme.Body2(pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)),
+						label:     pg.Theme.Body2(pg.currencyConversionLabel()),
 					}
 					return pg.clickableRow(gtx, currencyConversionRow)
 				}),
 				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					importPriceHistoryRow := row{
+						title:     values.String(values.StrImportPriceHistory),
+						clickable: pg.importPriceHistory,
+						icon:      pg.chevronRightIcon,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, importPriceHistoryRow)
+				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					blockExplorerRow := row{
+						title:     values.String(values.StrBlockExplorer),
+						clickable: pg.blockExplorer,
+						icon:      pg.chevronRightIcon,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, blockExplorerRow)
+				}),
+				layout.Rigid(pg.lineSeparator()),
+				layout.Rigid(func(gtx C) D {
+					addressBookRow := row{
+						title:     values.String(values.StrAddressBook),
+						clickable: pg.addressBook,
+						icon:      pg.chevronRightIcon,
+						label:     pg.Theme.Body2(""),
+					}
+					return pg.clickableRow(gtx, addressBookRow)
+				}),
+				layout.Rigid(pg.lineSeparator()),
 				layout.Rigid(func(gtx C) D {
 					languageRow := row{
 						title:     values.String(values.StrLanguage),
@@ -213,6 +250,16 @@ func (pg *SettingsPage) general() layout.Widget {
 	}
 }
 
+// currencyConversionLabel returns the display text of the selected currency
+// conversion option.
+func (pg *SettingsPage) currencyConversionLabel() string {
+	option := pg.WL.MultiWallet.ReadStringConfigValueForKey(dcrlibwallet.CurrencyConversionConfigKey)
+	if strKey, ok := values.ArrExchangeCurrencies[option]; ok {
+		return values.String(strKey)
+	}
+	return option
+}
+
 func (pg *SettingsPage) notification() layout.Widget {
 	return func(gtx C) D {
 		return pg.mainSection(gtx, values.String(values.StrNotifications), func(gtx C) D {
@@ -450,6 +497,21 @@ func (pg *SettingsPage) HandleUserInteractions() {
 		break
 	}
 
+	for pg.importPriceHistory.Clicked() {
+		pg.showImportPriceHistoryDialog()
+		break
+	}
+
+	for pg.blockExplorer.Clicked() {
+		pg.ParentWindow().ShowModal(modal.NewExplorerTemplatesModal(pg.Load))
+		break
+	}
+
+	for pg.addressBook.Clicked() {
+		pg.ParentNavigator().Display(send.NewAddressBookPage(pg.Load))
+		break
+	}
+
 	if pg.isDarkModeOn.Changed() {
 		pg.WL.MultiWallet.SaveUserConfigValue(load.DarkModeConfigKey, pg.isDarkModeOn.IsChecked())
 		pg.RefreshTheme(pg.ParentWindow())
@@ -546,7 +608,7 @@ func (pg *SettingsPage) HandleUserInteractions() {
 							go func() {
 								err := pg.wal.GetMultiWallet().ChangeStartupPassphrase([]byte(password), []byte(newPassword), dcrlibwallet.PassphraseTypePass)
 								if err != nil {
-									m.SetError(err.Error())
+									m.SetError(components.TranslateErr(err))
 									m.SetLoading(false)
 									return
 								}
@@ -576,7 +638,7 @@ func (pg *SettingsPage) HandleUserInteractions() {
 					go func() {
 						err := pg.wal.GetMultiWallet().SetStartupPassphrase([]byte(password), dcrlibwallet.PassphraseTypePass)
 						if err != nil {
-							m.SetError(err.Error())
+							m.SetError(components.TranslateErr(err))
 							m.SetLoading(false)
 							return
 						}
@@ -655,7 +717,7 @@ func (pg *SettingsPage) HandleUserInteractions() {
 			pg.Toast.NotifyError(values.String(values.StrInvalidPassphrase))
 			return
 		}
-		pg.Toast.NotifyError(err.Error())
+		pg.Toast.NotifyError(components.TranslateErr(err))
 	default:
 	}
 }
@@ -692,6 +754,26 @@ func (pg *SettingsPage) showUserAgentDialog() {
 	pg.ParentWindow().ShowModal(textModal)
 }
 
+func (pg *SettingsPage) showImportPriceHistoryDialog() {
+	textModal := modal.NewTextInputModal(pg.Load).
+		Hint(values.String(values.StrPriceHistoryCSVPath)).
+		PositiveButtonStyle(pg.Load.Theme.Color.Primary, pg.Load.Theme.Color.InvText).
+		PositiveButton(values.String(values.StrImport), func(path string, tim *modal.TextInputModal) bool {
+			count, err := pg.WL.ImportPriceHistory(path)
+			if err != nil {
+				tim.SetError(components.TranslateErr(err))
+				tim.SetLoading(false)
+				return false
+			}
+			pg.Toast.Notify(values.StringF(values.StrPriceHistoryImported, count))
+			return true
+		})
+
+	textModal.Title(values.String(values.StrImportPriceHistory)).
+		NegativeButton(values.String(values.StrCancel), func() {})
+	pg.ParentWindow().ShowModal(textModal)
+}
+
 func (pg *SettingsPage) updateSettingOptions() {
 	isPassword := pg.WL.MultiWallet.IsStartupSecuritySet()
 	pg.startupPassword.SetChecked(false)
@@ -81,7 +81,7 @@ func (tb *ticketBuyerModal) OnResume() {
 			tbConfig := wal.AutoTicketsBuyerConfig()
 			acct, err := wal.GetAccount(tbConfig.PurchaseAccount)
 			if err != nil {
-				tb.Toast.NotifyError(err.Error())
+				tb.Toast.NotifyError(components.TranslateErr(err))
 			}
 
 			if wal.ReadBoolConfigValueForKey(dcrlibwallet.AccountMixerConfigSet, false) &&
@@ -91,7 +91,7 @@ func (tb *ticketBuyerModal) OnResume() {
 			} else {
 				err := tb.accountSelector.SelectFirstWalletValidAccount(nil)
 				if err != nil {
-					tb.Toast.NotifyError(err.Error())
+					tb.Toast.NotifyError(components.TranslateErr(err))
 				}
 			}
 
@@ -104,7 +104,7 @@ func (tb *ticketBuyerModal) OnResume() {
 	if tb.accountSelector.SelectedAccount() == nil {
 		err := tb.accountSelector.SelectFirstWalletValidAccount(nil)
 		if err != nil {
-			tb.Toast.NotifyError(err.Error())
+			tb.Toast.NotifyError(components.TranslateErr(err))
 		}
 	}
 }
@@ -205,7 +205,7 @@ func (tb *ticketBuyerModal) Handle() {
 		vspHost := tb.vspSelector.SelectedVSP().Host
 		amount, err := strconv.ParseFloat(tb.balToMaintainEditor.Editor.Text(), 64)
 		if err != nil {
-			tb.Toast.NotifyError(err.Error())
+			tb.Toast.NotifyError(components.TranslateErr(err))
 			return
 		}
 
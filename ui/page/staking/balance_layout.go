@@ -1,6 +1,7 @@
 package staking
 
 import (
+	"fmt"
 	"image/color"
 
 	"gioui.org/layout"
@@ -32,6 +33,9 @@ func (pg *Page) walletBalanceLayout(gtx C) D {
 							totalBalance, err := components.CalculateTotalWalletsBalance(pg.Load)
 							if err == nil {
 								txt.Text = totalBalance.Total.String()
+								if pg.fiat != nil {
+									txt.Text = fmt.Sprintf("%s (%s)", txt.Text, pg.fiat.FormatAmount(totalBalance.Total))
+								}
 							} else {
 								txt.Text = err.Error()
 							}
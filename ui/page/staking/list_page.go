@@ -2,10 +2,10 @@ package staking
 
 import (
 	"context"
+	"sync"
 
 	"gioui.org/layout"
 	"gioui.org/text"
-	"gioui.org/widget"
 
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/app"
@@ -15,6 +15,7 @@ import (
 	"github.com/planetdecred/godcr/ui/page/components"
 	tpage "github.com/planetdecred/godcr/ui/page/transaction"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const listPageID = "StakingList"
@@ -39,13 +40,25 @@ type ListPage struct {
 	// and the root WindowNavigator.
 	*app.GenericPageModal
 	*listeners.TxAndBlockNotificationListener
+	// notifications are handled by HandleUserInteractions, the pager is only
+	// replaced on the UI goroutine.
+	notifications listeners.TxNotificationQueue
 
 	ctx       context.Context // page context
 	ctxCancel context.CancelFunc
 
+	// pager loads the tickets as the list is scrolled, tickets are the items
+	// of the tickets loaded that match the ticket type. The items are created
+	// in the background, updateMu serializes their updates so they are
+	// applied in the order the pager was read, and mu guards tickets.
+	pager       *wallet.TxPager
+	updateMu    sync.Mutex
+	mu          sync.Mutex
 	tickets     []*transactionItem
 	ticketsList *decredmaterial.ClickableList
-	scrollBar   *widget.List
+	txFilter    int32
+	hasFilter   func(int32) bool
+	newestFirst bool
 
 	orderDropDown      *decredmaterial.DropDown
 	ticketTypeDropDown *decredmaterial.DropDown
@@ -60,9 +73,6 @@ func newListPage(l *load.Load) *ListPage {
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(listPageID),
 		ticketsList:      l.Theme.NewClickableList(layout.Vertical),
-		scrollBar: &widget.List{
-			List: layout.List{Axis: layout.Vertical},
-		},
 	}
 	pg.backButton, _ = components.SubpageHeaderButtons(pg.Load)
 
@@ -108,12 +118,9 @@ func (pg *ListPage) listenForTxNotifications() {
 
 			select {
 			case n := <-pg.TxAndBlockNotifChan:
-				if n.Type == listeners.BlockAttached || n.Type == listeners.NewTransaction {
-					selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
-					if selectedWallet.ID == n.WalletID {
-						pg.fetchTickets()
-						pg.ParentWindow().Reload()
-					}
+				if n.Type == listeners.BlockAttached || n.Type == listeners.NewTransaction || n.Type == listeners.TxConfirmed {
+					pg.notifications.Push(n)
+					pg.ParentWindow().Reload()
 				}
 			case <-pg.ctx.Done():
 				pg.WL.MultiWallet.RemoveTxAndBlockNotificationListener(listPageID)
@@ -126,6 +133,47 @@ func (pg *ListPage) listenForTxNotifications() {
 	}()
 }
 
+// handleTxNotifications updates the tickets of the selected wallet with the
+// notified transactions and blocks.
+func (pg *ListPage) handleTxNotifications() {
+	refresh := false
+	for _, n := range pg.notifications.Take() {
+		// New transaction notifications only set the wallet of the
+		// transaction.
+		walletID := n.WalletID
+		if n.Transaction != nil {
+			walletID = n.Transaction.WalletID
+		}
+		selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
+		if selectedWallet.ID != walletID || pg.pager == nil {
+			continue
+		}
+
+		switch n.Type {
+		case listeners.NewTransaction:
+			pg.pager.Merge(n.Transaction)
+		case listeners.TxConfirmed:
+			pg.pager.Confirm(n.Hash, n.BlockHeight)
+		}
+
+		// The tickets matching these filters change with the blocks, they are
+		// loaded again from the first page. The items of the loaded tickets
+		// are refreshed otherwise as their status changes with the blocks.
+		switch pg.txFilter {
+		case dcrlibwallet.TxFilterImmature, dcrlibwallet.TxFilterLive, dcrlibwallet.TxFilterExpired:
+			if n.Type == listeners.BlockAttached {
+				pg.fetchTickets()
+				refresh = false
+				continue
+			}
+		}
+		refresh = true
+	}
+	if refresh {
+		go pg.refreshTickets(pg.pager, pg.newestFirst, pg.hasFilter)
+	}
+}
+
 func (pg *ListPage) fetchTickets() {
 	var txFilter int32
 	var ticketTypeDropdown = txType(pg.ticketTypeDropDown.SelectedIndex())
@@ -146,17 +194,9 @@ func (pg *ListPage) fetchTickets() {
 		txFilter = dcrlibwallet.TxFilterTickets
 	}
 
-	newestFirst := pg.orderDropDown.SelectedIndex() == 0
-	selectedWalletID := pg.wallets[pg.walletDropDown.SelectedIndex()].ID
-	multiWallet := pg.WL.MultiWallet
-	w := multiWallet.WalletWithID(selectedWalletID)
-	txs, err := w.GetTransactionsRaw(0, 0, txFilter, newestFirst)
-	if err != nil {
-		pg.Toast.NotifyError(err.Error())
-		return
-	}
-
-	tickets, err := stakeToTransactionItems(pg.Load, txs, newestFirst, func(filter int32) bool {
+	pg.txFilter = txFilter
+	pg.newestFirst = pg.orderDropDown.SelectedIndex() == 0
+	pg.hasFilter = func(filter int32) bool {
 		switch filter {
 		case dcrlibwallet.TxFilterVoted:
 			return ticketTypeDropdown == Voted
@@ -165,13 +205,70 @@ func (pg *ListPage) fetchTickets() {
 		}
 
 		return filter == txFilter
-	})
+	}
+
+	selectedWalletID := pg.wallets[pg.walletDropDown.SelectedIndex()].ID
+	w := pg.WL.MultiWallet.WalletWithID(selectedWalletID)
+	pager := wallet.NewTxPager(w, txFilter, pg.newestFirst)
+	pg.mu.Lock()
+	pg.pager = pager
+	pg.tickets = nil
+	pg.mu.Unlock()
+	go pg.loadMoreTickets(pager, pg.newestFirst, pg.hasFilter)
+}
+
+// ticketItems returns the items of the tickets listed.
+func (pg *ListPage) ticketItems() []*transactionItem {
+	pg.mu.Lock()
+	defer pg.mu.Unlock()
+	return pg.tickets
+}
+
+// loadMoreTickets reads the next page of tickets of pager and adds their
+// items to the list.
+func (pg *ListPage) loadMoreTickets(pager *wallet.TxPager, newestFirst bool, hasFilter func(int32) bool) {
+	pg.updateMu.Lock()
+	defer pg.updateMu.Unlock()
+
+	txs, err := pager.LoadMore()
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+
+	tickets, err := stakeToTransactionItems(pg.Load, txs, newestFirst, hasFilter)
+	if err != nil {
+		pg.Toast.NotifyError(components.TranslateErr(err))
+		return
+	}
+
+	pg.mu.Lock()
+	// The tickets were loaded for a previous wallet or filter.
+	if pager == pg.pager {
+		pg.tickets = append(pg.tickets, tickets...)
+	}
+	pg.mu.Unlock()
+	pg.ParentWindow().Reload()
+}
+
+// refreshTickets recreates the items of the tickets loaded by pager without
+// reading them again.
+func (pg *ListPage) refreshTickets(pager *wallet.TxPager, newestFirst bool, hasFilter func(int32) bool) {
+	pg.updateMu.Lock()
+	defer pg.updateMu.Unlock()
+
+	tickets, err := stakeToTransactionItems(pg.Load, pager.Transactions(), newestFirst, hasFilter)
 	if err != nil {
-		pg.Toast.NotifyError(err.Error())
+		pg.Toast.NotifyError(components.TranslateErr(err))
 		return
 	}
 
-	pg.tickets = tickets
+	pg.mu.Lock()
+	if pager == pg.pager {
+		pg.tickets = tickets
+	}
+	pg.mu.Unlock()
+	pg.ParentWindow().Reload()
 }
 
 // Layout draws the page UI components into the provided layout context
@@ -190,22 +287,23 @@ func (pg *ListPage) Layout(gtx C) D {
 				return layout.Stack{Alignment: layout.N}.Layout(gtx,
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{Top: values.MarginPadding60}.Layout(gtx, func(gtx C) D {
-							return pg.Theme.List(pg.scrollBar).Layout(gtx, 1, func(gtx C, index int) D {
-								return pg.Theme.Card().Layout(gtx, func(gtx C) D {
-									tickets := pg.tickets
+							return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+								tickets := pg.ticketItems()
 
-									if len(tickets) == 0 {
-										gtx.Constraints.Min.X = gtx.Constraints.Max.X
+								if len(tickets) == 0 {
+									gtx.Constraints.Min.X = gtx.Constraints.Max.X
 
-										txt := pg.Theme.Body1(values.String(values.StrNoTickets))
-										txt.Color = pg.Theme.Color.GrayText3
-										txt.Alignment = text.Middle
-										return layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+									txt := pg.Theme.Body1(values.String(values.StrNoTickets))
+									if pg.pager == nil || !pg.pager.Done() {
+										txt.Text = values.String(values.StrLoading)
 									}
+									txt.Color = pg.Theme.Color.GrayText3
+									txt.Alignment = text.Middle
+									return layout.Inset{Top: values.MarginPadding15, Bottom: values.MarginPadding16}.Layout(gtx, txt.Layout)
+								}
 
-									return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
-										return pg.ticketListLayout(gtx, tickets)
-									})
+								return layout.UniformInset(values.MarginPadding16).Layout(gtx, func(gtx C) D {
+									return pg.ticketListLayout(gtx, tickets)
 								})
 							})
 						})
@@ -253,6 +351,17 @@ func (pg *ListPage) ticketListLayout(gtx layout.Context, tickets []*transactionI
 // displayed.
 // Part of the load.Page interface.
 func (pg *ListPage) HandleUserInteractions() {
+	pg.handleTxNotifications()
+
+	// Load the next page once the last tickets of the list are visible.
+	tickets := pg.ticketItems()
+	if pager := pg.pager; pager != nil && !pager.Done() && !pager.Loading() {
+		position := pg.ticketsList.Position
+		if position.First+position.Count >= len(tickets)-wallet.TxPageSize/4 {
+			go pg.loadMoreTickets(pager, pg.newestFirst, pg.hasFilter)
+		}
+	}
+
 	for pg.orderDropDown.Changed() {
 		pg.fetchTickets()
 	}
@@ -265,8 +374,8 @@ func (pg *ListPage) HandleUserInteractions() {
 		pg.fetchTickets()
 	}
 
-	if clicked, selectedItem := pg.ticketsList.ItemClicked(); clicked {
-		ticketTx := pg.tickets[selectedItem].transaction
+	if clicked, selectedItem := pg.ticketsList.ItemClicked(); clicked && selectedItem < len(tickets) {
+		ticketTx := tickets[selectedItem].transaction
 		pg.ParentNavigator().Display(tpage.NewTransactionDetailsPage(pg.Load, ticketTx))
 
 		// Check if this ticket is fully registered with a VSP
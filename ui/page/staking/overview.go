@@ -17,6 +17,7 @@ import (
 	"github.com/planetdecred/godcr/ui/page/components"
 	tpage "github.com/planetdecred/godcr/ui/page/transaction"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type (
@@ -51,8 +52,11 @@ type Page struct {
 	ticketOverview *dcrlibwallet.StakingOverview
 	liveTickets    []*transactionItem
 
-	ticketPrice  string
-	totalRewards string
+	ticketPrice     string
+	ticketPriceFiat string
+	totalRewards    string
+
+	fiat *wallet.FiatConverter
 }
 
 func NewStakingPage(l *load.Load) *Page {
@@ -88,6 +92,7 @@ func (pg *Page) OnNavigatedTo() {
 	// set up auto ticekt buyer wallets
 	pg.setTBWallet()
 
+	pg.fiat = pg.WL.FiatConverter()
 	pg.fetchTicketPrice()
 
 	pg.loadPageData() // starts go routines to refresh the display which is just about to be displayed, ok?
@@ -99,6 +104,7 @@ func (pg *Page) OnNavigatedTo() {
 
 // fetch ticket price only when the wallet is synced
 func (pg *Page) fetchTicketPrice() {
+	pg.ticketPriceFiat = ""
 	if pg.WL.MultiWallet.IsSyncing() {
 		pg.ticketPrice = values.String(values.StrLoadingPrice)
 	} else {
@@ -109,6 +115,9 @@ func (pg *Page) fetchTicketPrice() {
 			pg.Toast.NotifyError(values.String(values.StrWalletNotSynced))
 		} else {
 			pg.ticketPrice = dcrutil.Amount(ticketPrice.TicketPrice).String()
+			if pg.fiat != nil {
+				pg.ticketPriceFiat = pg.fiat.FormatAmount(dcrutil.Amount(ticketPrice.TicketPrice))
+			}
 		}
 	}
 }
@@ -145,14 +154,14 @@ func (pg *Page) loadPageData() {
 
 		totalRewards, err := pg.WL.MultiWallet.TotalStakingRewards()
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(err))
 		} else {
 			pg.totalRewards = dcrutil.Amount(totalRewards).String()
 		}
 
 		overview, err := pg.WL.MultiWallet.StakingOverview()
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(err))
 		} else {
 			pg.ticketOverview = overview
 		}
@@ -164,7 +173,7 @@ func (pg *Page) loadPageData() {
 		mw := pg.WL.MultiWallet
 		tickets, err := allLiveTickets(mw)
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(err))
 			return
 		}
 
@@ -181,7 +190,7 @@ func (pg *Page) loadPageData() {
 			return false
 		})
 		if err != nil {
-			pg.Toast.NotifyError(err.Error())
+			pg.Toast.NotifyError(components.TranslateErr(err))
 			return
 		}
 
@@ -422,7 +431,7 @@ func (pg *Page) startTicketBuyerPasswordModal() {
 			go func() {
 				err := pg.ticketBuyerWallet.StartTicketBuyer([]byte(password))
 				if err != nil {
-					pg.Toast.NotifyError(err.Error())
+					pg.Toast.NotifyError(components.TranslateErr(err))
 					pm.SetLoading(false)
 					return
 				}
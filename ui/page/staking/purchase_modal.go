@@ -16,6 +16,7 @@ import (
 	"github.com/planetdecred/godcr/ui/load"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 type stakingModal struct {
@@ -26,6 +27,7 @@ type stakingModal struct {
 	ctxCancel context.CancelFunc
 
 	ticketPrice dcrutil.Amount
+	fiat        *wallet.FiatConverter
 
 	cancelPurchase decredmaterial.Button
 	stakeBtn       decredmaterial.Button
@@ -90,7 +92,7 @@ func (tp *stakingModal) OnResume() {
 
 	err := tp.accountSelector.SelectFirstWalletValidAccount(nil)
 	if err != nil {
-		tp.Toast.NotifyError(err.Error())
+		tp.Toast.NotifyError(components.TranslateErr(err))
 	}
 
 	tp.vspSelector = components.NewVSPSelector(tp.Load).Title(values.String(values.StrSelectVSP))
@@ -103,10 +105,12 @@ func (tp *stakingModal) OnResume() {
 		tp.vspSelector.SelectVSP(lastUsedVSP)
 	}
 
+	tp.fiat = tp.WL.FiatConverter()
+
 	go func() {
 		ticketPrice, err := tp.WL.MultiWallet.TicketPrice()
 		if err != nil {
-			tp.Toast.NotifyError(err.Error())
+			tp.Toast.NotifyError(components.TranslateErr(err))
 		} else {
 			tp.ticketPrice = dcrutil.Amount(ticketPrice.TicketPrice)
 			tp.ParentWindow().Reload()
@@ -160,7 +164,12 @@ func (tp *stakingModal) Layout(gtx layout.Context) layout.Dimensions {
 										return totalLabel.Layout(gtx)
 									}),
 									layout.Rigid(func(gtx C) D {
-										costLabel := tp.Theme.Label(values.TextSize16, dcrutil.Amount(int64(tp.ticketPrice)*tp.ticketCount()).String())
+										totalCost := dcrutil.Amount(int64(tp.ticketPrice) * tp.ticketCount())
+										costText := totalCost.String()
+										if tp.fiat != nil {
+											costText = fmt.Sprintf("%s (%s)", costText, tp.fiat.FormatAmount(totalCost))
+										}
+										costLabel := tp.Theme.Label(values.TextSize16, costText)
 										return costLabel.Layout(gtx)
 									}),
 								)
@@ -325,7 +334,7 @@ func (tp *stakingModal) calculateTotals() {
 
 	ticketPrice, err := wal.TicketPrice()
 	if err != nil {
-		tp.Toast.NotifyError(err.Error())
+		tp.Toast.NotifyError(components.TranslateErr(err))
 		return
 	}
 
@@ -406,6 +415,11 @@ func (tp *stakingModal) purchaseTickets() {
 		return
 	}
 
+	if !tp.WL.MultiWallet.IsSynced() {
+		tp.Toast.NotifyError(components.TranslateErr(wallet.ErrNotSynced))
+		return
+	}
+
 	tp.isLoading = true
 	tp.Modal.SetDisabled(true)
 	go func() {
@@ -424,10 +438,10 @@ func (tp *stakingModal) purchaseTickets() {
 		vspHost, vspPubKey := selectedVSP.Host, selectedVSP.PubKey
 		_, err := wal.PurchaseTickets(account.Number, int32(tp.ticketCount()), vspHost, vspPubKey, password)
 		if err != nil {
-			if err.Error() == dcrlibwallet.ErrInvalidPassphrase {
+			if wallet.ErrorCodeOf(err) == wallet.ErrCodeBadPassphrase {
 				tp.spendingPassword.SetError(values.String(values.StrInvalidPassphrase))
 			} else {
-				tp.Toast.NotifyError(err.Error())
+				tp.Toast.NotifyError(components.TranslateErr(wallet.NewError(wallet.ErrCodeVSP, err)))
 			}
 			return
 		}
@@ -87,6 +87,19 @@ func (pg *Page) stakePriceSection(gtx C) D {
 					})
 				})
 			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.ticketPriceFiat == "" {
+					return D{}
+				}
+
+				return layout.Inset{
+					Bottom: values.MarginPadding16,
+				}.Layout(gtx, func(gtx C) D {
+					txt := pg.Theme.Label(values.TextSize14, pg.ticketPriceFiat)
+					txt.Color = pg.Theme.Color.GrayText2
+					return layout.Center.Layout(gtx, txt.Layout)
+				})
+			}),
 			layout.Rigid(func(gtx C) D {
 				return layout.Center.Layout(gtx, func(gtx C) D {
 					gtx.Constraints.Min.X = gtx.Dp(values.MarginPadding150)
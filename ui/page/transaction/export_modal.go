@@ -0,0 +1,246 @@
+package transaction
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/page/components"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+const exportDateLayout = "2006-01-02"
+
+// exportModal exports the transaction history of the wallet selected on the
+// transactions page, or of all wallets, with the page's transaction filter.
+type exportModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	selectedWallet *dcrlibwallet.Wallet
+	txFilter       int32
+
+	allWallets  *widget.Bool
+	includeFiat *widget.Bool
+	format      *widget.Enum
+
+	fromEditor decredmaterial.Editor
+	toEditor   decredmaterial.Editor
+	pathEditor decredmaterial.Editor
+
+	cancelBtn decredmaterial.Button
+	exportBtn decredmaterial.Button
+
+	fiatCurrency    string
+	lastDefaultPath string
+	isExporting     bool
+}
+
+func newExportModal(l *load.Load, selectedWallet *dcrlibwallet.Wallet, txFilter int32) *exportModal {
+	em := &exportModal{
+		Load:           l,
+		Modal:          l.Theme.ModalFloatTitle("export_tx_modal"),
+		selectedWallet: selectedWallet,
+		txFilter:       txFilter,
+
+		allWallets:  new(widget.Bool),
+		includeFiat: new(widget.Bool),
+		format:      &widget.Enum{Value: wallet.TxExportCSV},
+
+		fromEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrFromDate)),
+		toEditor:   l.Theme.Editor(new(widget.Editor), values.String(values.StrToDate)),
+		pathEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrFilePath)),
+
+		cancelBtn: l.Theme.OutlineButton(values.String(values.StrCancel)),
+		exportBtn: l.Theme.Button(values.String(values.StrExport)),
+	}
+
+	em.fromEditor.Editor.SingleLine = true
+	em.toEditor.Editor.SingleLine = true
+	em.pathEditor.Editor.SingleLine = true
+
+	if _, currency, ok := l.WL.ExchangeRateSetting(); ok {
+		em.fiatCurrency = currency
+	}
+
+	return em
+}
+
+func (em *exportModal) OnResume() {
+	em.lastDefaultPath = em.defaultPath()
+	em.pathEditor.Editor.SetText(em.lastDefaultPath)
+}
+
+func (em *exportModal) OnDismiss() {}
+
+// defaultPath returns the path the history is exported to if the user does
+// not change it: a file named after the wallet in the home directory.
+func (em *exportModal) defaultPath() string {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		dir = "."
+	}
+
+	name := "transactions"
+	if !em.allWallets.Value {
+		name = em.selectedWallet.Name + "-" + name
+	}
+	return filepath.Join(dir, name+"."+em.format.Value)
+}
+
+func parseExportDate(editor *decredmaterial.Editor) (time.Time, bool) {
+	date := strings.TrimSpace(editor.Editor.Text())
+	if date == "" {
+		return time.Time{}, true
+	}
+
+	t, err := time.Parse(exportDateLayout, date)
+	if err != nil {
+		editor.SetError(values.String(values.StrInvalidDate))
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func (em *exportModal) export() {
+	from, fromOk := parseExportDate(&em.fromEditor)
+	to, toOk := parseExportDate(&em.toEditor)
+	if !fromOk || !toOk {
+		return
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1) // include transactions of the end date
+	}
+
+	opts := wallet.TxExportOptions{
+		Format: em.format.Value,
+		Filter: em.txFilter,
+		From:   from,
+		To:     to,
+	}
+	if em.includeFiat.Value {
+		opts.FiatCurrency = em.fiatCurrency
+	}
+
+	wallets := []*dcrlibwallet.Wallet{em.selectedWallet}
+	if em.allWallets.Value {
+		wallets = em.WL.SortedWalletList()
+	}
+
+	path := em.pathEditor.Editor.Text()
+	em.isExporting = true
+	em.Modal.SetDisabled(true)
+	go func() {
+		defer func() {
+			em.isExporting = false
+			em.Modal.SetDisabled(false)
+		}()
+
+		count, err := em.WL.ExportTransactions(context.TODO(), path, wallets, opts)
+		if err != nil {
+			em.Toast.NotifyError(components.TranslateErr(err))
+			return
+		}
+
+		em.Toast.Notify(values.StringF(values.StrTxExported, count, path))
+		em.Dismiss()
+	}()
+}
+
+func (em *exportModal) Handle() {
+	formatChanged, walletsChanged := em.format.Changed(), em.allWallets.Changed()
+	if formatChanged || walletsChanged {
+		// Only replace the path if the user has not edited it.
+		if em.pathEditor.Editor.Text() == em.lastDefaultPath {
+			em.pathEditor.Editor.SetText(em.defaultPath())
+		}
+		em.lastDefaultPath = em.defaultPath()
+	}
+
+	for _, editor := range []*decredmaterial.Editor{&em.fromEditor, &em.toEditor} {
+		if _, changed := decredmaterial.HandleEditorEvents(editor.Editor); changed {
+			editor.SetError("")
+		}
+	}
+
+	em.exportBtn.SetEnabled(!em.isExporting && strings.TrimSpace(em.pathEditor.Editor.Text()) != "")
+
+	if em.exportBtn.Clicked() {
+		em.export()
+	}
+
+	if (em.cancelBtn.Clicked() || em.Modal.BackdropClicked(true)) && !em.isExporting {
+		em.Dismiss()
+	}
+}
+
+func (em *exportModal) Layout(gtx layout.Context) layout.Dimensions {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := em.Theme.H6(values.String(values.StrExportTransactions))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			return em.Theme.CheckBox(em.allWallets, values.String(values.StrAllWallets)).Layout(gtx)
+		},
+		func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := em.Theme.Label(values.TextSize14, values.String(values.StrExportFormat))
+					lbl.Color = em.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					formats := wallet.TxExportFormats()
+					items := make([]layout.FlexChild, len(formats))
+					for i, format := range formats {
+						radioBtn := em.Theme.RadioButton(em.format, format, strings.ToUpper(format), em.Theme.Color.DeepBlue, em.Theme.Color.Primary)
+						items[i] = layout.Rigid(radioBtn.Layout)
+					}
+					return layout.Flex{}.Layout(gtx, items...)
+				}),
+			)
+		},
+		func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(.5, func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, em.fromEditor.Layout)
+				}),
+				layout.Flexed(.5, func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, em.toEditor.Layout)
+				}),
+			)
+		},
+		func(gtx C) D {
+			if em.fiatCurrency == "" {
+				return D{}
+			}
+			label := values.String(values.StrIncludeFiatValue) + " (" + em.fiatCurrency + ")"
+			return em.Theme.CheckBox(em.includeFiat, label).Layout(gtx)
+		},
+		em.pathEditor.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, em.cancelBtn.Layout)
+					}),
+					layout.Rigid(em.exportBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return em.Modal.Layout(gtx, w)
+}
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -38,6 +39,9 @@ type TxDetailsPage struct {
 	// and the root WindowNavigator.
 	*app.GenericPageModal
 
+	ctx       context.Context // page context
+	ctxCancel context.CancelFunc
+
 	list *widget.List
 
 	transactionDetailsPageContainer layout.List
@@ -48,6 +52,7 @@ type TxDetailsPage struct {
 	destAddressClickable            *widget.Clickable
 	dot                             *decredmaterial.Icon
 	toDcrdata                       *decredmaterial.Clickable
+	toBlock                         *decredmaterial.Clickable
 	outputsCollapsible              *decredmaterial.Collapsible
 	inputsCollapsible               *decredmaterial.Collapsible
 	backButton                      decredmaterial.IconButton
@@ -55,7 +60,7 @@ type TxDetailsPage struct {
 	rebroadcast                     decredmaterial.Label
 	rebroadcastClickable            *decredmaterial.Clickable
 	rebroadcastIcon                 *decredmaterial.Image
-	copyRedirectURL                 *decredmaterial.Clickable
+	editLabelButton                 decredmaterial.Button
 
 	txnWidgets    transactionWdg
 	transaction   *dcrlibwallet.Transaction
@@ -66,6 +71,15 @@ type TxDetailsPage struct {
 
 	txSourceAccount      string
 	txDestinationAddress string
+	fiatValue            string
+	// fiatValues receives the fiat values fetched in the background.
+	fiatValues chan txFiatValue
+}
+
+// txFiatValue is the fiat value of the transaction with hash.
+type txFiatValue struct {
+	hash  string
+	value string
 }
 
 func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transaction) *TxDetailsPage {
@@ -95,13 +109,15 @@ func NewTransactionDetailsPage(l *load.Load, transaction *dcrlibwallet.Transacti
 		hashClickable:             new(widget.Clickable),
 		destAddressClickable:      new(widget.Clickable),
 		toDcrdata:                 l.Theme.NewClickable(true),
-		copyRedirectURL:           l.Theme.NewClickable(false),
+		toBlock:                   l.Theme.NewClickable(true),
 
 		transaction:          transaction,
 		wallet:               l.WL.MultiWallet.WalletWithID(transaction.WalletID),
 		rebroadcast:          rebroadcast,
 		rebroadcastClickable: l.Theme.NewClickable(true),
 		rebroadcastIcon:      l.Theme.Icons.Rebroadcast,
+		editLabelButton:      l.Theme.OutlineButton(values.String(values.StrEdit)),
+		fiatValues:           make(chan txFiatValue, 1),
 	}
 
 	pg.backButton, pg.infoButton = components.SubpageHeaderButtons(pg.Load)
@@ -141,6 +157,8 @@ func (pg *TxDetailsPage) getTXSourceAccountAndDirection() {
 // the page is displayed.
 // Part of the load.Page interface.
 func (pg *TxDetailsPage) OnNavigatedTo() {
+	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+
 	if pg.transaction.TicketSpentHash != "" {
 		pg.ticketSpent, _ = pg.wallet.GetTransactionRaw(pg.transaction.TicketSpentHash)
 	}
@@ -151,6 +169,52 @@ func (pg *TxDetailsPage) OnNavigatedTo() {
 
 	pg.getTXSourceAccountAndDirection()
 	pg.txnWidgets = initTxnWidgets(pg.Load, pg.transaction)
+	pg.loadFiatValue()
+}
+
+// loadFiatValue sets the fiat value of the transaction at the rate of the day
+// it was mined, fetching the rate in the background if it is not in the price
+// history.
+func (pg *TxDetailsPage) loadFiatValue() {
+	fiat := pg.WL.HistoricalFiatConverter()
+	if fiat == nil || pg.transaction.BlockHeight == -1 {
+		pg.fiatValue = ""
+		return
+	}
+
+	pg.fiatValue = pg.WL.TxFiatValue(fiat, pg.transaction)
+	if pg.fiatValue != "" {
+		return
+	}
+
+	tx := *pg.transaction
+	go func() {
+		if _, err := pg.WL.FillPriceHistory(pg.ctx, []dcrlibwallet.Transaction{tx}); err != nil {
+			if pg.ctx.Err() == nil {
+				log.Errorf("error fetching price history: %v", err)
+			}
+			return
+		}
+		select {
+		case pg.fiatValues <- txFiatValue{hash: tx.Hash, value: pg.WL.TxFiatValue(fiat, &tx)}:
+			pg.ParentWindow().Reload()
+		case <-pg.ctx.Done():
+		}
+	}()
+}
+
+// receiveFiatValues sets the fiat value of the transaction once fetched.
+func (pg *TxDetailsPage) receiveFiatValues() {
+	for {
+		select {
+		case v := <-pg.fiatValues:
+			if v.hash == pg.transaction.Hash {
+				pg.fiatValue = v.value
+			}
+		default:
+			return
+		}
+	}
 }
 
 // Layout draws the page UI components into the provided layout context
@@ -173,6 +237,7 @@ func (pg *TxDetailsPage) Layout(gtx layout.Context) layout.Dimensions {
 				pg.transaction = pg.txBackStack
 				pg.getTXSourceAccountAndDirection()
 				pg.txnWidgets = initTxnWidgets(pg.Load, pg.transaction)
+				pg.loadFiatValue()
 				pg.txBackStack = nil
 				pg.ParentWindow().Reload()
 			},
@@ -196,6 +261,12 @@ func (pg *TxDetailsPage) Layout(gtx layout.Context) layout.Dimensions {
 					func(gtx C) D {
 						return pg.Theme.Separator().Layout(gtx)
 					},
+					func(gtx C) D {
+						return pg.txnLabel(gtx)
+					},
+					func(gtx C) D {
+						return pg.Theme.Separator().Layout(gtx)
+					},
 					func(gtx C) D {
 						return pg.txnInputs(gtx)
 					},
@@ -538,6 +609,14 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx layout.Context) layout.Dimensions {
 				return pg.txnInfoSection(gtx, values.String(values.StrFee), dcrutil.Amount(transaction.Fee).String(), false, nil)
 			})
 		}),
+		layout.Rigid(func(gtx C) D {
+			if pg.fiatValue == "" {
+				return D{}
+			}
+			return layout.Inset{Top: m}.Layout(gtx, func(gtx C) D {
+				return pg.txnInfoSection(gtx, values.String(values.StrValueWhenMined), pg.fiatValue, false, nil)
+			})
+		}),
 		layout.Rigid(func(gtx C) D {
 			if transaction.BlockHeight != -1 {
 				return layout.Inset{Top: m}.Layout(gtx, func(gtx C) D {
@@ -572,6 +651,51 @@ func (pg *TxDetailsPage) txnTypeAndID(gtx layout.Context) layout.Dimensions {
 	)
 }
 
+// txnLabel lays out the label and the note of the transaction with the button
+// editing them.
+func (pg *TxDetailsPage) txnLabel(gtx C) D {
+	label := pg.WL.Wallet.TxLabelOf(pg.wallet.ID, pg.transaction.Hash)
+	return decredmaterial.LinearLayout{
+		Width:       decredmaterial.MatchParent,
+		Height:      decredmaterial.WrapContent,
+		Orientation: layout.Vertical,
+		Padding:     layout.UniformInset(values.MarginPadding16),
+	}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return layout.Flex{Spacing: layout.SpaceBetween, Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					t := pg.Theme.Label(values.TextSize14, values.String(values.StrLabel))
+					t.Color = pg.Theme.Color.GrayText2
+					return t.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					title := label.Label
+					if title == "" {
+						title = values.String(values.StrNoLabel)
+					}
+					return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+						layout.Rigid(pg.Theme.Body1(title).Layout),
+						layout.Rigid(func(gtx C) D {
+							return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, pg.editLabelButton.Layout)
+						}),
+					)
+				}),
+			)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if label.Note == "" {
+				return D{}
+			}
+			return layout.Inset{Top: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+				t := pg.Theme.Body2(label.Note)
+				t.Color = pg.Theme.Color.GrayText1
+				return t.Layout(gtx)
+			})
+		}),
+	)
+}
+
 func (pg *TxDetailsPage) txnInfoSection(gtx layout.Context, label, value string, showWalletBadge bool, clickable *widget.Clickable) layout.Dimensions {
 	gtx.Constraints.Min.X = gtx.Constraints.Max.X
 	return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
@@ -716,13 +840,29 @@ func (pg *TxDetailsPage) txnIORow(gtx layout.Context, amount int64, acctNum int3
 
 func (pg *TxDetailsPage) viewTxn(gtx layout.Context) layout.Dimensions {
 	return pg.pageSections(gtx, func(gtx C) D {
-		return pg.toDcrdata.Layout(gtx, func(gtx C) D {
-			gtx.Constraints.Min.X = gtx.Constraints.Max.X
-			return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
-				layout.Rigid(pg.Theme.Body1(values.String(values.StrViewOnDcrdata)).Layout),
-				layout.Rigid(pg.Theme.Icons.RedirectIcon.Layout24dp),
-			)
-		})
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return pg.explorerLink(gtx, pg.toDcrdata, values.String(values.StrViewOnExplorer))
+			}),
+			layout.Rigid(func(gtx C) D {
+				if pg.transaction.BlockHeight == -1 {
+					return D{}
+				}
+				return layout.Inset{Top: values.MarginPadding16}.Layout(gtx, func(gtx C) D {
+					return pg.explorerLink(gtx, pg.toBlock, values.String(values.StrViewBlock))
+				})
+			}),
+		)
+	})
+}
+
+func (pg *TxDetailsPage) explorerLink(gtx C, clickable *decredmaterial.Clickable, title string) D {
+	return clickable.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min.X = gtx.Constraints.Max.X
+		return layout.Flex{Spacing: layout.SpaceBetween}.Layout(gtx,
+			layout.Rigid(pg.Theme.Body1(title).Layout),
+			layout.Rigid(pg.Theme.Icons.RedirectIcon.Layout24dp),
+		)
 	})
 }
 
@@ -736,55 +876,20 @@ func (pg *TxDetailsPage) pageSections(gtx layout.Context, body layout.Widget) la
 // displayed.
 // Part of the load.Page interface.
 func (pg *TxDetailsPage) HandleUserInteractions() {
+	pg.receiveFiatValues()
+
 	for pg.toDcrdata.Clicked() {
-		redirectURL := pg.WL.Wallet.GetBlockExplorerURL(pg.transaction.Hash)
-		info := modal.NewInfoModal(pg.Load).
-			Title("View on Dcrdata").
-			Body("Copy and paste the link below in your browser, to view transaction on dcrdata dashboard.").
-			SetCancelable(true).
-			UseCustomWidget(func(gtx C) D {
-				return layout.Stack{}.Layout(gtx,
-					layout.Stacked(func(gtx C) D {
-						border := widget.Border{Color: pg.Theme.Color.Gray4, CornerRadius: values.MarginPadding10, Width: values.MarginPadding2}
-						wrapper := pg.Theme.Card()
-						wrapper.Color = pg.Theme.Color.Gray4
-						return border.Layout(gtx, func(gtx C) D {
-							return wrapper.Layout(gtx, func(gtx C) D {
-								return layout.UniformInset(values.MarginPadding10).Layout(gtx, func(gtx C) D {
-									return layout.Flex{}.Layout(gtx,
-										layout.Flexed(0.9, pg.Theme.Body1(redirectURL).Layout),
-										layout.Flexed(0.1, func(gtx C) D {
-											return layout.E.Layout(gtx, func(gtx C) D {
-												return layout.Inset{Top: values.MarginPadding7}.Layout(gtx, func(gtx C) D {
-													if pg.copyRedirectURL.Clicked() {
-														clipboard.WriteOp{Text: redirectURL}.Add(gtx.Ops)
-														pg.Toast.Notify("URL copied")
-													}
-													return pg.copyRedirectURL.Layout(gtx, pg.Theme.Icons.CopyIcon.Layout24dp)
-												})
-											})
-										}),
-									)
-								})
-							})
-						})
-					}),
-					layout.Stacked(func(gtx layout.Context) layout.Dimensions {
-						return layout.Inset{
-							Top:  values.MarginPaddingMinus10,
-							Left: values.MarginPadding10,
-						}.Layout(gtx, func(gtx C) D {
-							label := pg.Theme.Body2("Web URL")
-							label.Color = pg.Theme.Color.GrayText2
-							return label.Layout(gtx)
-						})
-					}),
-				)
-			}).
-			PositiveButton("Got it", func(isChecked bool) bool {
-				return true
-			})
-		pg.ParentWindow().ShowModal(info)
+		templates := pg.WL.Wallet.ExplorerTemplates()
+		redirectURL := templates.TxURL(pg.transaction.Hash)
+		if pg.transaction.Type == dcrlibwallet.TxTypeTicketPurchase {
+			redirectURL = templates.TicketURL(pg.transaction.Hash)
+		}
+		components.ShowExplorerLink(pg.Load, pg.ParentWindow(), values.String(values.StrViewOnExplorer), redirectURL)
+	}
+
+	for pg.toBlock.Clicked() {
+		redirectURL := pg.WL.Wallet.ExplorerTemplates().BlockURL(pg.transaction.BlockHeight)
+		components.ShowExplorerLink(pg.Load, pg.ParentWindow(), values.String(values.StrViewBlock), redirectURL)
 	}
 
 	for pg.associatedTicketClickable.Clicked() {
@@ -793,10 +898,15 @@ func (pg *TxDetailsPage) HandleUserInteractions() {
 			pg.transaction = pg.ticketSpent
 			pg.getTXSourceAccountAndDirection()
 			pg.txnWidgets = initTxnWidgets(pg.Load, pg.transaction)
+			pg.loadFiatValue()
 			pg.ParentWindow().Reload()
 		}
 	}
 
+	if pg.editLabelButton.Clicked() {
+		pg.ParentWindow().ShowModal(newTxLabelModal(pg.Load, pg.wallet, pg.transaction, pg.ParentWindow().Reload))
+	}
+
 	if pg.rebroadcastClickable.Clicked() {
 		go func() {
 			pg.rebroadcastClickable.SetEnabled(false, nil)
@@ -812,7 +922,7 @@ func (pg *TxDetailsPage) HandleUserInteractions() {
 			err := pg.wallet.PublishUnminedTransactions()
 			if err != nil {
 				// If transactions are not published, notify the user
-				pg.Toast.NotifyError(err.Error())
+				pg.Toast.NotifyError(components.TranslateErr(err))
 			} else {
 				pg.Toast.Notify(values.String(values.StrRepublished))
 			}
@@ -849,7 +959,9 @@ func (pg *TxDetailsPage) handleTextCopyEvent(gtx layout.Context) {
 // OnNavigatedTo() will be called again. This method should not destroy UI
 // components unless they'll be recreated in the OnNavigatedTo() method.
 // Part of the load.Page interface.
-func (pg *TxDetailsPage) OnNavigatedFrom() {}
+func (pg *TxDetailsPage) OnNavigatedFrom() {
+	pg.ctxCancel()
+}
 
 func initTxnWidgets(l *load.Load, transaction *dcrlibwallet.Transaction) transactionWdg {
 
@@ -4,23 +4,35 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"time"
 
 	"gioui.org/layout"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
-	"gioui.org/widget"
+	"gioui.org/unit"
 
 	"github.com/planetdecred/dcrlibwallet"
 	"github.com/planetdecred/godcr/app"
 	"github.com/planetdecred/godcr/listeners"
 	"github.com/planetdecred/godcr/ui/decredmaterial"
 	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/modal"
 	"github.com/planetdecred/godcr/ui/page/components"
 	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
 )
 
 const TransactionsPageID = "Transactions"
 
+const (
+	// searchDelay is how long the search waits for the query to change
+	// again, the query changing on every keystroke.
+	searchDelay = 300 * time.Millisecond
+	// indexWaitInterval is how often a search waiting for the index checks
+	// it is ready.
+	indexWaitInterval = time.Second
+)
+
 type (
 	C = layout.Context
 	D = layout.Dimensions
@@ -35,9 +47,12 @@ type TransactionsPage struct {
 	*app.GenericPageModal
 
 	*listeners.TxAndBlockNotificationListener
-	ctx       context.Context // page context
-	ctxCancel context.CancelFunc
-	separator decredmaterial.Line
+	// notifications are handled by HandleUserInteractions, the list is only
+	// changed on the UI goroutine.
+	notifications listeners.TxNotificationQueue
+	ctx           context.Context // page context
+	ctxCancel     context.CancelFunc
+	separator     decredmaterial.Line
 
 	walletTabList         *decredmaterial.ClickableList // Tab list of all loaded wallets.
 	selectedCategoryIndex int
@@ -48,21 +63,51 @@ type TransactionsPage struct {
 	txTypeDropDown  *decredmaterial.DropDown
 	walletDropDown  *decredmaterial.DropDown
 	transactionList *decredmaterial.ClickableList
-	container       *widget.List
-	transactions    []dcrlibwallet.Transaction
-	wallets         []*dcrlibwallet.Wallet
+	// pager loads the transactions of the selected wallet as the list is
+	// scrolled, transactions are the results of the search instead if
+	// searching.
+	pager        *wallet.TxPager
+	transactions []dcrlibwallet.Transaction
+	// queuedTxs are the transactions waiting for peers to be broadcast, listed
+	// before the other transactions.
+	queuedTxs      []wallet.QueuedTx
+	wallets        []*dcrlibwallet.Wallet
+	selectedWallet *dcrlibwallet.Wallet
+	exportBtn      decredmaterial.Button
+
+	searchBar *txSearchBar
+	// searching is true if the transactions are selected by the search bar,
+	// searched once the results are received and indexing while the
+	// transactions of the wallet are not indexed yet. The search runs in the
+	// background, cancelSearch cancels the running search and its results are
+	// received from searchResults.
+	searching     bool
+	searched      bool
+	indexing      bool
+	searchGen     int
+	cancelSearch  context.CancelFunc
+	searchResults chan txSearchResult
+
+	fiat *wallet.FiatConverter
+}
+
+// txSearchResult is the result of the search with gen.
+type txSearchResult struct {
+	gen     int
+	txs     []dcrlibwallet.Transaction
+	indexed bool
 }
 
 func NewTransactionsPage(l *load.Load) *TransactionsPage {
 	pg := &TransactionsPage{
 		Load:             l,
 		GenericPageModal: app.NewGenericPageModal(TransactionsPageID),
-		container: &widget.List{
-			List: layout.List{Axis: layout.Vertical},
-		},
-		separator:       l.Theme.Separator(),
-		transactionList: l.Theme.NewClickableList(layout.Vertical),
-		walletTabList:   l.Theme.NewClickableList(layout.Horizontal),
+		separator:        l.Theme.Separator(),
+		transactionList:  l.Theme.NewClickableList(layout.Vertical),
+		walletTabList:    l.Theme.NewClickableList(layout.Horizontal),
+		exportBtn:        l.Theme.OutlineButton(values.String(values.StrExport)),
+		searchBar:        newTxSearchBar(l),
+		searchResults:    make(chan txSearchResult, 1),
 	}
 
 	pg.walletTabList.IsHoverable = false
@@ -90,6 +135,7 @@ func NewTransactionsPage(l *load.Load) *TransactionsPage {
 // Part of the load.Page interface.
 func (pg *TransactionsPage) OnNavigatedTo() {
 	pg.ctx, pg.ctxCancel = context.WithCancel(context.TODO())
+	pg.fiat = pg.WL.HistoricalFiatConverter()
 
 	pg.listenForTxNotifications()
 	pg.loadTransactions(pg.walletDropDown.SelectedIndex())
@@ -125,29 +171,181 @@ func (pg *TransactionsPage) refreshAvailableTxType(l *load.Load) {
 	}, values.TxDropdownGroup, 2)
 }
 
-func (pg *TransactionsPage) loadTransactions(selectedWalletIndex int) {
-	selectedWallet := pg.wallets[selectedWalletIndex]
-	newestFirst := pg.orderDropDown.SelectedIndex() == 0
-
-	txFilter := dcrlibwallet.TxFilterAll
+// selectedTxFilter returns the dcrlibwallet.TxFilter* value of the selected
+// transaction type.
+func (pg *TransactionsPage) selectedTxFilter() int32 {
 	switch pg.txTypeDropDown.SelectedIndex() {
 	case 1:
-		txFilter = dcrlibwallet.TxFilterSent
+		return dcrlibwallet.TxFilterSent
 	case 2:
-		txFilter = dcrlibwallet.TxFilterReceived
+		return dcrlibwallet.TxFilterReceived
 	case 3:
-		txFilter = dcrlibwallet.TxFilterTransferred
+		return dcrlibwallet.TxFilterTransferred
 	case 4:
-		txFilter = dcrlibwallet.TxFilterMixed
+		return dcrlibwallet.TxFilterMixed
 	case 5:
-		txFilter = dcrlibwallet.TxFilterStaking
+		return dcrlibwallet.TxFilterStaking
+	default:
+		return dcrlibwallet.TxFilterAll
+	}
+}
+
+func (pg *TransactionsPage) loadTransactions(selectedWalletIndex int) {
+	selectedWallet := pg.wallets[selectedWalletIndex]
+	pg.selectedWallet = selectedWallet
+	newestFirst := pg.orderDropDown.SelectedIndex() == 0
+	txFilter := pg.selectedTxFilter()
+
+	pg.searchBar.setWallet(selectedWallet)
+	query, ok := pg.searchBar.query(txFilter)
+	if !ok {
+		return
+	}
+	pg.searching = !query.IsZero()
+	pg.searched = false
+	pg.loadQueuedTxs()
+
+	// The results of a previous search are dropped.
+	pg.searchGen++
+	if pg.cancelSearch != nil {
+		pg.cancelSearch()
+		pg.cancelSearch = nil
+	}
+
+	if !pg.searching {
+		pg.transactions = nil
+		pg.pager = wallet.NewTxPager(selectedWallet, txFilter, newestFirst)
+		go pg.loadMoreTransactions(pg.pager)
+		return
+	}
+
+	var ctx context.Context
+	ctx, pg.cancelSearch = context.WithCancel(pg.ctx)
+	go pg.searchTransactions(ctx, pg.searchGen, selectedWallet, query, newestFirst)
+}
+
+// searchTransactions searches the transactions of w selected by q once the
+// query has not changed for searchDelay, waiting for w to be indexed, and
+// sends the results to searchResults unless ctx is canceled.
+func (pg *TransactionsPage) searchTransactions(ctx context.Context, gen int, w *dcrlibwallet.Wallet, q wallet.TxSearchQuery, newestFirst bool) {
+	delay := searchDelay
+	for {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		txs, indexed := pg.WL.Wallet.SearchTransactions(w, q, newestFirst)
+		select {
+		case pg.searchResults <- txSearchResult{gen: gen, txs: txs, indexed: indexed}:
+		case <-ctx.Done():
+			return
+		}
+		pg.ParentWindow().Reload()
+		if indexed {
+			return
+		}
+		delay = indexWaitInterval
+	}
+}
+
+// receiveSearchResults lists the results of the current search once
+// received.
+func (pg *TransactionsPage) receiveSearchResults() {
+	for {
+		select {
+		case result := <-pg.searchResults:
+			if result.gen != pg.searchGen {
+				continue
+			}
+			pg.searched = true
+			pg.indexing = !result.indexed
+			pg.transactions = result.txs
+			if pg.fiat != nil && len(result.txs) > 0 {
+				go pg.fillPriceHistory(result.txs)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// loadQueuedTxs lists the queued transactions of the selected wallet matching
+// the selected transaction type.
+func (pg *TransactionsPage) loadQueuedTxs() {
+	var queuedTxs []wallet.QueuedTx
+	// Queued transactions are not indexed, they are only listed when not
+	// searching.
+	if !pg.searching {
+		txFilter := pg.selectedTxFilter()
+		for _, q := range pg.WL.Wallet.QueuedTxsOf(pg.selectedWallet.ID) {
+			tx := q.Transaction()
+			if pg.selectedWallet.TxMatchesFilter(&tx, txFilter) {
+				queuedTxs = append(queuedTxs, q)
+			}
+		}
+	}
+	pg.queuedTxs = queuedTxs
+}
+
+// loadMoreTransactions reads the next page of transactions of pager and
+// refreshes the list once they are loaded.
+func (pg *TransactionsPage) loadMoreTransactions(pager *wallet.TxPager) {
+	txs, err := pager.LoadMore()
+	if err != nil {
+		log.Errorf("error loading transactions: %v", err)
+		return
+	}
+	if len(txs) > 0 {
+		if pg.fiat != nil {
+			go pg.fillPriceHistory(txs)
+		}
+		pg.ParentWindow().Reload()
+	}
+}
+
+// txCount returns the number of rows of the transaction list: the queued
+// transactions then the loaded or searched transactions.
+func (pg *TransactionsPage) txCount() int {
+	count := len(pg.queuedTxs)
+	if pg.searching {
+		return count + len(pg.transactions)
+	}
+	if pg.pager != nil {
+		count += pg.pager.Len()
+	}
+	return count
+}
+
+// txAt returns the transaction of the row at index of the transaction list,
+// ok is false if there is no such row.
+func (pg *TransactionsPage) txAt(index int) (tx dcrlibwallet.Transaction, ok bool) {
+	if index < len(pg.queuedTxs) {
+		return pg.queuedTxs[index].Transaction(), true
+	}
+	index -= len(pg.queuedTxs)
+	if pg.searching {
+		if index >= len(pg.transactions) {
+			return tx, false
+		}
+		return pg.transactions[index], true
+	}
+	if pg.pager == nil {
+		return tx, false
 	}
+	return pg.pager.Tx(index)
+}
 
-	wallTxs, err := selectedWallet.GetTransactionsRaw(0, 0, txFilter, newestFirst) //TODO
+// fillPriceHistory fetches the missing rates needed to show the fiat value of
+// txs and refreshes the list once they are available.
+func (pg *TransactionsPage) fillPriceHistory(txs []dcrlibwallet.Transaction) {
+	added, err := pg.WL.FillPriceHistory(pg.ctx, txs)
 	if err != nil {
-		// log.Error("Error loading transactions:", err)
-	} else {
-		pg.transactions = wallTxs
+		log.Errorf("error fetching price history: %v", err)
+	}
+	if added > 0 {
+		pg.ParentWindow().Reload()
 	}
 }
 
@@ -163,57 +361,16 @@ func (pg *TransactionsPage) Layout(gtx layout.Context) layout.Dimensions {
 
 func (pg *TransactionsPage) layoutDesktop(gtx layout.Context) layout.Dimensions {
 	container := func(gtx C) D {
-		wallTxs := pg.transactions
 		return layout.Stack{Alignment: layout.N}.Layout(gtx,
 			layout.Expanded(func(gtx C) D {
 				return layout.Inset{
 					Top: values.MarginPadding60,
-				}.Layout(gtx, func(gtx C) D {
-					return pg.Theme.List(pg.container).Layout(gtx, 1, func(gtx C, i int) D {
-						return layout.Inset{Right: values.MarginPadding2}.Layout(gtx, func(gtx C) D {
-							return pg.Theme.Card().Layout(gtx, func(gtx C) D {
-
-								// return "No transactions yet" text if there are no transactions
-								if len(wallTxs) == 0 {
-									padding := values.MarginPadding16
-									txt := pg.Theme.Body1(values.String(values.StrNoTransactions))
-									txt.Color = pg.Theme.Color.GrayText3
-									gtx.Constraints.Min.X = gtx.Constraints.Max.X
-									return layout.Center.Layout(gtx, func(gtx C) D {
-										return layout.Inset{Top: padding, Bottom: padding}.Layout(gtx, txt.Layout)
-									})
-								}
-
-								return pg.transactionList.Layout(gtx, len(wallTxs), func(gtx C, index int) D {
-									var row = components.TransactionRow{
-										Transaction: wallTxs[index],
-										Index:       index,
-										ShowBadge:   false,
-									}
-
-									return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-										layout.Rigid(func(gtx C) D {
-											return components.LayoutTransactionRow(gtx, pg.Load, row)
-										}),
-										layout.Rigid(func(gtx C) D {
-											// No divider for last row
-											if row.Index == len(wallTxs)-1 {
-												return layout.Dimensions{}
-											}
-
-											gtx.Constraints.Min.X = gtx.Constraints.Max.X
-											separator := pg.Theme.Separator()
-											return layout.E.Layout(gtx, func(gtx C) D {
-												// Show bottom divider for all rows except last
-												return layout.Inset{Left: values.MarginPadding56}.Layout(gtx, separator.Layout)
-											})
-										}),
-									)
-								})
-							})
-						})
-					})
-				})
+				}.Layout(gtx, pg.layoutTransactions)
+			}),
+			layout.Expanded(func(gtx C) D {
+				return layout.Inset{
+					Left: unit.Dp(float32(pg.walletDropDown.Width + 10)),
+				}.Layout(gtx, pg.layoutExportButton)
 			}),
 			layout.Expanded(func(gtx C) D {
 				return pg.walletDropDown.Layout(gtx, 0, false)
@@ -231,7 +388,6 @@ func (pg *TransactionsPage) layoutDesktop(gtx layout.Context) layout.Dimensions
 
 func (pg *TransactionsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 	container := func(gtx C) D {
-		wallTxs := pg.transactions
 		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 			layout.Rigid(func(gtx C) D {
 				if len(pg.wallets) > 1 {
@@ -246,51 +402,9 @@ func (pg *TransactionsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{
 							Top: values.MarginPadding60,
-						}.Layout(gtx, func(gtx C) D {
-							return pg.Theme.List(pg.container).Layout(gtx, 1, func(gtx C, i int) D {
-								return pg.Theme.Card().Layout(gtx, func(gtx C) D {
-
-									// return "No transactions yet" text if there are no transactions
-									if len(wallTxs) == 0 {
-										padding := values.MarginPadding16
-										txt := pg.Theme.Body1(values.String(values.StrNoTransactions))
-										txt.Color = pg.Theme.Color.GrayText3
-										gtx.Constraints.Min.X = gtx.Constraints.Max.X
-										return layout.Center.Layout(gtx, func(gtx C) D {
-											return layout.Inset{Top: padding, Bottom: padding}.Layout(gtx, txt.Layout)
-										})
-									}
-
-									return pg.transactionList.Layout(gtx, len(wallTxs), func(gtx C, index int) D {
-										var row = components.TransactionRow{
-											Transaction: wallTxs[index],
-											Index:       index,
-											ShowBadge:   false,
-										}
-
-										return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
-											layout.Rigid(func(gtx C) D {
-												return components.LayoutTransactionRow(gtx, pg.Load, row)
-											}),
-											layout.Rigid(func(gtx C) D {
-												// No divider for last row
-												if row.Index == len(wallTxs)-1 {
-													return layout.Dimensions{}
-												}
-
-												gtx.Constraints.Min.X = gtx.Constraints.Max.X
-												separator := pg.Theme.Separator()
-												return layout.E.Layout(gtx, func(gtx C) D {
-													// Show bottom divider for all rows except last
-													return layout.Inset{Left: values.MarginPadding56}.Layout(gtx, separator.Layout)
-												})
-											}),
-										)
-									})
-								})
-							})
-						})
+						}.Layout(gtx, pg.layoutTransactions)
 					}),
+					layout.Expanded(pg.layoutExportButton),
 					layout.Expanded(func(gtx C) D {
 						return layout.Inset{Right: values.MarginPadding10}.Layout(gtx, func(gtx C) D {
 							return pg.orderDropDown.Layout(gtx, 0, true)
@@ -309,6 +423,92 @@ func (pg *TransactionsPage) layoutMobile(gtx layout.Context) layout.Dimensions {
 	return components.UniformMobile(gtx, false, true, container)
 }
 
+// layoutTransactions draws the search bar and the transaction list. Only the
+// visible rows of the list are drawn, the next page of transactions is loaded
+// by HandleUserInteractions when the end of the list is visible.
+func (pg *TransactionsPage) layoutTransactions(gtx C) D {
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(pg.layoutSearchBar),
+		layout.Flexed(1, func(gtx C) D {
+			return pg.Theme.Card().Layout(gtx, func(gtx C) D {
+				count := pg.txCount()
+
+				// return "No transactions yet" text if there are no transactions
+				if count == 0 {
+					padding := values.MarginPadding16
+					txt := pg.Theme.Body1(values.String(values.StrNoTransactions))
+					if pg.searching && pg.searched && !pg.indexing {
+						txt.Text = values.String(values.StrNoMatchingTxs)
+					} else if pg.searching {
+						txt.Text = values.String(values.StrLoading)
+					} else if pg.pager == nil || !pg.pager.Done() {
+						txt.Text = values.String(values.StrLoading)
+					}
+					txt.Color = pg.Theme.Color.GrayText3
+					gtx.Constraints.Min.X = gtx.Constraints.Max.X
+					return layout.Center.Layout(gtx, func(gtx C) D {
+						return layout.Inset{Top: padding, Bottom: padding}.Layout(gtx, txt.Layout)
+					})
+				}
+
+				return pg.transactionList.Layout(gtx, count, func(gtx C, index int) D {
+					tx, ok := pg.txAt(index)
+					if !ok {
+						return D{}
+					}
+					var row = components.TransactionRow{
+						Transaction:      tx,
+						Index:            index,
+						ShowBadge:        false,
+						FiatValue:        pg.WL.TxFiatValue(pg.fiat, &tx),
+						PendingBroadcast: index < len(pg.queuedTxs),
+					}
+
+					return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+						layout.Rigid(func(gtx C) D {
+							return components.LayoutTransactionRow(gtx, pg.Load, row)
+						}),
+						layout.Rigid(func(gtx C) D {
+							// No divider for last row
+							if row.Index == count-1 {
+								return layout.Dimensions{}
+							}
+
+							gtx.Constraints.Min.X = gtx.Constraints.Max.X
+							separator := pg.Theme.Separator()
+							return layout.E.Layout(gtx, func(gtx C) D {
+								// Show bottom divider for all rows except last
+								return layout.Inset{Left: values.MarginPadding56}.Layout(gtx, separator.Layout)
+							})
+						}),
+					)
+				})
+			})
+		}),
+	)
+}
+
+func (pg *TransactionsPage) layoutSearchBar(gtx C) D {
+	return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(pg.searchBar.layout),
+			layout.Rigid(func(gtx C) D {
+				if !pg.searching || !pg.indexing {
+					return D{}
+				}
+				txt := pg.Theme.Caption(values.String(values.StrIndexingTxs))
+				txt.Color = pg.Theme.Color.GrayText2
+				return txt.Layout(gtx)
+			}),
+		)
+	})
+}
+
+func (pg *TransactionsPage) layoutExportButton(gtx C) D {
+	gtx.Constraints.Min = image.Point{}
+	return pg.exportBtn.Layout(gtx)
+}
+
 func (pg *TransactionsPage) layoutTabs(gtx C) D {
 	var dims layout.Dimensions
 
@@ -358,6 +558,19 @@ func (pg *TransactionsPage) layoutTabs(gtx C) D {
 // displayed.
 // Part of the load.Page interface.
 func (pg *TransactionsPage) HandleUserInteractions() {
+	if pg.searchBar.handle() {
+		pg.loadTransactions(pg.walletDropDown.SelectedIndex())
+	}
+	pg.receiveSearchResults()
+	pg.handleTxNotifications()
+
+	// Load the next page once the last rows of the list are visible.
+	if pager := pg.pager; !pg.searching && pager != nil && !pager.Done() && !pager.Loading() {
+		position := pg.transactionList.Position
+		if position.First+position.Count >= pg.txCount()-wallet.TxPageSize/4 {
+			go pg.loadMoreTransactions(pager)
+		}
+	}
 
 	for pg.txTypeDropDown.Changed() {
 		pg.loadTransactions(pg.walletDropDown.SelectedIndex())
@@ -371,8 +584,16 @@ func (pg *TransactionsPage) HandleUserInteractions() {
 		pg.loadTransactions(pg.walletDropDown.SelectedIndex())
 	}
 
+	if pg.exportBtn.Clicked() && pg.selectedWallet != nil {
+		pg.ParentWindow().ShowModal(newExportModal(pg.Load, pg.selectedWallet, pg.selectedTxFilter()))
+	}
+
 	if clicked, selectedItem := pg.transactionList.ItemClicked(); clicked {
-		pg.ParentNavigator().Display(NewTransactionDetailsPage(pg.Load, &pg.transactions[selectedItem]))
+		if selectedItem < len(pg.queuedTxs) {
+			pg.showQueuedTxModal(pg.queuedTxs[selectedItem])
+		} else if tx, ok := pg.txAt(selectedItem); ok {
+			pg.ParentNavigator().Display(NewTransactionDetailsPage(pg.Load, &tx))
+		}
 	}
 	decredmaterial.DisplayOneDropdown(pg.walletDropDown, pg.txTypeDropDown, pg.orderDropDown)
 
@@ -387,6 +608,56 @@ func (pg *TransactionsPage) HandleUserInteractions() {
 	}
 }
 
+// showQueuedTxModal shows the broadcast attempts of the queued transaction q,
+// which the user may cancel to release the coins it spends.
+func (pg *TransactionsPage) showQueuedTxModal(q wallet.QueuedTx) {
+	body := values.StringF(values.StrQueuedTxInfo, q.Hash, q.Attempts)
+	if q.LastError != "" {
+		body = fmt.Sprintf("%s\n\n%s", body, values.StringF(values.StrLastBroadcastError, q.LastError))
+	}
+
+	infoModal := modal.NewInfoModal(pg.Load).
+		Title(values.String(values.StrPendingBroadcast)).
+		Body(body).
+		NegativeButton(values.String(values.StrGotIt), func() {}).
+		PositiveButtonStyle(pg.Theme.Color.Surface, pg.Theme.Color.Danger).
+		PositiveButton(values.String(values.StrCancelQueuedTx), func(isChecked bool) bool {
+			if err := pg.WL.Wallet.CancelQueuedTx(q.Hash); err != nil {
+				pg.Toast.NotifyError(components.TranslateErr(err))
+				return true
+			}
+			pg.Toast.Notify(values.String(values.StrQueuedTxCanceled))
+			pg.loadTransactions(pg.walletDropDown.SelectedIndex())
+			return true
+		})
+	pg.ParentWindow().ShowModal(infoModal)
+}
+
+// handleTxNotifications merges the notified transactions of the selected
+// wallet into the list.
+func (pg *TransactionsPage) handleTxNotifications() {
+	for _, n := range pg.notifications.Take() {
+		switch n.Type {
+		case listeners.NewTransaction:
+			if pg.selectedWallet == nil || pg.selectedWallet.ID != n.Transaction.WalletID {
+				continue
+			}
+			if pg.searching {
+				pg.loadTransactions(pg.walletDropDown.SelectedIndex())
+				continue
+			}
+			pg.loadQueuedTxs()
+			if pg.pager != nil && pg.pager.Merge(n.Transaction) && pg.fiat != nil {
+				go pg.fillPriceHistory([]dcrlibwallet.Transaction{*n.Transaction})
+			}
+		case listeners.TxConfirmed:
+			if pg.pager != nil && pg.selectedWallet != nil && pg.selectedWallet.ID == n.WalletID {
+				pg.pager.Confirm(n.Hash, n.BlockHeight)
+			}
+		}
+	}
+}
+
 func (pg *TransactionsPage) listenForTxNotifications() {
 	if pg.TxAndBlockNotificationListener != nil {
 		return
@@ -402,13 +673,16 @@ func (pg *TransactionsPage) listenForTxNotifications() {
 		for {
 			select {
 			case n := <-pg.TxAndBlockNotifChan:
-				if n.Type == listeners.NewTransaction {
-
-					selectedWallet := pg.wallets[pg.walletDropDown.SelectedIndex()]
-					if selectedWallet.ID == n.Transaction.WalletID {
-						pg.loadTransactions(pg.walletDropDown.SelectedIndex())
-						pg.ParentWindow().Reload()
-					}
+				switch n.Type {
+				case listeners.NewTransaction:
+					// The main page adds the transaction to the index too,
+					// it is added here in case the page is notified first.
+					pg.WL.Wallet.TxIndex().AddTx(n.Transaction)
+					pg.notifications.Push(n)
+					pg.ParentWindow().Reload()
+				case listeners.TxConfirmed:
+					pg.notifications.Push(n)
+					pg.ParentWindow().Reload()
 				}
 			case <-pg.ctx.Done():
 				pg.WL.MultiWallet.RemoveTxAndBlockNotificationListener(TransactionsPageID)
@@ -0,0 +1,101 @@
+package transaction
+
+import (
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/widget"
+
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+)
+
+// txLabelModal edits the label and the note of a transaction.
+type txLabelModal struct {
+	*load.Load
+	*decredmaterial.Modal
+
+	wallet      *dcrlibwallet.Wallet
+	transaction *dcrlibwallet.Transaction
+	saved       func()
+
+	labelEditor decredmaterial.Editor
+	noteEditor  decredmaterial.Editor
+
+	cancelBtn decredmaterial.Button
+	saveBtn   decredmaterial.Button
+}
+
+// newTxLabelModal returns the modal editing the label of transaction of w,
+// saved is called once it is saved.
+func newTxLabelModal(l *load.Load, w *dcrlibwallet.Wallet, transaction *dcrlibwallet.Transaction, saved func()) *txLabelModal {
+	lm := &txLabelModal{
+		Load:        l,
+		Modal:       l.Theme.ModalFloatTitle("tx_label_modal"),
+		wallet:      w,
+		transaction: transaction,
+		saved:       saved,
+
+		labelEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrLabel)),
+		noteEditor:  l.Theme.Editor(new(widget.Editor), values.String(values.StrNote)),
+
+		cancelBtn: l.Theme.OutlineButton(values.String(values.StrCancel)),
+		saveBtn:   l.Theme.Button(values.String(values.StrSave)),
+	}
+	lm.labelEditor.Editor.SingleLine = true
+
+	label := l.WL.Wallet.TxLabelOf(w.ID, transaction.Hash)
+	lm.labelEditor.Editor.SetText(label.Label)
+	lm.noteEditor.Editor.SetText(label.Note)
+
+	return lm
+}
+
+func (lm *txLabelModal) OnResume() {
+	lm.labelEditor.Editor.Focus()
+}
+
+func (lm *txLabelModal) OnDismiss() {}
+
+func (lm *txLabelModal) Handle() {
+	if lm.saveBtn.Clicked() {
+		lm.WL.Wallet.SetTxLabel(lm.wallet, lm.transaction, lm.labelEditor.Editor.Text(), lm.noteEditor.Editor.Text())
+		lm.Toast.Notify(values.String(values.StrTxLabelSaved))
+		lm.saved()
+		lm.Dismiss()
+	}
+
+	if lm.cancelBtn.Clicked() || lm.Modal.BackdropClicked(true) {
+		lm.Dismiss()
+	}
+}
+
+func (lm *txLabelModal) Layout(gtx layout.Context) layout.Dimensions {
+	w := []layout.Widget{
+		func(gtx C) D {
+			t := lm.Theme.H6(values.String(values.StrEditTxLabel))
+			t.Font.Weight = text.SemiBold
+			return t.Layout(gtx)
+		},
+		func(gtx C) D {
+			lbl := lm.Theme.Label(values.TextSize14, values.String(values.StrTxLabelHint))
+			lbl.Color = lm.Theme.Color.GrayText2
+			return lbl.Layout(gtx)
+		},
+		lm.labelEditor.Layout,
+		lm.noteEditor.Layout,
+		func(gtx C) D {
+			return layout.E.Layout(gtx, func(gtx C) D {
+				return layout.Flex{Axis: layout.Horizontal}.Layout(gtx,
+					layout.Rigid(func(gtx C) D {
+						return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, lm.cancelBtn.Layout)
+					}),
+					layout.Rigid(lm.saveBtn.Layout),
+				)
+			})
+		},
+	}
+
+	return lm.Modal.Layout(gtx, w)
+}
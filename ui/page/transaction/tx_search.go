@@ -0,0 +1,226 @@
+package transaction
+
+import (
+	"strconv"
+	"strings"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/godcr/ui/decredmaterial"
+	"github.com/planetdecred/godcr/ui/load"
+	"github.com/planetdecred/godcr/ui/values"
+	"github.com/planetdecred/godcr/wallet"
+)
+
+// txSearchBar is the search field of the transactions page with the filters
+// of the advanced search, which are hidden until the filters button is
+// clicked.
+type txSearchBar struct {
+	*load.Load
+
+	textEditor    decredmaterial.Editor
+	filtersButton decredmaterial.Button
+	clearButton   decredmaterial.Button
+	showFilters   bool
+
+	minAmountEditor decredmaterial.Editor
+	maxAmountEditor decredmaterial.Editor
+	fromEditor      decredmaterial.Editor
+	toEditor        decredmaterial.Editor
+	accountSwitch   *decredmaterial.SwitchButtonText
+	confirmSwitch   *decredmaterial.SwitchButtonText
+
+	// accounts are the account numbers of the items of accountSwitch after
+	// the first, which selects all accounts.
+	accounts []int32
+	walletID int
+}
+
+func newTxSearchBar(l *load.Load) *txSearchBar {
+	sb := &txSearchBar{
+		Load:          l,
+		textEditor:    l.Theme.IconEditor(new(widget.Editor), values.String(values.StrSearchTxsHint), l.Theme.Icons.SearchIcon, true),
+		filtersButton: l.Theme.OutlineButton(values.String(values.StrFilters)),
+		clearButton:   l.Theme.OutlineButton(values.String(values.StrClear)),
+
+		minAmountEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrMinAmountDCR)),
+		maxAmountEditor: l.Theme.Editor(new(widget.Editor), values.String(values.StrMaxAmountDCR)),
+		fromEditor:      l.Theme.Editor(new(widget.Editor), values.String(values.StrFromDate)),
+		toEditor:        l.Theme.Editor(new(widget.Editor), values.String(values.StrToDate)),
+		walletID:        -1,
+	}
+
+	for _, editor := range sb.editors() {
+		editor.Editor.SingleLine = true
+	}
+
+	// The items follow the order of the wallet.TxConfirmState values.
+	sb.confirmSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{
+		{Text: values.String(values.StrAll)},
+		{Text: values.String(values.StrPending)},
+		{Text: values.String(values.StrConfirmed)},
+	})
+	sb.accountSwitch = l.Theme.SwitchButtonText([]decredmaterial.SwitchItem{{Text: values.String(values.StrAll)}})
+
+	return sb
+}
+
+func (sb *txSearchBar) editors() []*decredmaterial.Editor {
+	return []*decredmaterial.Editor{&sb.textEditor, &sb.minAmountEditor, &sb.maxAmountEditor, &sb.fromEditor, &sb.toEditor}
+}
+
+// setWallet lists the accounts of w in the account filter, which is reset
+// if w is not the wallet previously searched.
+func (sb *txSearchBar) setWallet(w *dcrlibwallet.Wallet) {
+	if w.ID == sb.walletID {
+		return
+	}
+	sb.walletID = w.ID
+
+	items := []decredmaterial.SwitchItem{{Text: values.String(values.StrAll)}}
+	sb.accounts = nil
+	if accounts, err := w.GetAccountsRaw(); err == nil {
+		for _, account := range accounts.Acc {
+			items = append(items, decredmaterial.SwitchItem{Text: account.Name})
+			sb.accounts = append(sb.accounts, account.Number)
+		}
+	}
+	sb.accountSwitch = sb.Theme.SwitchButtonText(items)
+}
+
+// handle returns true if the search changed since the last call.
+func (sb *txSearchBar) handle() bool {
+	changed := false
+	for _, editor := range sb.editors() {
+		if _, editorChanged := decredmaterial.HandleEditorEvents(editor.Editor); editorChanged {
+			editor.SetError("")
+			changed = true
+		}
+	}
+
+	if sb.accountSwitch.Changed() || sb.confirmSwitch.Changed() {
+		changed = true
+	}
+
+	if sb.filtersButton.Clicked() {
+		sb.showFilters = !sb.showFilters
+	}
+
+	if sb.clearButton.Clicked() {
+		for _, editor := range sb.editors() {
+			editor.Editor.SetText("")
+			editor.SetError("")
+		}
+		sb.accountSwitch.SetSelectedIndex(1)
+		sb.confirmSwitch.SetSelectedIndex(1)
+		changed = true
+	}
+	return changed
+}
+
+func parseSearchAmount(editor *decredmaterial.Editor) (int64, bool) {
+	text := strings.TrimSpace(editor.Editor.Text())
+	if text == "" {
+		return 0, true
+	}
+
+	dcr, err := strconv.ParseFloat(text, 64)
+	atoms, convErr := dcrutil.NewAmount(dcr)
+	if err != nil || convErr != nil || atoms < 0 {
+		editor.SetError(values.String(values.StrInvalidAmount))
+		return 0, false
+	}
+	return int64(atoms), true
+}
+
+// query returns the search of the transactions of type txFilter. ok is false
+// if a field is invalid, its error is shown on its editor.
+func (sb *txSearchBar) query(txFilter int32) (q wallet.TxSearchQuery, ok bool) {
+	q.Text = sb.textEditor.Editor.Text()
+	q.Filter = txFilter
+	q.Confirm = wallet.TxConfirmState(sb.confirmSwitch.SelectedIndex() - 1)
+	if i := sb.accountSwitch.SelectedIndex() - 2; i >= 0 && i < len(sb.accounts) {
+		q.Account = &sb.accounts[i]
+	}
+
+	minAmount, minOk := parseSearchAmount(&sb.minAmountEditor)
+	maxAmount, maxOk := parseSearchAmount(&sb.maxAmountEditor)
+	from, fromOk := parseExportDate(&sb.fromEditor)
+	to, toOk := parseExportDate(&sb.toEditor)
+	if !minOk || !maxOk || !fromOk || !toOk {
+		return q, false
+	}
+	if maxAmount > 0 && minAmount > maxAmount {
+		sb.maxAmountEditor.SetError(values.String(values.StrInvalidAmountRange))
+		return q, false
+	}
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1) // include transactions of the end date
+	}
+
+	q.MinAmount, q.MaxAmount, q.From, q.To = minAmount, maxAmount, from, to
+	return q, true
+}
+
+func (sb *txSearchBar) layout(gtx C) D {
+	pair := func(left, right layout.Widget) layout.Widget {
+		return func(gtx C) D {
+			return layout.Flex{}.Layout(gtx,
+				layout.Flexed(.5, func(gtx C) D {
+					return layout.Inset{Right: values.MarginPadding4}.Layout(gtx, left)
+				}),
+				layout.Flexed(.5, func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding4}.Layout(gtx, right)
+				}),
+			)
+		}
+	}
+	labeled := func(label string, w layout.Widget) layout.Widget {
+		return func(gtx C) D {
+			return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					lbl := sb.Theme.Label(values.TextSize14, label)
+					lbl.Color = sb.Theme.Color.GrayText2
+					return lbl.Layout(gtx)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Top: values.MarginPadding4}.Layout(gtx, w)
+				}),
+			)
+		}
+	}
+
+	rows := []layout.Widget{
+		func(gtx C) D {
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Flexed(1, sb.textEditor.Layout),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, sb.filtersButton.Layout)
+				}),
+				layout.Rigid(func(gtx C) D {
+					return layout.Inset{Left: values.MarginPadding8}.Layout(gtx, sb.clearButton.Layout)
+				}),
+			)
+		},
+	}
+	if sb.showFilters {
+		rows = append(rows,
+			pair(sb.minAmountEditor.Layout, sb.maxAmountEditor.Layout),
+			pair(sb.fromEditor.Layout, sb.toEditor.Layout),
+			labeled(values.String(values.StrAccount), sb.accountSwitch.Layout),
+			labeled(values.String(values.StrConfirmations), sb.confirmSwitch.Layout),
+		)
+	}
+
+	children := make([]layout.FlexChild, len(rows))
+	for i, row := range rows {
+		row := row
+		children[i] = layout.Rigid(func(gtx C) D {
+			return layout.Inset{Bottom: values.MarginPadding8}.Layout(gtx, row)
+		})
+	}
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx, children...)
+}
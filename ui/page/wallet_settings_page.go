@@ -306,7 +306,7 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 				go func() {
 					err := pg.wallet.UnlockWallet([]byte(password))
 					if err != nil {
-						pm.SetError(err.Error())
+						pm.SetError(components.TranslateErr(err))
 						pm.SetLoading(false)
 						return
 					}
@@ -324,7 +324,7 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 								err := pg.WL.MultiWallet.ChangePrivatePassphraseForWallet(pg.wallet.ID, []byte(password),
 									[]byte(newPassword), dcrlibwallet.PassphraseTypePass)
 								if err != nil {
-									m.SetError(err.Error())
+									m.SetError(components.TranslateErr(err))
 									m.SetLoading(false)
 									return
 								}
@@ -356,7 +356,7 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 							pg.Toast.NotifyError(values.String(values.StrNotConnected))
 							return true
 						}
-						pg.Toast.NotifyError(err.Error())
+						pg.Toast.NotifyError(components.TranslateErr(err))
 						return true
 					}
 					msg := values.String(values.StrRescanProgressNotification)
@@ -395,7 +395,7 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 						// no password is required for watching only wallets.
 						err := pg.WL.MultiWallet.DeleteWallet(pg.wallet.ID, nil)
 						if err != nil {
-							pg.Toast.NotifyError(err.Error())
+							pg.Toast.NotifyError(components.TranslateErr(err))
 							confirmRemoveWalletModal.SetLoading(false)
 						} else {
 							walletDeleted()
@@ -412,7 +412,7 @@ func (pg *WalletSettingsPage) HandleUserInteractions() {
 						go func() {
 							err := pg.WL.MultiWallet.DeleteWallet(pg.wallet.ID, []byte(password))
 							if err != nil {
-								pm.SetError(err.Error())
+								pm.SetError(components.TranslateErr(err))
 								pm.SetLoading(false)
 								return
 							}
@@ -424,13 +424,13 @@ func (pg *CreateWallet) HandleUserInteractions() {
 				go func() {
 					wal, err := pg.WL.MultiWallet.CreateNewWallet(pg.walletName.Editor.Text(), password, dcrlibwallet.PassphraseTypePass)
 					if err != nil {
-						m.SetError(err.Error())
+						m.SetError(components.TranslateErr(err))
 						m.SetLoading(false)
 						return
 					}
 					err = wal.CreateMixerAccounts("mixed", "unmixed", password)
 					if err != nil {
-						m.SetError(err.Error())
+						m.SetError(components.TranslateErr(err))
 						m.SetLoading(false)
 						return
 					}
@@ -458,7 +458,7 @@ func (pg *CreateWallet) HandleUserInteractions() {
 		go func() {
 			_, err := pg.WL.MultiWallet.CreateWatchOnlyWallet(pg.walletName.Editor.Text(), pg.watchOnlyWalletHex.Editor.Text())
 			if err != nil {
-				pg.watchOnlyWalletHex.SetError(err.Error())
+				pg.watchOnlyWalletHex.SetError(components.TranslateErr(err))
 				return
 			}
 			pg.handlerWalletDexServerSelectorCallBacks()
@@ -1,6 +1,10 @@
 package values
 
-import "github.com/planetdecred/godcr/ui/values/localizable"
+import (
+	"strings"
+
+	"github.com/planetdecred/godcr/ui/values/localizable"
+)
 
 var (
 	ArrLanguages          map[string]string
@@ -9,7 +13,22 @@ var (
 
 const (
 	DefaultExchangeValue = "none"
-	USDExchangeValue     = "USD (Bittrex)"
+
+	// Currency conversion options are saved as "provider:currency" pairs.
+	DcrdataUSDExchangeValue   = "dcrdata:USD"
+	CoinGeckoUSDExchangeValue = "coingecko:USD"
+	CoinGeckoEURExchangeValue = "coingecko:EUR"
+	CoinGeckoGBPExchangeValue = "coingecko:GBP"
+	CoinGeckoNGNExchangeValue = "coingecko:NGN"
+	BinanceUSDTExchangeValue  = "binance:USDT"
+
+	// DefaultUSDExchangeValue is the option used when USD conversion is
+	// enabled without a specific source.
+	DefaultUSDExchangeValue = DcrdataUSDExchangeValue
+
+	// LegacyUSDExchangeValue is the option saved by earlier versions when USD
+	// conversion through Bittrex was enabled.
+	LegacyUSDExchangeValue = "USD (Bittrex)"
 )
 
 func init() {
@@ -20,5 +39,21 @@ func init() {
 
 	ArrExchangeCurrencies = make(map[string]string)
 	ArrExchangeCurrencies[DefaultExchangeValue] = StrNone
-	ArrExchangeCurrencies[USDExchangeValue] = StrUsdBittrex
+	ArrExchangeCurrencies[DcrdataUSDExchangeValue] = StrUsdDcrdata
+	ArrExchangeCurrencies[CoinGeckoUSDExchangeValue] = StrUsdCoinGecko
+	ArrExchangeCurrencies[CoinGeckoEURExchangeValue] = StrEurCoinGecko
+	ArrExchangeCurrencies[CoinGeckoGBPExchangeValue] = StrGbpCoinGecko
+	ArrExchangeCurrencies[CoinGeckoNGNExchangeValue] = StrNgnCoinGecko
+	ArrExchangeCurrencies[BinanceUSDTExchangeValue] = StrUsdtBinance
+}
+
+// ParseExchangeValue splits a currency conversion option into its exchange
+// rate provider and currency. ok is false if the option does not select a
+// provider.
+func ParseExchangeValue(option string) (provider, currency string, ok bool) {
+	parts := strings.SplitN(option, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
 }
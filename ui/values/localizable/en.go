@@ -165,7 +165,12 @@ unlockWithPassword = "Unlock with password"
 "english" = "English";
 "french" = "French";
 "spanish" = "Spanish";
-"usdBittrex" = "USD (Bittrex)";
+"usdDcrdata" = "USD (dcrdata)";
+"usdCoinGecko" = "USD (CoinGecko)";
+"eurCoinGecko" = "EUR (CoinGecko)";
+"gbpCoinGecko" = "GBP (CoinGecko)";
+"ngnCoinGecko" = "NGN (CoinGecko)";
+"usdtBinance" = "USDT (Binance)";
 "none" = "None";
 "proposals" = "Proposals";
 "dex" = "Dex";
@@ -487,4 +492,219 @@ unlockWithPassword = "Unlock with password"
 "account" = "Account"
 "selectDexServerToOpen" = "Select the Dex server you would like to open."
 "addDexServer" = "Add dex server"
+"valueWhenMined" = "Value when mined";
+"importPriceHistory" = "Import price history";
+"priceHistoryCSVPath" = "CSV file path (date,currency,rate)";
+"priceHistoryImported" = "%d prices imported";
+"export" = "Export";
+"exportTransactions" = "Export transactions";
+"allWallets" = "All wallets";
+"fromDate" = "From (YYYY-MM-DD)";
+"toDate" = "To (YYYY-MM-DD)";
+"includeFiatValue" = "Include fiat value";
+"filePath" = "File path";
+"invalidDate" = "Invalid date, use YYYY-MM-DD";
+"txExported" = "%d transactions exported to %s";
+"exportFormat" = "Format";
+"viewOnExplorer" = "View on block explorer";
+"viewBlock" = "View block";
+"blockExplorer" = "Block explorer";
+"txTemplate" = "Transaction URL";
+"addressTemplate" = "Address URL";
+"blockTemplate" = "Block URL";
+"ticketTemplate" = "Ticket URL";
+"resetToDefaults" = "Reset to defaults";
+"explorerTemplatesInfo" = "Links to the block explorer are made from these templates. {hash}, {address} and {block} are replaced by the transaction hash, the address and the block height.";
+"explorerTemplatesSaved" = "Block explorer saved";
+"errInsufficientFunds" = "There are not enough spendable funds for this transaction.";
+"errNotSynced" = "The wallets must be synced first.";
+"errNoPeers" = "Not connected to any peers of the Decred network.";
+"errVSP" = "The voting service provider could not complete the request.";
+"errPoliteia" = "Politeia could not complete the request.";
+"errDEX" = "The DEX could not complete the request.";
+"errDetails" = "%s Details: %s";
+"addRecipient" = "Add recipient";
+"importRecipients" = "Import recipients";
+"recipientsCSVPath" = "Path of the CSV file (address,amount)";
+"recipientsImported" = "%d recipients imported";
+"recipientNumber" = "Recipient %d";
+"totalFeeRecipients" = "Total fee (%d recipients)";
+"invalidAmount" = "Invalid amount";
+"requestedAmount" = "Requested amount in DCR (optional)";
+"copyPaymentURI" = "Copy payment URI";
+"invalidPaymentURI" = "Invalid payment URI";
+"feeRateLow" = "Low";
+"feeRateNormal" = "Normal";
+"feeRateHigh" = "High";
+"feeRateCustom" = "Custom";
+"customFeeRate" = "Fee rate (atoms/kB)";
+"invalidFeeRate" = "Enter a fee rate between %d and %d atoms/kB";
+"confirmationTime" = "%d minutes (%d blocks)";
+"feeRateAtomsKB" = "%d atoms/kB";
+"coinControl" = "Coin control";
+"coinSelection" = "Coin selection";
+"coinSelectionRandom" = "Random";
+"largestFirst" = "Largest first";
+"smallestFirst" = "Smallest first";
+"coinSelectionPrivacy" = "Privacy";
+"minimizeChange" = "Minimize change";
+"newestFirst" = "Newest first";
+"oldestFirst" = "Oldest first";
+"searchCoins" = "Search address, label or transaction";
+"hideFrozen" = "Hide frozen";
+"frozen" = "Frozen";
+"label" = "Label";
+"coinLabel" = "Label of the coin";
+"dateUTC" = "Date (UTC)";
+"confirmations" = "Confirmations";
+"selected" = "Selected";
+"afterFee" = "After fee";
+"coinsSelected" = "%d coins selected";
+"useSelectedCoins" = "Use selected coins";
+"consolidateCoins" = "Consolidate coins";
+"consolidationThreshold" = "Consolidate coins worth up to (DCR)";
+"consolidateInto" = "Consolidate into";
+"sameAccount" = "Same account";
+"otherAccount" = "Other account";
+"findCoins" = "Find coins";
+"coinsToConsolidate" = "Coins to consolidate";
+"dustCoins" = "Dust coins";
+"consolidatedAmount" = "Consolidated amount";
+"consolidationFee" = "Consolidation fee";
+"futureFeesSaved" = "Future fees saved";
+"netSavings" = "Net savings";
+"consolidationNotWorth" = "The fee of this consolidation is higher than the fees it saves.";
+"noCoinsToConsolidate" = "There are not enough coins worth up to %s to consolidate.";
+"consolidationLimit" = "Only the %d smallest coins are consolidated at once, consolidate again to merge the others.";
+"consolidate" = "Consolidate";
+"coinsConsolidated" = "Coins consolidated";
+"back" = "Back";
+"unsigned" = "Unsigned";
+"signed" = "Signed";
+"txInputs" = "Inputs (%d)";
+"txOutputs" = "Outputs (%d)";
+"size" = "Size";
+"exportUnsignedTx" = "Export unsigned transaction";
+"exportUnsignedTxNote" = "Watch-only wallets cannot sign. Sign the exported file with the Sign transaction tool of godcr on the computer holding the seed of this wallet, then broadcast it from here.";
+"unsignedTxExported" = "Unsigned transaction saved to %s";
+"signTransaction" = "Sign transaction";
+"broadcastTransaction" = "Broadcast signed transaction";
+"signTxNote" = "Open an unsigned transaction exported by a watch-only wallet. It is signed by the wallet with the same account and saved to a new file.";
+"broadcastTxNote" = "Open a transaction signed offline to publish it through the watch-only wallet it spends from.";
+"open" = "Open";
+"signedTxPath" = "Save the signed transaction to";
+"sign" = "Sign";
+"broadcast" = "Broadcast";
+"txSignedSaved" = "Signed transaction saved to %s";
+"txBroadcast" = "Transaction %s broadcast";
+"txAlreadySigned" = "The transaction is already signed";
+"txNotSigned" = "The transaction is not signed";
+"addressBook" = "Address book";
+"contactName" = "Name";
+"notes" = "Notes";
+"contactNameRequired" = "Enter the name of the contact";
+"contactExists" = "A contact with this address already exists";
+"addContact" = "Add contact";
+"editContact" = "Edit contact";
+"ownWalletAddress" = "This address belongs to your wallet %s";
+"searchContacts" = "Search contacts";
+"deleteContact" = "Delete contact";
+"deleteContactConfirm" = "Delete %s from the address book?";
+"contactsImported" = "%d contacts imported";
+"importAddressBook" = "Import address book";
+"exportAddressBook" = "Export address book";
+"addressBookExported" = "Address book saved to %s";
+"noContacts" = "No contacts";
+"manageAddressBook" = "Manage address book";
+"contactDescription" = "Contact: %s";
+"scheduledPayments" = "Scheduled payments";
+"addScheduledPayment" = "Add scheduled payment";
+"editScheduledPayment" = "Edit scheduled payment";
+"noScheduledPayments" = "No scheduled payments";
+"scheduledPaymentNote" = "You are notified when a payment is due, it is sent once you confirm it with your spending passphrase.";
+"labelRequired" = "A label is required";
+"schedule" = "Schedule";
+"daily" = "Daily";
+"weekly" = "Weekly";
+"monthly" = "Monthly";
+"cron" = "Cron";
+"cronExpression" = "Cron expression: minute hour day month weekday";
+"invalidCronExpression" = "Invalid cron expression";
+"firstPayment" = "First payment (YYYY-MM-DD HH:MM)";
+"nextPayment" = "Next payment: %s";
+"paymentDue" = "Due since %s";
+"missedPayments" = "%d payments missed while the app was closed, the amount is paid once";
+"payNow" = "Pay now";
+"skip" = "Skip";
+"scheduledPaymentDue" = "Scheduled payment %s is due";
+"scheduledPaymentMissed" = "Scheduled payment %s: %d payments were missed";
+"scheduledPaymentPaid" = "Scheduled payment %s paid";
+"deleteScheduledPayment" = "Delete scheduled payment";
+"deleteScheduledPaymentConfirm" = "Delete the scheduled payment %s?";
+"skipScheduledPayment" = "Skip payment";
+"skipScheduledPaymentConfirm" = "Skip the payments of %s due now? The next payment is not affected.";
+"payFrom" = "Pay from";
+"noExchangeRate" = "Enable currency conversion in the settings to pay in fiat";
+"invalidDateTime" = "Invalid date, use the YYYY-MM-DD HH:MM format";
+"sendAmountTo" = "Send %s to %s";
+"scheduleCronDesc" = "Cron: %s";
+"insufficientFundSendMax" = "Insufficient funds, use Max to send the whole balance less the fee";
+"pendingBroadcast" = "Pending broadcast";
+"txQueued" = "No peers are connected, the transaction will be broadcast once they are";
+"queuedTxsBroadcast" = "%d queued transactions broadcast";
+"cancelQueuedTx" = "Cancel transaction";
+"queuedTxInfo" = "The transaction %s is waiting for peers to be broadcast, %d attempts failed. Canceling it releases the coins it spends.";
+"lastBroadcastError" = "Last error: %s";
+"queuedTxCanceled" = "Transaction canceled, its coins are released";
+"addresses" = "Addresses";
+"viewAddresses" = "View addresses";
+"used" = "Used";
+"unused" = "Unused";
+"noLabel" = "No label";
+"editAddressLabel" = "Edit address label";
+"addressLabelSaved" = "Address label saved";
+"addressReceivedTxs" = "Received %s in %d transactions";
+"addressesSummary" = "%d addresses generated, %d used";
+"noAddresses" = "No addresses generated yet";
+"addressGapWarning" = "%d unused addresses in a row. Addresses past the gap limit of %d are reused, and payments to them may be missed when the wallet is restored from its seed.";
+"paymentRequests" = "Payment requests";
+"newPaymentRequest" = "New payment request";
+"memo" = "Memo";
+"amountOptional" = "Amount (optional)";
+"expiresIn" = "Expires in";
+"never" = "Never";
+"oneHour" = "1 hour";
+"oneDay" = "1 day";
+"oneWeek" = "1 week";
+"unpaid" = "Unpaid";
+"partiallyPaid" = "Partially paid";
+"paid" = "Paid";
+"anyAmount" = "Any amount";
+"noPaymentRequests" = "No payment requests";
+"paymentRequestCreated" = "Payment request created";
+"paymentRequestReceived" = "Payment request %s received %s";
+"receivedOf" = "Received %s of %s";
+"expiresAt" = "Expires %s";
+"createdAt" = "Created %s";
+"exportQRCode" = "Export QR code";
+"qrCodeExported" = "QR code saved to %s";
+"deletePaymentRequest" = "Delete payment request";
+"deletePaymentRequestConfirm" = "Delete the payment request %s? Payments to its address are still received by the wallet.";
+"noUnusedAddress" = "No unused address is available, wait for the pending payment requests to be paid or delete some.";
+"searchTxsHint" = "Search by hash, address or label";
+"filters" = "Filters";
+"minAmountDCR" = "Min amount (DCR)";
+"maxAmountDCR" = "Max amount (DCR)";
+"indexingTxs" = "Indexing the transactions, the results are listed once it is done";
+"noMatchingTxs" = "No transactions match the search";
+"invalidAmountRange" = "The min amount is above the max amount";
+"note" = "Note";
+"editTxLabel" = "Edit label and note";
+"txLabelSaved" = "Label saved";
+"txLabelHint" = "Why was this payment made?";
+"edit" = "Edit";
+"amountUnverified" = "Amount not verified";
+"paysThisWallet" = "Pays this wallet";
+"feeUnverified" = "Some input amounts come from the transaction file and could not be checked by this wallet, so the fee is not verified.";
+"queuedTxRejected" = "The queued transaction %s was rejected and removed, its coins are released: %s";
 `
@@ -162,7 +162,12 @@ const ES = `
 "english" = "Inglés";
 "french" = "Francés";
 "spanish" = "Español";
-"usdBittrex" = "USD (Bittrex)";
+"usdDcrdata" = "USD (dcrdata)";
+"usdCoinGecko" = "USD (CoinGecko)";
+"eurCoinGecko" = "EUR (CoinGecko)";
+"gbpCoinGecko" = "GBP (CoinGecko)";
+"ngnCoinGecko" = "NGN (CoinGecko)";
+"usdtBinance" = "USDT (Binance)";
 "none" = "Ninguno";
 "proposals" = "Propuestas";
 "governance" = "Gobernancia";
@@ -477,4 +482,11 @@ syncCompTime" = "Est. Sync completion time"
 "account" = "Cuenta"
 "selectDexServerToOpen" = "Select the Dex server you would like to open."
 "addDexServer" = "Add dex server"
+"errInsufficientFunds" = "No hay suficientes fondos disponibles para esta transacción.";
+"errNotSynced" = "Las billeteras deben sincronizarse primero.";
+"errNoPeers" = "No hay conexión con ningún par de la red Decred.";
+"errVSP" = "El proveedor de servicios de votación no pudo completar la solicitud.";
+"errPoliteia" = "Politeia no pudo completar la solicitud.";
+"errDEX" = "El DEX no pudo completar la solicitud.";
+"errDetails" = "%s Detalles: %s";
 `
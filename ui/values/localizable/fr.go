@@ -454,4 +454,11 @@ const FR = `
 "account" = "Compte"
 "selectDexServerToOpen" = "Select the Dex server you would like to open."
 "addDexServer" = "Add dex server"
+"errInsufficientFunds" = "Les fonds disponibles sont insuffisants pour cette transaction.";
+"errNotSynced" = "Les portefeuilles doivent d'abord être synchronisés.";
+"errNoPeers" = "Aucun pair du réseau Decred n'est connecté.";
+"errVSP" = "Le fournisseur de services de vote n'a pas pu traiter la demande.";
+"errPoliteia" = "Politeia n'a pas pu traiter la demande.";
+"errDEX" = "Le DEX n'a pas pu traiter la demande.";
+"errDetails" = "%s Détails : %s";
 `
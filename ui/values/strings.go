@@ -275,7 +275,12 @@ const (
 	StrEnglish                         = "english"
 	StrFrench                          = "french"
 	StrSpanish                         = "spanish"
-	StrUsdBittrex                      = "usdBittrex"
+	StrUsdDcrdata                      = "usdDcrdata"
+	StrUsdCoinGecko                    = "usdCoinGecko"
+	StrEurCoinGecko                    = "eurCoinGecko"
+	StrGbpCoinGecko                    = "gbpCoinGecko"
+	StrNgnCoinGecko                    = "ngnCoinGecko"
+	StrUsdtBinance                     = "usdtBinance"
 	StrNone                            = "none"
 	StrProposal                        = "proposals"
 	StrDex                             = "dex"
@@ -593,4 +598,219 @@ const (
 	StrAccount                         = "account"
 	StrSelectDexServerToOpen           = "selectDexServerToOpen"
 	StrAddDexServer                    = "addDexServer"
+	StrValueWhenMined                  = "valueWhenMined"
+	StrImportPriceHistory              = "importPriceHistory"
+	StrPriceHistoryCSVPath             = "priceHistoryCSVPath"
+	StrPriceHistoryImported            = "priceHistoryImported"
+	StrExport                          = "export"
+	StrExportTransactions              = "exportTransactions"
+	StrAllWallets                      = "allWallets"
+	StrFromDate                        = "fromDate"
+	StrToDate                          = "toDate"
+	StrIncludeFiatValue                = "includeFiatValue"
+	StrFilePath                        = "filePath"
+	StrInvalidDate                     = "invalidDate"
+	StrTxExported                      = "txExported"
+	StrExportFormat                    = "exportFormat"
+	StrViewOnExplorer                  = "viewOnExplorer"
+	StrViewBlock                       = "viewBlock"
+	StrBlockExplorer                   = "blockExplorer"
+	StrTxTemplate                      = "txTemplate"
+	StrAddressTemplate                 = "addressTemplate"
+	StrBlockTemplate                   = "blockTemplate"
+	StrTicketTemplate                  = "ticketTemplate"
+	StrResetToDefaults                 = "resetToDefaults"
+	StrExplorerTemplatesInfo           = "explorerTemplatesInfo"
+	StrExplorerTemplatesSaved          = "explorerTemplatesSaved"
+	StrErrInsufficientFunds            = "errInsufficientFunds"
+	StrErrNotSynced                    = "errNotSynced"
+	StrErrNoPeers                      = "errNoPeers"
+	StrErrVSP                          = "errVSP"
+	StrErrPoliteia                     = "errPoliteia"
+	StrErrDEX                          = "errDEX"
+	StrErrDetails                      = "errDetails"
+	StrAddRecipient                    = "addRecipient"
+	StrImportRecipients                = "importRecipients"
+	StrRecipientsCSVPath               = "recipientsCSVPath"
+	StrRecipientsImported              = "recipientsImported"
+	StrRecipientNumber                 = "recipientNumber"
+	StrTotalFeeRecipients              = "totalFeeRecipients"
+	StrInvalidAmount                   = "invalidAmount"
+	StrRequestedAmount                 = "requestedAmount"
+	StrCopyPaymentURI                  = "copyPaymentURI"
+	StrInvalidPaymentURI               = "invalidPaymentURI"
+	StrFeeRateLow                      = "feeRateLow"
+	StrFeeRateNormal                   = "feeRateNormal"
+	StrFeeRateHigh                     = "feeRateHigh"
+	StrFeeRateCustom                   = "feeRateCustom"
+	StrCustomFeeRate                   = "customFeeRate"
+	StrInvalidFeeRate                  = "invalidFeeRate"
+	StrConfirmationTime                = "confirmationTime"
+	StrFeeRateAtomsKB                  = "feeRateAtomsKB"
+	StrCoinControl                     = "coinControl"
+	StrCoinSelection                   = "coinSelection"
+	StrCoinSelectionRandom             = "coinSelectionRandom"
+	StrLargestFirst                    = "largestFirst"
+	StrSmallestFirst                   = "smallestFirst"
+	StrCoinSelectionPrivacy            = "coinSelectionPrivacy"
+	StrMinimizeChange                  = "minimizeChange"
+	StrNewestFirst                     = "newestFirst"
+	StrOldestFirst                     = "oldestFirst"
+	StrSearchCoins                     = "searchCoins"
+	StrHideFrozen                      = "hideFrozen"
+	StrFrozen                          = "frozen"
+	StrLabel                           = "label"
+	StrCoinLabel                       = "coinLabel"
+	StrDateUTC                         = "dateUTC"
+	StrConfirmations                   = "confirmations"
+	StrSelected                        = "selected"
+	StrAfterFee                        = "afterFee"
+	StrCoinsSelected                   = "coinsSelected"
+	StrUseSelectedCoins                = "useSelectedCoins"
+	StrConsolidateCoins                = "consolidateCoins"
+	StrConsolidationThreshold          = "consolidationThreshold"
+	StrConsolidateInto                 = "consolidateInto"
+	StrSameAccount                     = "sameAccount"
+	StrOtherAccount                    = "otherAccount"
+	StrFindCoins                       = "findCoins"
+	StrCoinsToConsolidate              = "coinsToConsolidate"
+	StrDustCoins                       = "dustCoins"
+	StrConsolidatedAmount              = "consolidatedAmount"
+	StrConsolidationFee                = "consolidationFee"
+	StrFutureFeesSaved                 = "futureFeesSaved"
+	StrNetSavings                      = "netSavings"
+	StrConsolidationNotWorth           = "consolidationNotWorth"
+	StrNoCoinsToConsolidate            = "noCoinsToConsolidate"
+	StrConsolidationLimit              = "consolidationLimit"
+	StrConsolidate                     = "consolidate"
+	StrCoinsConsolidated               = "coinsConsolidated"
+	StrBack                            = "back"
+	StrUnsigned                        = "unsigned"
+	StrSigned                          = "signed"
+	StrTxInputs                        = "txInputs"
+	StrTxOutputs                       = "txOutputs"
+	StrSize                            = "size"
+	StrExportUnsignedTx                = "exportUnsignedTx"
+	StrExportUnsignedTxNote            = "exportUnsignedTxNote"
+	StrUnsignedTxExported              = "unsignedTxExported"
+	StrSignTransaction                 = "signTransaction"
+	StrBroadcastTransaction            = "broadcastTransaction"
+	StrSignTxNote                      = "signTxNote"
+	StrBroadcastTxNote                 = "broadcastTxNote"
+	StrOpen                            = "open"
+	StrSignedTxPath                    = "signedTxPath"
+	StrSign                            = "sign"
+	StrBroadcast                       = "broadcast"
+	StrTxSignedSaved                   = "txSignedSaved"
+	StrTxBroadcast                     = "txBroadcast"
+	StrTxAlreadySigned                 = "txAlreadySigned"
+	StrTxNotSigned                     = "txNotSigned"
+	StrAddressBook                     = "addressBook"
+	StrContactName                     = "contactName"
+	StrNotes                           = "notes"
+	StrContactNameRequired             = "contactNameRequired"
+	StrContactExists                   = "contactExists"
+	StrAddContact                      = "addContact"
+	StrEditContact                     = "editContact"
+	StrOwnWalletAddress                = "ownWalletAddress"
+	StrSearchContacts                  = "searchContacts"
+	StrDeleteContact                   = "deleteContact"
+	StrDeleteContactConfirm            = "deleteContactConfirm"
+	StrContactsImported                = "contactsImported"
+	StrImportAddressBook               = "importAddressBook"
+	StrExportAddressBook               = "exportAddressBook"
+	StrAddressBookExported             = "addressBookExported"
+	StrNoContacts                      = "noContacts"
+	StrManageAddressBook               = "manageAddressBook"
+	StrContactDescription              = "contactDescription"
+	StrScheduledPayments               = "scheduledPayments"
+	StrAddScheduledPayment             = "addScheduledPayment"
+	StrEditScheduledPayment            = "editScheduledPayment"
+	StrNoScheduledPayments             = "noScheduledPayments"
+	StrScheduledPaymentNote            = "scheduledPaymentNote"
+	StrLabelRequired                   = "labelRequired"
+	StrSchedule                        = "schedule"
+	StrDaily                           = "daily"
+	StrWeekly                          = "weekly"
+	StrMonthly                         = "monthly"
+	StrCron                            = "cron"
+	StrCronExpression                  = "cronExpression"
+	StrInvalidCronExpression           = "invalidCronExpression"
+	StrFirstPayment                    = "firstPayment"
+	StrNextPayment                     = "nextPayment"
+	StrPaymentDue                      = "paymentDue"
+	StrMissedPayments                  = "missedPayments"
+	StrPayNow                          = "payNow"
+	StrSkip                            = "skip"
+	StrScheduledPaymentDue             = "scheduledPaymentDue"
+	StrScheduledPaymentMissed          = "scheduledPaymentMissed"
+	StrScheduledPaymentPaid            = "scheduledPaymentPaid"
+	StrDeleteScheduledPayment          = "deleteScheduledPayment"
+	StrDeleteScheduledPaymentConfirm   = "deleteScheduledPaymentConfirm"
+	StrSkipScheduledPayment            = "skipScheduledPayment"
+	StrSkipScheduledPaymentConfirm     = "skipScheduledPaymentConfirm"
+	StrPayFrom                         = "payFrom"
+	StrNoExchangeRate                  = "noExchangeRate"
+	StrInvalidDateTime                 = "invalidDateTime"
+	StrSendAmountTo                    = "sendAmountTo"
+	StrScheduleCronDesc                = "scheduleCronDesc"
+	StrInsufficientFundSendMax         = "insufficientFundSendMax"
+	StrPendingBroadcast                = "pendingBroadcast"
+	StrTxQueued                        = "txQueued"
+	StrQueuedTxsBroadcast              = "queuedTxsBroadcast"
+	StrCancelQueuedTx                  = "cancelQueuedTx"
+	StrQueuedTxInfo                    = "queuedTxInfo"
+	StrLastBroadcastError              = "lastBroadcastError"
+	StrQueuedTxCanceled                = "queuedTxCanceled"
+	StrAddresses                       = "addresses"
+	StrViewAddresses                   = "viewAddresses"
+	StrUsed                            = "used"
+	StrUnused                          = "unused"
+	StrNoLabel                         = "noLabel"
+	StrEditAddressLabel                = "editAddressLabel"
+	StrAddressLabelSaved               = "addressLabelSaved"
+	StrAddressReceivedTxs              = "addressReceivedTxs"
+	StrAddressesSummary                = "addressesSummary"
+	StrNoAddresses                     = "noAddresses"
+	StrAddressGapWarning               = "addressGapWarning"
+	StrPaymentRequests                 = "paymentRequests"
+	StrNewPaymentRequest               = "newPaymentRequest"
+	StrMemo                            = "memo"
+	StrAmountOptional                  = "amountOptional"
+	StrExpiresIn                       = "expiresIn"
+	StrNever                           = "never"
+	StrOneHour                         = "oneHour"
+	StrOneDay                          = "oneDay"
+	StrOneWeek                         = "oneWeek"
+	StrUnpaid                          = "unpaid"
+	StrPartiallyPaid                   = "partiallyPaid"
+	StrPaid                            = "paid"
+	StrAnyAmount                       = "anyAmount"
+	StrNoPaymentRequests               = "noPaymentRequests"
+	StrPaymentRequestCreated           = "paymentRequestCreated"
+	StrPaymentRequestReceived          = "paymentRequestReceived"
+	StrReceivedOf                      = "receivedOf"
+	StrExpiresAt                       = "expiresAt"
+	StrCreatedAt                       = "createdAt"
+	StrExportQRCode                    = "exportQRCode"
+	StrQRCodeExported                  = "qrCodeExported"
+	StrDeletePaymentRequest            = "deletePaymentRequest"
+	StrDeletePaymentRequestConfirm     = "deletePaymentRequestConfirm"
+	StrNoUnusedAddress                 = "noUnusedAddress"
+	StrSearchTxsHint                   = "searchTxsHint"
+	StrFilters                         = "filters"
+	StrMinAmountDCR                    = "minAmountDCR"
+	StrMaxAmountDCR                    = "maxAmountDCR"
+	StrIndexingTxs                     = "indexingTxs"
+	StrNoMatchingTxs                   = "noMatchingTxs"
+	StrInvalidAmountRange              = "invalidAmountRange"
+	StrNote                            = "note"
+	StrEditTxLabel                     = "editTxLabel"
+	StrTxLabelSaved                    = "txLabelSaved"
+	StrTxLabelHint                     = "txLabelHint"
+	StrEdit                            = "edit"
+	StrAmountUnverified                = "amountUnverified"
+	StrPaysThisWallet                  = "paysThisWallet"
+	StrFeeUnverified                   = "feeUnverified"
+	StrQueuedTxRejected                = "queuedTxRejected"
 )
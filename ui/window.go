@@ -78,6 +78,12 @@ func CreateWindow(wal *wallet.Wallet) (*Window, error) {
 	return win, nil
 }
 
+// OpenPaymentURI opens the payment URI on the send page once the wallets are
+// synced.
+func (win *Window) OpenPaymentURI(uri string) {
+	win.load.PaymentURI = uri
+}
+
 func (win *Window) NewLoad() (*load.Load, error) {
 	th := decredmaterial.NewTheme(assets.FontCollection(), assets.DecredIcons, false)
 	if th == nil {
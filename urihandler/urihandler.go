@@ -0,0 +1,25 @@
+// Package urihandler registers the app as the handler of the decred: payment
+// URIs, so that the OS launches it with the URI as argument when a payment link
+// is opened.
+package urihandler
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// scheme is the scheme of the payment URIs.
+const scheme = "decred"
+
+// Register registers the running executable as the handler of the payment
+// URIs of the current user.
+func Register() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	return register(exe)
+}
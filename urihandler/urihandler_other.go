@@ -0,0 +1,15 @@
+//go:build !windows && !linux && !freebsd
+// +build !windows,!linux,!freebsd
+
+package urihandler
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// register is not supported on the other systems, where the schemes handled by
+// an app are declared in its bundle, e.g. in the Info.plist of a macOS app.
+func register(exe string) error {
+	return fmt.Errorf("registering the %s: URI handler is not supported on %s", scheme, runtime.GOOS)
+}
@@ -0,0 +1,49 @@
+//go:build linux || freebsd
+// +build linux freebsd
+
+package urihandler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const desktopFile = "godcr-uri-handler.desktop"
+
+// register installs a desktop entry of the user handling the scheme and makes
+// it the default handler with xdg-mime.
+func register(exe string) error {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+
+	dir := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	entry := fmt.Sprintf("[Desktop Entry]\n"+
+		"Type=Application\n"+
+		"Name=godcr\n"+
+		"Exec=\"%s\" %%u\n"+
+		"Terminal=false\n"+
+		"NoDisplay=true\n"+
+		"MimeType=x-scheme-handler/%s;\n", exe, scheme)
+	if err := ioutil.WriteFile(filepath.Join(dir, desktopFile), []byte(entry), 0600); err != nil {
+		return err
+	}
+
+	output, err := exec.Command("xdg-mime", "default", desktopFile, "x-scheme-handler/"+scheme).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("xdg-mime: %v: %s", err, output)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package urihandler
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// register declares the URL protocol in the classes of the current user, which
+// does not require administrator rights.
+func register(exe string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+scheme, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer key.Close()
+
+	if err = key.SetStringValue("", "URL:Decred payment"); err != nil {
+		return err
+	}
+	if err = key.SetStringValue("URL Protocol", ""); err != nil {
+		return err
+	}
+
+	command, _, err := registry.CreateKey(key, `shell\open\command`, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer command.Close()
+
+	return command.SetStringValue("", fmt.Sprintf(`"%s" "%%1"`, exe))
+}
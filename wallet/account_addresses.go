@@ -0,0 +1,152 @@
+package wallet
+
+import (
+	"context"
+	"strings"
+
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// AddressLabelsConfigKey is the user config key of the labels of the
+// addresses of the wallets.
+const AddressLabelsConfigKey = "address_labels"
+
+// externalBranch is the BIP0044 branch of the receiving addresses.
+const externalBranch = 0
+
+// AccountAddress is a receiving address of an account, with what it received.
+type AccountAddress struct {
+	Address string
+	Label   string
+	// Index is the derivation index of the address in the external branch of
+	// the account.
+	Index uint32
+	// Received is the total amount received by the address, in atoms, in
+	// TxCount transactions.
+	Received int64
+	TxCount  int
+}
+
+// Used returns true if the address received a transaction.
+func (a *AccountAddress) Used() bool {
+	return a.TxCount > 0
+}
+
+// addressUsage sets the amount received by addresses in txs and the number of
+// transactions they received.
+func addressUsage(addresses []AccountAddress, txs []dcrlibwallet.Transaction) {
+	byAddress := make(map[string]*AccountAddress, len(addresses))
+	for i := range addresses {
+		byAddress[addresses[i].Address] = &addresses[i]
+	}
+
+	for _, tx := range txs {
+		seen := make(map[string]bool)
+		for _, out := range tx.Outputs {
+			a, ok := byAddress[out.Address]
+			if !ok {
+				continue
+			}
+			a.Received += out.Amount
+			if !seen[out.Address] {
+				seen[out.Address] = true
+				a.TxCount++
+			}
+		}
+	}
+}
+
+// UnusedAddressGap returns the number of unused addresses following the last
+// used address of addresses, ordered by index. Payments to the addresses past
+// the gap limit of the wallet may be missed when it is restored from its
+// seed.
+func UnusedAddressGap(addresses []AccountAddress) int {
+	gap := 0
+	for i := len(addresses) - 1; i >= 0 && !addresses[i].Used(); i-- {
+		gap++
+	}
+	return gap
+}
+
+// AccountAddresses returns the receiving addresses of account of w generated
+// so far, ordered by index, with their labels and what they received.
+func (wal *Wallet) AccountAddresses(w *dcrlibwallet.Wallet, account int32) ([]AccountAddress, error) {
+	ctx := context.Background()
+	xpub, err := w.Internal().AccountXpub(ctx, uint32(account))
+	if err != nil {
+		return nil, err
+	}
+	branchXpub, err := xpub.Child(externalBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	// The current address follows the addresses returned, unless
+	// addresses were returned past the gap limit, after which the wallet
+	// returns the unused addresses again.
+	current, _, err := w.Internal().BIP0044BranchNextIndexes(ctx, uint32(account))
+	if err != nil {
+		return nil, err
+	}
+	count := current + 1
+	accounts, err := w.Internal().Accounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accounts.Accounts {
+		if a.AccountNumber == uint32(account) && a.LastReturnedExternalIndex != ^uint32(0) &&
+			a.LastReturnedExternalIndex+1 > count {
+			count = a.LastReturnedExternalIndex + 1
+		}
+	}
+
+	labels := wal.AddressLabels()
+	params := w.Internal().ChainParams()
+	addresses := make([]AccountAddress, 0, count)
+	for i := uint32(0); i < count; i++ {
+		child, err := branchXpub.Child(i)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(stdaddr.Hash160(child.SerializedPubKey()), params)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, AccountAddress{
+			Address: addr.String(),
+			Label:   labels[addr.String()],
+			Index:   i,
+		})
+	}
+
+	txs, err := w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterAll, true)
+	if err != nil {
+		return nil, err
+	}
+	addressUsage(addresses, txs)
+	return addresses, nil
+}
+
+// AddressLabels returns the labels of the addresses, by address.
+func (wal *Wallet) AddressLabels() map[string]string {
+	labels := make(map[string]string)
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(AddressLabelsConfigKey, &labels); err != nil || labels == nil {
+			return make(map[string]string)
+		}
+	}
+	return labels
+}
+
+// SetAddressLabel sets the label of address, which is removed if label is
+// empty.
+func (wal *Wallet) SetAddressLabel(address, label string) {
+	labels := wal.AddressLabels()
+	if label = strings.TrimSpace(label); label == "" {
+		delete(labels, address)
+	} else {
+		labels[address] = label
+	}
+	wal.multi.SaveUserConfigValue(AddressLabelsConfigKey, labels)
+}
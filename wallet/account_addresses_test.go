@@ -0,0 +1,51 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestAddressUsage(t *testing.T) {
+	addresses := []AccountAddress{{Address: "a", Index: 0}, {Address: "b", Index: 1}, {Address: "c", Index: 2}}
+	txs := []dcrlibwallet.Transaction{
+		{Outputs: []*dcrlibwallet.TxOutput{{Address: "a", Amount: 100}, {Address: "x", Amount: 50}}},
+		{Outputs: []*dcrlibwallet.TxOutput{{Address: "a", Amount: 20}, {Address: "a", Amount: 5}}},
+		{Outputs: []*dcrlibwallet.TxOutput{{Address: "c", Amount: 7}}},
+	}
+	addressUsage(addresses, txs)
+
+	expected := []struct {
+		received int64
+		txCount  int
+	}{{125, 2}, {0, 0}, {7, 1}}
+	for i, e := range expected {
+		a := addresses[i]
+		if a.Received != e.received || a.TxCount != e.txCount || a.Used() != (e.txCount > 0) {
+			t.Errorf("address %s: received %d in %d txs, expected %d in %d", a.Address, a.Received, a.TxCount, e.received, e.txCount)
+		}
+	}
+}
+
+func TestUnusedAddressGap(t *testing.T) {
+	tests := []struct {
+		used []bool
+		gap  int
+	}{
+		{nil, 0},
+		{[]bool{false, false}, 2},
+		{[]bool{true, false, true}, 0},
+		{[]bool{true, true, false, false, false}, 3},
+	}
+	for _, test := range tests {
+		addresses := make([]AccountAddress, len(test.used))
+		for i, used := range test.used {
+			if used {
+				addresses[i].TxCount = 1
+			}
+		}
+		if gap := UnusedAddressGap(addresses); gap != test.gap {
+			t.Errorf("%v: gap %d, expected %d", test.used, gap, test.gap)
+		}
+	}
+}
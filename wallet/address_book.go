@@ -0,0 +1,184 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// AddressBookConfigKey is the user config key of the address book.
+const AddressBookConfigKey = "address_book"
+
+var (
+	// ErrContactName is returned when a contact is saved without a name.
+	ErrContactName = errors.New("the contact has no name")
+
+	// ErrContactExists is returned when a contact is saved with the address
+	// of another contact.
+	ErrContactExists = errors.New("a contact with this address exists")
+)
+
+// Contact is an entry of the address book.
+type Contact struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	Notes   string `json:"notes,omitempty"`
+	// Network is the network the address is valid on, the user config
+	// being kept per network.
+	Network string `json:"network"`
+}
+
+// ValidateContact returns an error if c has no name or if its address is not
+// valid on network according to validAddress.
+func ValidateContact(c Contact, network string, validAddress func(string) bool) error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrContactName
+	}
+	if c.Network != network {
+		return fmt.Errorf("contact %q is for the %s network", c.Name, c.Network)
+	}
+	if !validAddress(c.Address) {
+		return fmt.Errorf("contact %q: invalid address %q", c.Name, c.Address)
+	}
+	return nil
+}
+
+// ReadAddressBookJSON reads the contacts written by WriteAddressBookJSON from
+// r. The contacts without network are of network, all of them are validated.
+func ReadAddressBookJSON(r io.Reader, network string, validAddress func(string) bool) ([]Contact, error) {
+	var contacts []Contact
+	if err := json.NewDecoder(r).Decode(&contacts); err != nil {
+		return nil, fmt.Errorf("invalid address book: %v", err)
+	}
+
+	for i := range contacts {
+		c := &contacts[i]
+		c.Name, c.Address, c.Notes = strings.TrimSpace(c.Name), strings.TrimSpace(c.Address), strings.TrimSpace(c.Notes)
+		if c.Network == "" {
+			c.Network = network
+		}
+		if err := ValidateContact(*c, network, validAddress); err != nil {
+			return nil, err
+		}
+	}
+	return contacts, nil
+}
+
+// WriteAddressBookJSON writes contacts to w as a JSON array.
+func WriteAddressBookJSON(w io.Writer, contacts []Contact) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(contacts)
+}
+
+// MergeContacts returns the contacts of book with those of imported added. The
+// imported contacts replace the contacts of book with the same address. The
+// result is sorted by name.
+func MergeContacts(book, imported []Contact) []Contact {
+	byAddress := make(map[string]int, len(book))
+	merged := make([]Contact, len(book), len(book)+len(imported))
+	copy(merged, book)
+	for i, c := range merged {
+		byAddress[c.Address] = i
+	}
+
+	for _, c := range imported {
+		if i, ok := byAddress[c.Address]; ok {
+			merged[i] = c
+			continue
+		}
+		byAddress[c.Address] = len(merged)
+		merged = append(merged, c)
+	}
+
+	sortContacts(merged)
+	return merged
+}
+
+func sortContacts(contacts []Contact) {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+}
+
+// AddressBook returns the contacts of the address book, sorted by name.
+func (wal *Wallet) AddressBook() []Contact {
+	var contacts []Contact
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(AddressBookConfigKey, &contacts); err != nil {
+			return nil
+		}
+	}
+	sortContacts(contacts)
+	return contacts
+}
+
+// Contact returns the contact with address.
+func (wal *Wallet) Contact(address string) (Contact, bool) {
+	for _, c := range wal.AddressBook() {
+		if c.Address == address {
+			return c, true
+		}
+	}
+	return Contact{}, false
+}
+
+// SaveContact validates c and saves it in the address book, in place of the
+// contact with address if it is not empty.
+func (wal *Wallet) SaveContact(address string, c Contact) error {
+	c.Name, c.Address, c.Notes = strings.TrimSpace(c.Name), strings.TrimSpace(c.Address), strings.TrimSpace(c.Notes)
+	c.Network = wal.Net
+	if err := ValidateContact(c, wal.Net, wal.multi.IsAddressValid); err != nil {
+		return err
+	}
+
+	contacts := wal.AddressBook()
+	saved := contacts[:0]
+	for _, existing := range contacts {
+		if existing.Address == address && address != "" {
+			continue
+		}
+		if existing.Address == c.Address {
+			return ErrContactExists
+		}
+		saved = append(saved, existing)
+	}
+	wal.multi.SaveUserConfigValue(AddressBookConfigKey, append(saved, c))
+	return nil
+}
+
+// DeleteContact removes the contact with address from the address book.
+func (wal *Wallet) DeleteContact(address string) {
+	contacts := wal.AddressBook()
+	kept := contacts[:0]
+	for _, c := range contacts {
+		if c.Address != address {
+			kept = append(kept, c)
+		}
+	}
+	wal.multi.SaveUserConfigValue(AddressBookConfigKey, kept)
+}
+
+// ImportAddressBook adds the contacts read from r to the address book,
+// replacing the contacts with the same address. The number of contacts
+// imported is returned.
+func (wal *Wallet) ImportAddressBook(r io.Reader) (int, error) {
+	imported, err := ReadAddressBookJSON(r, wal.Net, wal.multi.IsAddressValid)
+	if err != nil {
+		return 0, err
+	}
+	wal.multi.SaveUserConfigValue(AddressBookConfigKey, MergeContacts(wal.AddressBook(), imported))
+	return len(imported), nil
+}
+
+// ExportAddressBook writes the address book to w.
+func (wal *Wallet) ExportAddressBook(w io.Writer) error {
+	contacts := wal.AddressBook()
+	if contacts == nil {
+		contacts = []Contact{}
+	}
+	return WriteAddressBookJSON(w, contacts)
+}
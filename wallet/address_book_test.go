@@ -0,0 +1,73 @@
+package wallet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func validTestAddress(address string) bool {
+	return strings.HasPrefix(address, "Ts")
+}
+
+func TestAddressBookJSON(t *testing.T) {
+	contacts := []Contact{
+		{Name: "Vendor", Address: "TsVendor", Notes: "monthly invoice", Network: "testnet3"},
+		{Name: "Hosting", Address: "TsHosting", Network: "testnet3"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteAddressBookJSON(&buf, contacts); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadAddressBookJSON(&buf, "testnet3", validTestAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != 2 || read[0] != contacts[0] || read[1] != contacts[1] {
+		t.Errorf("unexpected contacts %+v", read)
+	}
+
+	read, err = ReadAddressBookJSON(strings.NewReader(`[{"name":" Shop ","address":"TsShop"}]`), "testnet3", validTestAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read[0].Name != "Shop" || read[0].Network != "testnet3" {
+		t.Errorf("unexpected contact %+v", read[0])
+	}
+
+	invalid := []string{
+		`{"name":"Shop"}`,
+		`[{"name":"","address":"TsShop"}]`,
+		`[{"name":"Shop","address":"DsShop"}]`,
+		`[{"name":"Shop","address":"TsShop","network":"mainnet"}]`,
+	}
+	for _, s := range invalid {
+		if _, err := ReadAddressBookJSON(strings.NewReader(s), "testnet3", validTestAddress); err == nil {
+			t.Errorf("expected an error reading %s", s)
+		}
+	}
+}
+
+func TestMergeContacts(t *testing.T) {
+	book := []Contact{
+		{Name: "vendor", Address: "TsVendor"},
+		{Name: "Hosting", Address: "TsHosting"},
+	}
+	imported := []Contact{
+		{Name: "Vendor Inc", Address: "TsVendor", Notes: "new name"},
+		{Name: "Alice", Address: "TsAlice"},
+	}
+
+	merged := MergeContacts(book, imported)
+	names := make([]string, len(merged))
+	for i, c := range merged {
+		names[i] = c.Name
+	}
+	if strings.Join(names, ",") != "Alice,Hosting,Vendor Inc" {
+		t.Errorf("unexpected merged contacts %v", names)
+	}
+	if book[0].Name != "vendor" {
+		t.Error("the address book was modified")
+	}
+}
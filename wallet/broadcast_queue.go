@@ -0,0 +1,346 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	walleterrors "decred.org/dcrwallet/v2/errors"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// BroadcastQueueConfigKey is the user config key of the transactions signed
+// while offline, waiting to be broadcast.
+const BroadcastQueueConfigKey = "broadcast_queue"
+
+var (
+	// errQueuedTxInvalid is returned when a queued transaction cannot be
+	// decoded.
+	errQueuedTxInvalid = errors.New("invalid queued transaction")
+
+	// errQueuedTxNoWallet is the broadcast error of the transactions of
+	// removed wallets.
+	errQueuedTxNoWallet = errors.New(dcrlibwallet.ErrWalletNotFound)
+)
+
+// QueuedTx is a signed transaction waiting for peers to be broadcast. Its
+// inputs are locked so that they are not spent by another transaction until
+// it is broadcast or canceled.
+type QueuedTx struct {
+	Hash     string `json:"hash"`
+	WalletID int    `json:"wallet_id"`
+	// Tx is the serialized signed transaction, hex encoded.
+	Tx        string    `json:"tx"`
+	Amount    int64     `json:"amount"`
+	Fee       int64     `json:"fee"`
+	Direction int32     `json:"direction"`
+	QueuedAt  time.Time `json:"queued_at"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// MsgTx returns the transaction of q.
+func (q *QueuedTx) MsgTx() (*wire.MsgTx, error) {
+	b, err := hex.DecodeString(q.Tx)
+	if err != nil {
+		return nil, err
+	}
+	msgTx := wire.NewMsgTx()
+	if err = msgTx.Deserialize(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	return msgTx, nil
+}
+
+// Transaction returns q as an unmined regular transaction, to be listed with
+// the transactions of its wallet.
+func (q *QueuedTx) Transaction() dcrlibwallet.Transaction {
+	return dcrlibwallet.Transaction{
+		WalletID:    q.WalletID,
+		Hash:        q.Hash,
+		Type:        dcrlibwallet.TxTypeRegular,
+		Hex:         q.Tx,
+		Timestamp:   q.QueuedAt.Unix(),
+		BlockHeight: -1,
+		Fee:         q.Fee,
+		Direction:   q.Direction,
+		Amount:      q.Amount,
+	}
+}
+
+// lockInputs locks or unlocks the outputs spent by q in w.
+func (q *QueuedTx) lockInputs(w *dcrlibwallet.Wallet, lock bool) error {
+	msgTx, err := q.MsgTx()
+	if err != nil {
+		return err
+	}
+	for _, in := range msgTx.TxIn {
+		if lock {
+			w.Internal().LockOutpoint(&in.PreviousOutPoint.Hash, in.PreviousOutPoint.Index)
+		} else {
+			w.Internal().UnlockOutpoint(&in.PreviousOutPoint.Hash, in.PreviousOutPoint.Index)
+		}
+	}
+	return nil
+}
+
+// Queue signs the transaction with privatePassphrase and returns it to be
+// broadcast later, once peers are connected. privatePassphrase is zeroed.
+func (tx *TxAuthor) Queue(privatePassphrase []byte) (*QueuedTx, error) {
+	defer func() {
+		for i := range privatePassphrase {
+			privatePassphrase[i] = 0
+		}
+	}()
+
+	unsignedTx, err := tx.unsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	// The output receiving the max amount is the change output.
+	sendsMax := tx.sendsMax()
+	var amount, outputs int64
+	for i, out := range unsignedTx.Tx.TxOut {
+		outputs += out.Value
+		if i != unsignedTx.ChangeIndex || sendsMax {
+			amount += out.Value
+		}
+	}
+	direction := dcrlibwallet.TxDirectionTransferred
+	for _, destination := range tx.destinations {
+		if !tx.wallet.HaveAddress(destination.Address) {
+			direction = dcrlibwallet.TxDirectionSent
+		}
+	}
+
+	msgTx, err := tx.sign(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = msgTx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	// The signed transaction can't be queued again.
+	tx.unsignedTx = nil
+
+	return &QueuedTx{
+		Hash:      msgTx.TxHash().String(),
+		WalletID:  tx.wallet.ID,
+		Tx:        hex.EncodeToString(buf.Bytes()),
+		Amount:    amount,
+		Fee:       int64(unsignedTx.TotalInput) - outputs,
+		Direction: direction,
+		QueuedAt:  time.Now(),
+	}, nil
+}
+
+// QueuedTxs returns the transactions waiting to be broadcast, the most
+// recent first.
+func (wal *Wallet) QueuedTxs() []QueuedTx {
+	var queue []QueuedTx
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(BroadcastQueueConfigKey, &queue); err != nil {
+			return nil
+		}
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].QueuedAt.After(queue[j].QueuedAt)
+	})
+	return queue
+}
+
+// QueuedTxsOf returns the transactions of the wallet with walletID waiting to
+// be broadcast.
+func (wal *Wallet) QueuedTxsOf(walletID int) []QueuedTx {
+	var queue []QueuedTx
+	for _, q := range wal.QueuedTxs() {
+		if q.WalletID == walletID {
+			queue = append(queue, q)
+		}
+	}
+	return queue
+}
+
+// QueueTx adds q to the transactions waiting to be broadcast and locks its
+// inputs.
+func (wal *Wallet) QueueTx(q *QueuedTx) error {
+	w := wal.multi.WalletWithID(q.WalletID)
+	if w == nil {
+		return errors.New(dcrlibwallet.ErrWalletNotFound)
+	}
+	if err := q.lockInputs(w, true); err != nil {
+		return err
+	}
+
+	wal.queueMtx.Lock()
+	defer wal.queueMtx.Unlock()
+	wal.multi.SaveUserConfigValue(BroadcastQueueConfigKey, append(wal.QueuedTxs(), *q))
+	return nil
+}
+
+// BroadcastOrQueue broadcasts tx signed with privatePassphrase, or queues it
+// to be broadcast by BroadcastQueuedTxs if no peer is connected. queued is
+// true if the transaction was queued. privatePassphrase is zeroed.
+func (wal *Wallet) BroadcastOrQueue(tx *TxAuthor, privatePassphrase []byte) (queued bool, err error) {
+	if wal.multi.IsConnectedToDecredNetwork() {
+		pass := make([]byte, len(privatePassphrase))
+		copy(pass, privatePassphrase)
+		_, err = tx.Broadcast(pass)
+		if ErrorCodeOf(err) != ErrCodeNoPeers {
+			for i := range privatePassphrase {
+				privatePassphrase[i] = 0
+			}
+			return false, err
+		}
+	}
+
+	q, err := tx.Queue(privatePassphrase)
+	if err != nil {
+		return false, err
+	}
+	return true, wal.QueueTx(q)
+}
+
+// LockQueuedInputs locks the inputs of the transactions waiting to be
+// broadcast, the locks of the wallets being lost when the app is closed.
+func (wal *Wallet) LockQueuedInputs() {
+	for _, q := range wal.QueuedTxs() {
+		if w := wal.multi.WalletWithID(q.WalletID); w != nil {
+			if err := q.lockInputs(w, true); err != nil {
+				log.Errorf("error locking the inputs of queued tx %s: %v", q.Hash, err)
+			}
+		}
+	}
+}
+
+// CancelQueuedTx removes the transaction with hash from the transactions
+// waiting to be broadcast and unlocks its inputs.
+func (wal *Wallet) CancelQueuedTx(hash string) error {
+	wal.queueMtx.Lock()
+	defer wal.queueMtx.Unlock()
+
+	queue := wal.QueuedTxs()
+	kept := queue[:0]
+	for _, q := range queue {
+		if q.Hash != hash {
+			kept = append(kept, q)
+			continue
+		}
+		if w := wal.multi.WalletWithID(q.WalletID); w != nil {
+			if err := q.lockInputs(w, false); err != nil {
+				return err
+			}
+		}
+	}
+	wal.multi.SaveUserConfigValue(BroadcastQueueConfigKey, kept)
+	return nil
+}
+
+// BroadcastQueuedTxs broadcasts the transactions waiting for peers, which are
+// removed from the queue once broadcast. The transactions rejected by the
+// wallet or the network are removed too and their inputs unlocked, the
+// others are retried on the next call. The transactions of removed wallets
+// are dropped. The transactions broadcast and rejected are returned.
+func (wal *Wallet) BroadcastQueuedTxs(ctx context.Context) (broadcast, rejected []QueuedTx, err error) {
+	wal.queueMtx.Lock()
+	queue := wal.QueuedTxs()
+	wal.queueMtx.Unlock()
+	if len(queue) == 0 || !wal.multi.IsConnectedToDecredNetwork() {
+		return nil, nil, nil
+	}
+
+	// The queue is not locked while the transactions are published, the
+	// transactions canceled meanwhile are not queued again.
+	results := make(map[string]error, len(queue))
+	for i := range queue {
+		if ctx.Err() != nil {
+			break
+		}
+		q := &queue[i]
+		if w := wal.multi.WalletWithID(q.WalletID); w != nil {
+			results[q.Hash] = publishQueuedTx(ctx, w, q)
+		} else {
+			results[q.Hash] = errQueuedTxNoWallet
+		}
+	}
+
+	wal.queueMtx.Lock()
+	defer wal.queueMtx.Unlock()
+	kept, broadcast, rejected := mergeBroadcastResults(wal.QueuedTxs(), results)
+	for _, q := range rejected {
+		log.Errorf("queued tx %s was rejected and removed from the queue: %s", q.Hash, q.LastError)
+		if w := wal.multi.WalletWithID(q.WalletID); w != nil {
+			if err := q.lockInputs(w, false); err != nil {
+				log.Errorf("error unlocking the inputs of queued tx %s: %v", q.Hash, err)
+			}
+		}
+	}
+	wal.multi.SaveUserConfigValue(BroadcastQueueConfigKey, kept)
+	return broadcast, rejected, nil
+}
+
+// mergeBroadcastResults applies the results of publishing the transactions of
+// queue, by hash, and returns the transactions still waiting and those
+// broadcast and rejected. The transactions without a result, queued while the
+// others were published, keep waiting.
+func mergeBroadcastResults(queue []QueuedTx, results map[string]error) (kept, broadcast, rejected []QueuedTx) {
+	kept = make([]QueuedTx, 0, len(queue))
+	for _, q := range queue {
+		err, published := results[q.Hash]
+		switch {
+		case !published:
+			kept = append(kept, q)
+		case err == nil:
+			broadcast = append(broadcast, q)
+		case err == errQueuedTxNoWallet:
+		default:
+			q.Attempts++
+			q.LastError = err.Error()
+			if broadcastRejected(err) {
+				rejected = append(rejected, q)
+			} else {
+				kept = append(kept, q)
+			}
+		}
+	}
+	return kept, broadcast, rejected
+}
+
+// broadcastRejected returns true if err is a rejection of a transaction,
+// which broadcasting it again does not fix, rather than a failure to reach
+// the network.
+func broadcastRejected(err error) bool {
+	if errors.Is(err, errQueuedTxInvalid) {
+		return true
+	}
+	for _, kind := range []walleterrors.Kind{walleterrors.Invalid, walleterrors.Encoding,
+		walleterrors.ScriptFailure, walleterrors.Policy, walleterrors.Consensus, walleterrors.DoubleSpend} {
+		if walleterrors.Is(err, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+func publishQueuedTx(ctx context.Context, w *dcrlibwallet.Wallet, q *QueuedTx) error {
+	n, err := w.Internal().NetworkBackend()
+	if err != nil {
+		return err
+	}
+	msgTx, err := q.MsgTx()
+	if err != nil {
+		return fmt.Errorf("%w: %v", errQueuedTxInvalid, err)
+	}
+	if msgTx.TxHash().String() != q.Hash {
+		return fmt.Errorf("%w: %s does not match its hash", errQueuedTxInvalid, q.Hash)
+	}
+	_, err = w.Internal().PublishTransaction(ctx, msgTx, n)
+	return err
+}
@@ -0,0 +1,95 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	walleterrors "decred.org/dcrwallet/v2/errors"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestQueuedTx(t *testing.T) {
+	msgTx := wire.NewMsgTx()
+	msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 2, wire.TxTreeRegular), 150000000, []byte{0x51}))
+	msgTx.AddTxOut(wire.NewTxOut(100000000, []byte{0x76, 0xa9}))
+	msgTx.AddTxOut(wire.NewTxOut(49990000, []byte{0x76, 0xa9}))
+
+	var buf bytes.Buffer
+	if err := msgTx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	queuedAt := time.Unix(1650000000, 0)
+	q := QueuedTx{
+		Hash:      msgTx.TxHash().String(),
+		WalletID:  3,
+		Tx:        hex.EncodeToString(buf.Bytes()),
+		Amount:    100000000,
+		Fee:       10000,
+		Direction: dcrlibwallet.TxDirectionSent,
+		QueuedAt:  queuedAt,
+	}
+
+	decoded, err := q.MsgTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.TxHash() != msgTx.TxHash() || len(decoded.TxIn) != 1 || decoded.TxIn[0].PreviousOutPoint.Index != 2 {
+		t.Errorf("unexpected decoded transaction %v", decoded)
+	}
+
+	tx := q.Transaction()
+	if tx.Hash != q.Hash || tx.WalletID != 3 || tx.BlockHeight != -1 || tx.Type != dcrlibwallet.TxTypeRegular ||
+		tx.Amount != 100000000 || tx.Fee != 10000 || tx.Timestamp != queuedAt.Unix() {
+		t.Errorf("unexpected transaction %+v", tx)
+	}
+
+	q.Tx = "zz"
+	if _, err := q.MsgTx(); err == nil {
+		t.Error("expected an error decoding an invalid transaction")
+	}
+}
+
+func TestMergeBroadcastResults(t *testing.T) {
+	queue := []QueuedTx{{Hash: "sent"}, {Hash: "offline"}, {Hash: "spent"}, {Hash: "new"}, {Hash: "removed"}}
+	results := map[string]error{
+		"sent":     nil,
+		"offline":  walleterrors.E(walleterrors.NoPeers, "no peers"),
+		"spent":    walleterrors.E(walleterrors.DoubleSpend, "output already spent"),
+		"canceled": nil,
+		"removed":  errQueuedTxNoWallet,
+	}
+
+	// The canceled transaction is no longer queued, the new one was queued
+	// while the others were published.
+	kept, broadcast, rejected := mergeBroadcastResults(queue, results)
+	hashes := func(queue []QueuedTx) (hashes []string) {
+		for _, q := range queue {
+			hashes = append(hashes, q.Hash)
+		}
+		return hashes
+	}
+	if h := hashes(kept); !reflect.DeepEqual(h, []string{"offline", "new"}) {
+		t.Errorf("kept %v", h)
+	}
+	if h := hashes(broadcast); !reflect.DeepEqual(h, []string{"sent"}) {
+		t.Errorf("broadcast %v", h)
+	}
+	if h := hashes(rejected); !reflect.DeepEqual(h, []string{"spent"}) {
+		t.Errorf("rejected %v", h)
+	}
+	if kept[0].Attempts != 1 || kept[0].LastError == "" || kept[1].Attempts != 0 {
+		t.Errorf("unexpected attempts %+v", kept)
+	}
+
+	if !broadcastRejected(fmt.Errorf("%w: bad hex", errQueuedTxInvalid)) || broadcastRejected(context.DeadlineExceeded) {
+		t.Errorf("unexpected classification of the broadcast errors")
+	}
+}
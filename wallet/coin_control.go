@@ -0,0 +1,230 @@
+package wallet
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"decred.org/dcrwallet/v2/wallet/txauthor"
+	"decred.org/dcrwallet/v2/wallet/txrules"
+	"decred.org/dcrwallet/v2/wallet/txsizes"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// CoinControlConfigKey is the wallet config key of the labels and the frozen
+// flags of the unspent outputs of the wallet.
+const CoinControlConfigKey = "coin_control"
+
+// CoinInfo is what the user set on an unspent output.
+type CoinInfo struct {
+	Label string `json:"label,omitempty"`
+	// Frozen outputs are never spent by the automatic coin selection.
+	Frozen bool `json:"frozen,omitempty"`
+}
+
+// CoinControl maps the output keys of the unspent outputs of a wallet, the
+// "hash:index" dcrlibwallet.UnspentOutput.OutputKey, to their CoinInfo.
+type CoinControl map[string]CoinInfo
+
+// ReadCoinControl returns the CoinControl saved in the config of wal.
+func ReadCoinControl(wal *dcrlibwallet.Wallet) CoinControl {
+	coins := make(CoinControl)
+	if err := wal.ReadUserConfigValue(CoinControlConfigKey, &coins); err != nil || coins == nil {
+		return make(CoinControl)
+	}
+	return coins
+}
+
+// SaveCoinControl saves coins in the config of wal. The outputs with nothing
+// set are not saved.
+func SaveCoinControl(wal *dcrlibwallet.Wallet, coins CoinControl) {
+	saved := make(CoinControl, len(coins))
+	for key, info := range coins {
+		if info != (CoinInfo{}) {
+			saved[key] = info
+		}
+	}
+	wal.SaveUserConfigValue(CoinControlConfigKey, saved)
+}
+
+// Label returns the label of the output with key.
+func (coins CoinControl) Label(key string) string {
+	return coins[key].Label
+}
+
+// Frozen returns true if the output with key is frozen.
+func (coins CoinControl) Frozen(key string) bool {
+	return coins[key].Frozen
+}
+
+// CoinSelection is a strategy of selection of the outputs spent by a
+// transaction.
+type CoinSelection string
+
+const (
+	// CoinSelectionRandom spends randomly selected outputs, as dcrwallet
+	// does by default.
+	CoinSelectionRandom CoinSelection = ""
+	// CoinSelectionLargestFirst spends the fewest outputs, leaving the small
+	// outputs in the wallet.
+	CoinSelectionLargestFirst CoinSelection = "largest_first"
+	// CoinSelectionSmallestFirst spends the small outputs first, which
+	// consolidates them at the cost of larger transactions.
+	CoinSelectionSmallestFirst CoinSelection = "smallest_first"
+	// CoinSelectionPrivacy only spends from the mixed account and links as
+	// few of its outputs as possible, preferably a single one.
+	CoinSelectionPrivacy CoinSelection = "privacy"
+	// CoinSelectionMinimizeChange spends the outputs adding up closest to
+	// the amount sent, to leave as little change as possible.
+	CoinSelectionMinimizeChange CoinSelection = "minimize_change"
+)
+
+// CoinSelections are the coin selection strategies that can be chosen.
+var CoinSelections = []CoinSelection{
+	CoinSelectionRandom,
+	CoinSelectionLargestFirst,
+	CoinSelectionSmallestFirst,
+	CoinSelectionPrivacy,
+	CoinSelectionMinimizeChange,
+}
+
+// coinSelectionConfigKey returns the wallet config key of the coin selection
+// strategy of account.
+func coinSelectionConfigKey(account int32) string {
+	return fmt.Sprintf("coin_selection_%d", account)
+}
+
+// ReadCoinSelection returns the coin selection strategy of account of wal.
+func ReadCoinSelection(wal *dcrlibwallet.Wallet, account int32) CoinSelection {
+	return CoinSelection(wal.ReadStringConfigValueForKey(coinSelectionConfigKey(account), string(CoinSelectionRandom)))
+}
+
+// SaveCoinSelection saves the coin selection strategy of account of wal.
+func SaveCoinSelection(wal *dcrlibwallet.Wallet, account int32, selection CoinSelection) {
+	wal.SetStringConfigValueForKey(coinSelectionConfigKey(account), string(selection))
+}
+
+// maxMinimizeChangeSteps bounds the search of the outputs adding up closest to
+// the target, which is exponential in the number of outputs.
+const maxMinimizeChangeSteps = 100000
+
+// SelectCoins returns the outputs of utxos spent to pay target according to
+// selection. All utxos are returned if they do not add up to target.
+func SelectCoins(utxos []*dcrlibwallet.UnspentOutput, target dcrutil.Amount, selection CoinSelection) []*dcrlibwallet.UnspentOutput {
+	sorted := make([]*dcrlibwallet.UnspentOutput, len(utxos))
+	copy(sorted, utxos)
+
+	switch selection {
+	case CoinSelectionSmallestFirst:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Amount < sorted[j].Amount })
+	case CoinSelectionRandom:
+		rand.Shuffle(len(sorted), func(i, j int) { sorted[i], sorted[j] = sorted[j], sorted[i] })
+	default:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Amount > sorted[j].Amount })
+	}
+
+	switch selection {
+	case CoinSelectionPrivacy:
+		// The smallest single output paying target, sorted is from the
+		// largest.
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if dcrutil.Amount(sorted[i].Amount) >= target {
+				return sorted[i : i+1]
+			}
+		}
+	case CoinSelectionMinimizeChange:
+		if selected := closestCoins(sorted, target); selected != nil {
+			return selected
+		}
+	}
+
+	var total dcrutil.Amount
+	for i, utxo := range sorted {
+		total += dcrutil.Amount(utxo.Amount)
+		if total >= target {
+			return sorted[:i+1]
+		}
+	}
+	return sorted
+}
+
+// closestCoins returns the outputs of sorted, which is sorted from the
+// largest, adding up closest to target without being less. nil is returned if
+// sorted does not add up to target.
+func closestCoins(sorted []*dcrlibwallet.UnspentOutput, target dcrutil.Amount) []*dcrlibwallet.UnspentOutput {
+	// remaining[i] is the sum of the outputs from i, to stop the search
+	// once the outputs left cannot reach target.
+	remaining := make([]dcrutil.Amount, len(sorted)+1)
+	for i := len(sorted) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + dcrutil.Amount(sorted[i].Amount)
+	}
+	if remaining[0] < target {
+		return nil
+	}
+
+	var best, current []int
+	bestTotal := remaining[0] + 1
+	steps := 0
+	var search func(i int, total dcrutil.Amount)
+	search = func(i int, total dcrutil.Amount) {
+		steps++
+		if total >= target {
+			if total < bestTotal {
+				bestTotal = total
+				best = append(best[:0], current...)
+			}
+			return
+		}
+		if i == len(sorted) || total+remaining[i] < target || steps > maxMinimizeChangeSteps || bestTotal == target {
+			return
+		}
+
+		current = append(current, i)
+		search(i+1, total+dcrutil.Amount(sorted[i].Amount))
+		current = current[:len(current)-1]
+		search(i+1, total)
+	}
+	search(0, 0)
+
+	selected := make([]*dcrlibwallet.UnspentOutput, len(best))
+	for i, index := range best {
+		selected[i] = sorted[index]
+	}
+	return selected
+}
+
+// inputDetail returns the inputs spending utxos.
+func inputDetail(utxos []*dcrlibwallet.UnspentOutput) (*txauthor.InputDetail, error) {
+	detail := &txauthor.InputDetail{
+		Inputs:            make([]*wire.TxIn, 0, len(utxos)),
+		Scripts:           make([][]byte, 0, len(utxos)),
+		RedeemScriptSizes: make([]int, 0, len(utxos)),
+	}
+	for _, utxo := range utxos {
+		hash, err := chainhash.NewHash(utxo.TransactionHash)
+		if err != nil {
+			return nil, err
+		}
+		outpoint := wire.NewOutPoint(hash, utxo.OutputIndex, int8(utxo.Tree))
+		detail.Inputs = append(detail.Inputs, wire.NewTxIn(outpoint, utxo.Amount, nil))
+		detail.Scripts = append(detail.Scripts, utxo.PkScript)
+		// The outputs of the wallet are P2PKH or smaller.
+		detail.RedeemScriptSizes = append(detail.RedeemScriptSizes, txsizes.RedeemP2PKHSigScriptSize)
+		detail.Amount += dcrutil.Amount(utxo.Amount)
+	}
+	return detail, nil
+}
+
+// EstimateSpendFee returns the fee of a transaction spending inputs outputs of
+// the wallet to a single P2PKH output at rate.
+func EstimateSpendFee(inputs int, rate dcrutil.Amount) dcrutil.Amount {
+	scriptSizes := make([]int, inputs)
+	for i := range scriptSizes {
+		scriptSizes[i] = txsizes.RedeemP2PKHSigScriptSize
+	}
+	size := txsizes.EstimateSerializeSize(scriptSizes, nil, txsizes.P2PKHPkScriptSize)
+	return txrules.FeeForSerializeSize(rate, size)
+}
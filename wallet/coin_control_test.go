@@ -0,0 +1,55 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestSelectCoins(t *testing.T) {
+	var utxos []*dcrlibwallet.UnspentOutput
+	for _, amount := range []int64{5, 1, 8, 3, 12} {
+		utxos = append(utxos, &dcrlibwallet.UnspentOutput{Amount: amount})
+	}
+
+	tests := []struct {
+		selection CoinSelection
+		target    dcrutil.Amount
+		expected  []int64
+	}{
+		{CoinSelectionLargestFirst, 15, []int64{12, 8}},
+		{CoinSelectionSmallestFirst, 8, []int64{1, 3, 5}},
+		{CoinSelectionPrivacy, 6, []int64{8}},
+		{CoinSelectionPrivacy, 15, []int64{12, 8}},
+		{CoinSelectionMinimizeChange, 14, []int64{8, 5, 1}},
+		{CoinSelectionMinimizeChange, 16, []int64{12, 3, 1}},
+		{CoinSelectionLargestFirst, 30, []int64{12, 8, 5, 3, 1}},
+		{CoinSelectionMinimizeChange, 30, []int64{12, 8, 5, 3, 1}},
+	}
+	for _, test := range tests {
+		selected := SelectCoins(utxos, test.target, test.selection)
+		amounts := make([]int64, len(selected))
+		for i, utxo := range selected {
+			amounts[i] = utxo.Amount
+		}
+		if len(amounts) != len(test.expected) {
+			t.Errorf("%s %d: expected %v, got %v", test.selection, test.target, test.expected, amounts)
+			continue
+		}
+		for i := range amounts {
+			if amounts[i] != test.expected[i] {
+				t.Errorf("%s %d: expected %v, got %v", test.selection, test.target, test.expected, amounts)
+				break
+			}
+		}
+	}
+
+	var total dcrutil.Amount
+	for _, utxo := range SelectCoins(utxos, 20, CoinSelectionRandom) {
+		total += dcrutil.Amount(utxo.Amount)
+	}
+	if total < 20 {
+		t.Errorf("random selection of %d does not pay 20", total)
+	}
+}
@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// DefaultConsolidationThreshold is the amount up to which the outputs
+	// are consolidated by default.
+	DefaultConsolidationThreshold dcrutil.Amount = 1e7 // 0.1 DCR
+
+	// MaxConsolidationInputs is the highest number of outputs consolidated
+	// by a transaction, which keeps it well under the maximum size of a
+	// transaction. More outputs are consolidated by repeating.
+	MaxConsolidationInputs = 500
+
+	// consolidationFutureFeeRate is the fee rate the outputs are expected to
+	// be spent at if they are not consolidated.
+	consolidationFutureFeeRate = FeeRateNormal
+)
+
+// Consolidation is a plan of merging small outputs of an account into a single
+// output.
+type Consolidation struct {
+	// Inputs are the outputs merged, from the smallest.
+	Inputs []*dcrlibwallet.UnspentOutput
+	// Dust is the number of inputs that are worth less than the fee of
+	// spending them, which would be lost if they were spent on their own.
+	Dust  int
+	Total dcrutil.Amount
+	// Fee is the fee of the consolidation transaction.
+	Fee dcrutil.Amount
+	// FutureFee is the fee saved when the consolidated amount is spent,
+	// compared to spending the inputs at the normal fee rate.
+	FutureFee dcrutil.Amount
+}
+
+// Output returns the amount of the consolidated output.
+func (c *Consolidation) Output() dcrutil.Amount {
+	return c.Total - c.Fee
+}
+
+// Savings returns the fees saved by the consolidation, which are negative if it
+// costs more than it saves.
+func (c *Consolidation) Savings() dcrutil.Amount {
+	return c.FutureFee - c.Fee
+}
+
+// Keys returns the output keys of the inputs.
+func (c *Consolidation) Keys() []string {
+	keys := make([]string, len(c.Inputs))
+	for i, utxo := range c.Inputs {
+		keys[i] = utxo.OutputKey
+	}
+	return keys
+}
+
+// PlanConsolidation returns the consolidation of the outputs of utxos worth at
+// most threshold, that are not frozen in coins, at rate. At most
+// MaxConsolidationInputs outputs are consolidated, the smallest first. nil is
+// returned if there are less than two outputs to consolidate or if they are not
+// worth the fee.
+func PlanConsolidation(utxos []*dcrlibwallet.UnspentOutput, threshold dcrutil.Amount, coins CoinControl, rate dcrutil.Amount) *Consolidation {
+	var inputs []*dcrlibwallet.UnspentOutput
+	for _, utxo := range utxos {
+		if dcrutil.Amount(utxo.Amount) <= threshold && !coins.Frozen(utxo.OutputKey) {
+			inputs = append(inputs, utxo)
+		}
+	}
+	if len(inputs) < 2 {
+		return nil
+	}
+
+	sort.SliceStable(inputs, func(i, j int) bool { return inputs[i].Amount < inputs[j].Amount })
+	if len(inputs) > MaxConsolidationInputs {
+		inputs = inputs[:MaxConsolidationInputs]
+	}
+
+	c := &Consolidation{
+		Inputs: inputs,
+		Fee:    EstimateSpendFee(len(inputs), rate),
+	}
+	inputFee := EstimateSpendFee(2, consolidationFutureFeeRate) - EstimateSpendFee(1, consolidationFutureFeeRate)
+	for _, utxo := range inputs {
+		c.Total += dcrutil.Amount(utxo.Amount)
+		if dcrutil.Amount(utxo.Amount) <= inputFee {
+			c.Dust++
+		}
+	}
+	if c.Total <= c.Fee {
+		return nil
+	}
+	c.FutureFee = EstimateSpendFee(len(inputs), consolidationFutureFeeRate) - EstimateSpendFee(1, consolidationFutureFeeRate)
+	return c
+}
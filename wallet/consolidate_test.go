@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestPlanConsolidation(t *testing.T) {
+	var utxos []*dcrlibwallet.UnspentOutput
+	for i, amount := range []int64{1000, 5e6, 2e6, 3e8, 8e6, 4e6} {
+		utxos = append(utxos, &dcrlibwallet.UnspentOutput{OutputKey: fmt.Sprintf("hash:%d", i), Amount: amount})
+	}
+	coins := CoinControl{"hash:2": {Frozen: true}}
+
+	c := PlanConsolidation(utxos, DefaultConsolidationThreshold, coins, FeeRateLow)
+	if c == nil {
+		t.Fatal("expected a consolidation")
+	}
+	expected := []string{"hash:0", "hash:5", "hash:1", "hash:4"}
+	keys := c.Keys()
+	if fmt.Sprint(keys) != fmt.Sprint(expected) {
+		t.Errorf("expected inputs %v, got %v", expected, keys)
+	}
+	if c.Total != 1000+4e6+5e6+8e6 {
+		t.Errorf("unexpected total %v", c.Total)
+	}
+	if c.Dust != 1 {
+		t.Errorf("expected 1 dust output, got %d", c.Dust)
+	}
+	if c.Fee != EstimateSpendFee(4, FeeRateLow) || c.Output() != c.Total-c.Fee {
+		t.Errorf("unexpected fee %v", c.Fee)
+	}
+	if c.Savings() <= 0 {
+		t.Errorf("expected savings, got %v", c.Savings())
+	}
+
+	if c := PlanConsolidation(utxos, 3000, coins, FeeRateLow); c != nil {
+		t.Errorf("expected no consolidation of a single output, got %v", c.Keys())
+	}
+}
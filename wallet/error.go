@@ -2,6 +2,7 @@ package wallet
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/planetdecred/dcrlibwallet"
 )
@@ -12,8 +13,106 @@ var (
 
 	// ErrBadPass wraps dcrlibwallet.ErrInvalidPassphrase
 	ErrBadPass = errors.New(dcrlibwallet.ErrInvalidPassphrase)
+
+	// ErrNotSynced is returned when an action needs the wallets to be synced
+	ErrNotSynced = &Error{Code: ErrCodeNotSynced, Err: errors.New(errNotSyncedMsg)}
+)
+
+const errNotSyncedMsg = "wallets are not synced"
+
+// ErrorCode classifies the errors shown to the user so that all the errors of
+// a class are shown with the same translated message.
+type ErrorCode string
+
+const (
+	ErrCodeUnknown           ErrorCode = ""
+	ErrCodeBadPassphrase     ErrorCode = "bad_passphrase"
+	ErrCodeInsufficientFunds ErrorCode = "insufficient_funds"
+	ErrCodeNotSynced         ErrorCode = "not_synced"
+	ErrCodeNoPeers           ErrorCode = "no_peers"
+	ErrCodeVSP               ErrorCode = "vsp"
+	ErrCodePoliteia          ErrorCode = "politeia"
+	ErrCodeDEX               ErrorCode = "dex"
 )
 
+// Error is an error with a code, Err holds the technical details.
+type Error struct {
+	Code ErrorCode
+	Err  error
+}
+
+// NewError returns err with code. nil is returned if err is nil, so that the
+// result of a call can be wrapped directly.
+func NewError(code ErrorCode, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+func (err *Error) Error() string {
+	if err.Err == nil {
+		return string(err.Code)
+	}
+	return err.Err.Error()
+}
+
+// Unwrap returns the embedded error
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// errorClasses maps the messages of dcrlibwallet, dcrwallet and godcr errors to
+// the code of their class. Both the dcrlibwallet error codes and the
+// descriptions of the dcrwallet error kinds are matched. The first message of
+// a class is never shown as error details.
+var errorClasses = []struct {
+	messages []string
+	code     ErrorCode
+}{
+	{[]string{dcrlibwallet.ErrInvalidPassphrase, "invalid passphrase"}, ErrCodeBadPassphrase},
+	{[]string{dcrlibwallet.ErrInsufficientBalance, "insufficient balance"}, ErrCodeInsufficientFunds},
+	{[]string{dcrlibwallet.ErrNoPeers, dcrlibwallet.ErrNotConnected, "no peers"}, ErrCodeNoPeers},
+	{[]string{errNotSyncedMsg}, ErrCodeNotSynced},
+}
+
+// ErrorCodeOf returns the code of err. The class of the underlying dcrlibwallet
+// error takes precedence over the code of an *Error in the chain of err, so
+// that a bad passphrase entered to buy tickets is not reported as a VSP error.
+func ErrorCodeOf(err error) ErrorCode {
+	if err == nil {
+		return ErrCodeUnknown
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, class := range errorClasses {
+		for _, m := range class.messages {
+			if strings.Contains(msg, m) {
+				return class.code
+			}
+		}
+	}
+
+	var codeErr *Error
+	if errors.As(err, &codeErr) {
+		return codeErr.Code
+	}
+	return ErrCodeUnknown
+}
+
+// ErrorDetails returns the technical details of err that are shown with the
+// message of its code. They are empty if err is only a dcrlibwallet error
+// code or ErrNotSynced, which say nothing more than the message.
+func ErrorDetails(err error) string {
+	details := err.Error()
+	for _, class := range errorClasses {
+		if details == class.messages[0] {
+			return ""
+		}
+	}
+	return details
+}
+
 // InternalWalletError wraps errors encountered with individual Wallets and Accounts
 type InternalWalletError struct {
 	Message  string
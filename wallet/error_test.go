@@ -0,0 +1,41 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    ErrorCode
+		details string
+	}{
+		{errors.New(dcrlibwallet.ErrInvalidPassphrase), ErrCodeBadPassphrase, ""},
+		{errors.New("wallet.Unlock: invalid passphrase"), ErrCodeBadPassphrase, "wallet.Unlock: invalid passphrase"},
+		{errors.New(dcrlibwallet.ErrInsufficientBalance), ErrCodeInsufficientFunds, ""},
+		{errors.New(dcrlibwallet.ErrNoPeers), ErrCodeNoPeers, ""},
+		{ErrNotSynced, ErrCodeNotSynced, ""},
+		{fmt.Errorf("staking: %w", ErrNotSynced), ErrCodeNotSynced, "staking: wallets are not synced"},
+		{NewError(ErrCodeVSP, errors.New("fee not paid")), ErrCodeVSP, "fee not paid"},
+		{fmt.Errorf("voting: %w", NewError(ErrCodePoliteia, errors.New("timeout"))), ErrCodePoliteia, "voting: timeout"},
+		// The class of the underlying error takes precedence over the code.
+		{NewError(ErrCodeVSP, errors.New(dcrlibwallet.ErrInvalidPassphrase)), ErrCodeBadPassphrase, ""},
+		{errors.New("something else"), ErrCodeUnknown, "something else"},
+	}
+	for _, test := range tests {
+		if code := ErrorCodeOf(test.err); code != test.code {
+			t.Errorf("%v: expected code %q, got %q", test.err, test.code, code)
+		}
+		if details := ErrorDetails(test.err); details != test.details {
+			t.Errorf("%v: expected details %q, got %q", test.err, test.details, details)
+		}
+	}
+
+	if NewError(ErrCodeDEX, nil) != nil {
+		t.Error("expected a nil error for a nil cause")
+	}
+}
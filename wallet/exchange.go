@@ -0,0 +1,475 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DcrdataProvider, CoinGeckoProvider and BinanceProvider are the names of
+	// the built-in exchange rate providers.
+	DcrdataProvider   = "dcrdata"
+	CoinGeckoProvider = "coingecko"
+	BinanceProvider   = "binance"
+
+	DefaultDcrdataURL   = "https://explorer.dcrdata.org"
+	DefaultCoinGeckoURL = "https://api.coingecko.com"
+	DefaultBinanceURL   = "https://api.binance.com"
+
+	// exchangeRateCacheTTL is how long a fetched rate is served from the
+	// cache before the provider is queried again.
+	exchangeRateCacheTTL = 5 * time.Minute
+
+	exchangeRateRequestTimeout = 30 * time.Second
+	exchangeRateUserAgent      = "godcr"
+)
+
+var (
+	// ErrUnknownProvider is returned when a rate is requested from a provider
+	// that has not been registered.
+	ErrUnknownProvider = errors.New("unknown exchange rate provider")
+
+	// ErrUnsupportedCurrency is returned when a provider cannot quote DCR in
+	// the requested currency.
+	ErrUnsupportedCurrency = errors.New("currency not supported by exchange rate provider")
+)
+
+// ExchangeRate is the value of 1 DCR in Currency as reported by Provider at
+// Timestamp.
+type ExchangeRate struct {
+	Provider  string
+	Currency  string
+	Rate      float64
+	Timestamp time.Time
+}
+
+// ExchangeRateProvider is implemented by sources of the DCR exchange rate.
+type ExchangeRateProvider interface {
+	// Name returns the unique name the provider is registered with.
+	Name() string
+	// Currencies returns the currency codes DCR can be quoted in.
+	Currencies() []string
+	// FetchRate queries the provider for the current value of 1 DCR in the
+	// specified currency.
+	FetchRate(ctx context.Context, currency string) (*ExchangeRate, error)
+}
+
+// ExchangeRates holds the registered exchange rate providers and caches the
+// rates fetched from them.
+type ExchangeRates struct {
+	mtx       sync.RWMutex
+	providers map[string]ExchangeRateProvider
+	cache     map[string]*ExchangeRate
+	ttl       time.Duration
+}
+
+// NewExchangeRates returns an ExchangeRates with the specified providers
+// registered.
+func NewExchangeRates(providers ...ExchangeRateProvider) *ExchangeRates {
+	er := &ExchangeRates{
+		providers: make(map[string]ExchangeRateProvider),
+		cache:     make(map[string]*ExchangeRate),
+		ttl:       exchangeRateCacheTTL,
+	}
+	for _, p := range providers {
+		er.providers[p.Name()] = p
+	}
+	return er
+}
+
+// DefaultExchangeRateProviders returns the built-in exchange rate providers
+// configured with their public API endpoints.
+func DefaultExchangeRateProviders() []ExchangeRateProvider {
+	return []ExchangeRateProvider{
+		NewDcrdataProvider(""),
+		NewCoinGeckoProvider(""),
+		NewBinanceProvider(""),
+	}
+}
+
+// Register adds p to the list of providers, replacing any provider previously
+// registered with the same name.
+func (er *ExchangeRates) Register(p ExchangeRateProvider) {
+	er.mtx.Lock()
+	defer er.mtx.Unlock()
+	er.providers[p.Name()] = p
+}
+
+// Providers returns the names of the registered providers in alphabetical
+// order.
+func (er *ExchangeRates) Providers() []string {
+	er.mtx.RLock()
+	defer er.mtx.RUnlock()
+
+	names := make([]string, 0, len(er.providers))
+	for name := range er.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Provider returns the provider registered with the specified name.
+func (er *ExchangeRates) Provider(name string) (ExchangeRateProvider, bool) {
+	er.mtx.RLock()
+	defer er.mtx.RUnlock()
+	p, ok := er.providers[name]
+	return p, ok
+}
+
+// Rate returns the value of 1 DCR in currency as reported by provider. A
+// cached rate is returned if it was fetched less than the cache TTL ago.
+func (er *ExchangeRates) Rate(ctx context.Context, provider, currency string) (*ExchangeRate, error) {
+	if rate := er.CachedRate(provider, currency); rate != nil && time.Since(rate.Timestamp) < er.ttl {
+		return rate, nil
+	}
+
+	p, ok := er.Provider(provider)
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	rate, err := p.FetchRate(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	er.mtx.Lock()
+	er.cache[exchangeRateCacheKey(provider, currency)] = rate
+	er.mtx.Unlock()
+
+	return rate, nil
+}
+
+// CachedRate returns the last rate fetched from provider for currency, or nil
+// if none has been fetched. The rate is returned regardless of its age.
+func (er *ExchangeRates) CachedRate(provider, currency string) *ExchangeRate {
+	er.mtx.RLock()
+	defer er.mtx.RUnlock()
+	return er.cache[exchangeRateCacheKey(provider, currency)]
+}
+
+func exchangeRateCacheKey(provider, currency string) string {
+	return provider + ":" + strings.ToUpper(currency)
+}
+
+// httpRateProvider holds the fields common to the built-in providers.
+type httpRateProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newHTTPRateProvider(baseURL, defaultURL string) httpRateProvider {
+	if baseURL == "" {
+		baseURL = defaultURL
+	}
+	return httpRateProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: exchangeRateRequestTimeout},
+	}
+}
+
+func (p httpRateProvider) getJSON(ctx context.Context, path string, target interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", exchangeRateUserAgent)
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with %s", p.baseURL, res.Status)
+	}
+
+	return json.NewDecoder(res.Body).Decode(target)
+}
+
+func supportsCurrency(p ExchangeRateProvider, currency string) bool {
+	for _, c := range p.Currencies() {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// dcrdataProvider fetches the DCR rate from the exchange rate API of a
+// dcrdata instance. dcrdata only quotes DCR in the fiat index the instance is
+// configured with, which is USD on the public explorer.
+type dcrdataProvider struct {
+	httpRateProvider
+}
+
+// NewDcrdataProvider returns a provider backed by the dcrdata instance at
+// baseURL. The public explorer is used if baseURL is empty.
+func NewDcrdataProvider(baseURL string) ExchangeRateProvider {
+	return &dcrdataProvider{newHTTPRateProvider(baseURL, DefaultDcrdataURL)}
+}
+
+func (p *dcrdataProvider) Name() string {
+	return DcrdataProvider
+}
+
+func (p *dcrdataProvider) Currencies() []string {
+	return []string{"USD"}
+}
+
+func (p *dcrdataProvider) FetchRate(ctx context.Context, currency string) (*ExchangeRate, error) {
+	var res struct {
+		BtcIndex string  `json:"btcIndex"`
+		DcrPrice float64 `json:"dcrPrice"`
+	}
+	if err := p.getJSON(ctx, "/api/exchangerate", &res); err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(res.BtcIndex, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+	if res.DcrPrice <= 0 {
+		return nil, fmt.Errorf("dcrdata returned an invalid rate: %v", res.DcrPrice)
+	}
+
+	return &ExchangeRate{
+		Provider:  DcrdataProvider,
+		Currency:  strings.ToUpper(currency),
+		Rate:      res.DcrPrice,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// coinGeckoProvider fetches the DCR rate from the CoinGecko simple price API.
+type coinGeckoProvider struct {
+	httpRateProvider
+}
+
+// NewCoinGeckoProvider returns a provider backed by the CoinGecko API at
+// baseURL. The public API is used if baseURL is empty.
+func NewCoinGeckoProvider(baseURL string) ExchangeRateProvider {
+	return &coinGeckoProvider{newHTTPRateProvider(baseURL, DefaultCoinGeckoURL)}
+}
+
+func (p *coinGeckoProvider) Name() string {
+	return CoinGeckoProvider
+}
+
+func (p *coinGeckoProvider) Currencies() []string {
+	return []string{"USD", "EUR", "GBP", "NGN", "JPY", "CNY", "CAD", "AUD", "CHF", "BRL"}
+}
+
+func (p *coinGeckoProvider) FetchRate(ctx context.Context, currency string) (*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	vsCurrency := strings.ToLower(currency)
+	var res map[string]map[string]float64
+	if err := p.getJSON(ctx, "/api/v3/simple/price?ids=decred&vs_currencies="+vsCurrency, &res); err != nil {
+		return nil, err
+	}
+
+	rate, ok := res["decred"][vsCurrency]
+	if !ok || rate <= 0 {
+		return nil, fmt.Errorf("coingecko returned no %s rate", currency)
+	}
+
+	return &ExchangeRate{
+		Provider:  CoinGeckoProvider,
+		Currency:  strings.ToUpper(currency),
+		Rate:      rate,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (p *coinGeckoProvider) FetchHistoricalRate(ctx context.Context, currency string, t time.Time) (*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	var res struct {
+		MarketData struct {
+			CurrentPrice map[string]float64 `json:"current_price"`
+		} `json:"market_data"`
+	}
+	date := t.UTC().Format("02-01-2006")
+	if err := p.getJSON(ctx, "/api/v3/coins/decred/history?localization=false&date="+date, &res); err != nil {
+		return nil, err
+	}
+
+	rate, ok := res.MarketData.CurrentPrice[strings.ToLower(currency)]
+	if !ok || rate <= 0 {
+		return nil, fmt.Errorf("coingecko returned no %s rate for %s", currency, date)
+	}
+
+	return &ExchangeRate{
+		Provider:  CoinGeckoProvider,
+		Currency:  strings.ToUpper(currency),
+		Rate:      rate,
+		Timestamp: t,
+	}, nil
+}
+
+func (p *coinGeckoProvider) FetchHistoricalRates(ctx context.Context, currency string, from, to time.Time) ([]*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	// The prices are hourly for ranges of up to 90 days and daily beyond,
+	// each as an array of the time in milliseconds and the price.
+	var res struct {
+		Prices [][2]float64 `json:"prices"`
+	}
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour).Add(24*time.Hour)
+	path := fmt.Sprintf("/api/v3/coins/decred/market_chart/range?vs_currency=%s&from=%d&to=%d",
+		strings.ToLower(currency), from.Unix(), to.Unix())
+	if err := p.getJSON(ctx, path, &res); err != nil {
+		return nil, err
+	}
+
+	// The first price of each day is kept, it is the closest to the price at
+	// midnight reported by FetchHistoricalRate.
+	var rates []*ExchangeRate
+	seen := make(map[string]bool)
+	for _, price := range res.Prices {
+		t := time.Unix(0, int64(price[0])*int64(time.Millisecond)).UTC()
+		day := priceHistoryDay(t)
+		if seen[day] || price[1] <= 0 {
+			continue
+		}
+		seen[day] = true
+		rates = append(rates, &ExchangeRate{
+			Provider:  CoinGeckoProvider,
+			Currency:  strings.ToUpper(currency),
+			Rate:      price[1],
+			Timestamp: t,
+		})
+	}
+	return rates, nil
+}
+
+// binanceProvider fetches the last traded DCR price from the Binance ticker
+// API. Binance has no fiat markets for DCR so rates are quoted in USDT.
+type binanceProvider struct {
+	httpRateProvider
+}
+
+// NewBinanceProvider returns a provider backed by the Binance API at baseURL.
+// The public API is used if baseURL is empty.
+func NewBinanceProvider(baseURL string) ExchangeRateProvider {
+	return &binanceProvider{newHTTPRateProvider(baseURL, DefaultBinanceURL)}
+}
+
+func (p *binanceProvider) Name() string {
+	return BinanceProvider
+}
+
+func (p *binanceProvider) Currencies() []string {
+	return []string{"USDT"}
+}
+
+func (p *binanceProvider) FetchRate(ctx context.Context, currency string) (*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	var res struct {
+		Symbol string `json:"symbol"`
+		Price  string `json:"price"`
+	}
+	symbol := "DCR" + strings.ToUpper(currency)
+	if err := p.getJSON(ctx, "/api/v3/ticker/price?symbol="+symbol, &res); err != nil {
+		return nil, err
+	}
+
+	rate, err := strconv.ParseFloat(res.Price, 64)
+	if err != nil || rate <= 0 {
+		return nil, fmt.Errorf("binance returned an invalid %s price: %q", symbol, res.Price)
+	}
+
+	return &ExchangeRate{
+		Provider:  BinanceProvider,
+		Currency:  strings.ToUpper(currency),
+		Rate:      rate,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (p *binanceProvider) FetchHistoricalRate(ctx context.Context, currency string, t time.Time) (*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	// Each kline is an array of the open time followed by the open, high,
+	// low and close prices of the interval.
+	var res [][]interface{}
+	symbol := "DCR" + strings.ToUpper(currency)
+	day := t.UTC().Truncate(24 * time.Hour)
+	path := fmt.Sprintf("/api/v3/klines?symbol=%s&interval=1d&limit=1&startTime=%d", symbol, day.UnixNano()/int64(time.Millisecond))
+	if err := p.getJSON(ctx, path, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 || len(res[0]) < 5 {
+		return nil, fmt.Errorf("binance returned no %s price for %s", symbol, day.Format("2006-01-02"))
+	}
+	closePrice, _ := res[0][4].(string)
+	rate, err := strconv.ParseFloat(closePrice, 64)
+	if err != nil || rate <= 0 {
+		return nil, fmt.Errorf("binance returned an invalid %s price: %q", symbol, closePrice)
+	}
+
+	return &ExchangeRate{
+		Provider:  BinanceProvider,
+		Currency:  strings.ToUpper(currency),
+		Rate:      rate,
+		Timestamp: t,
+	}, nil
+}
+
+func (p *binanceProvider) FetchHistoricalRates(ctx context.Context, currency string, from, to time.Time) ([]*ExchangeRate, error) {
+	if !supportsCurrency(p, currency) {
+		return nil, ErrUnsupportedCurrency
+	}
+
+	var res [][]interface{}
+	symbol := "DCR" + strings.ToUpper(currency)
+	from, to = from.UTC().Truncate(24*time.Hour), to.UTC().Truncate(24*time.Hour)
+	path := fmt.Sprintf("/api/v3/klines?symbol=%s&interval=1d&limit=1000&startTime=%d&endTime=%d", symbol,
+		from.UnixNano()/int64(time.Millisecond), to.UnixNano()/int64(time.Millisecond))
+	if err := p.getJSON(ctx, path, &res); err != nil {
+		return nil, err
+	}
+
+	var rates []*ExchangeRate
+	for _, kline := range res {
+		if len(kline) < 5 {
+			continue
+		}
+		openTime, _ := kline[0].(float64)
+		closePrice, _ := kline[4].(string)
+		rate, err := strconv.ParseFloat(closePrice, 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+		rates = append(rates, &ExchangeRate{
+			Provider:  BinanceProvider,
+			Currency:  strings.ToUpper(currency),
+			Rate:      rate,
+			Timestamp: time.Unix(0, int64(openTime)*int64(time.Millisecond)).UTC(),
+		})
+	}
+	return rates, nil
+}
@@ -0,0 +1,93 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newRateServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/exchangerate", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fmt.Fprint(w, `{"btcIndex":"USD","dcrPrice":21.5,"btcPrice":30000}`)
+	})
+	mux.HandleFunc("/api/v3/simple/price", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fmt.Fprintf(w, `{"decred":{"%s":19.25}}`, r.URL.Query().Get("vs_currencies"))
+	})
+	mux.HandleFunc("/api/v3/ticker/price", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fmt.Fprintf(w, `{"symbol":"%s","price":"20.10000000"}`, r.URL.Query().Get("symbol"))
+	})
+	mux.HandleFunc("/api/v3/coins/decred/history", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fmt.Fprint(w, `{"market_data":{"current_price":{"usd":15.5,"eur":13.25}}}`)
+	})
+	mux.HandleFunc("/api/v3/coins/decred/market_chart/range", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		fmt.Fprintf(w, `{"prices":[[%s000,15.5]]}`, r.URL.Query().Get("from"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestExchangeRateProviders(t *testing.T) {
+	var hits int32
+	server := newRateServer(t, &hits)
+
+	tests := []struct {
+		provider ExchangeRateProvider
+		currency string
+		rate     float64
+	}{
+		{NewDcrdataProvider(server.URL), "USD", 21.5},
+		{NewCoinGeckoProvider(server.URL), "USD", 19.25},
+		{NewBinanceProvider(server.URL), "USDT", 20.1},
+	}
+
+	for _, test := range tests {
+		rate, err := test.provider.FetchRate(context.Background(), test.currency)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.provider.Name(), err)
+		}
+		if rate.Rate != test.rate || rate.Currency != test.currency || rate.Provider != test.provider.Name() {
+			t.Fatalf("%s: unexpected rate %+v", test.provider.Name(), rate)
+		}
+		if rate.Timestamp.IsZero() {
+			t.Fatalf("%s: rate has no timestamp", test.provider.Name())
+		}
+	}
+
+	if _, err := NewBinanceProvider(server.URL).FetchRate(context.Background(), "EUR"); err != ErrUnsupportedCurrency {
+		t.Fatalf("expected ErrUnsupportedCurrency, got %v", err)
+	}
+}
+
+func TestExchangeRatesCache(t *testing.T) {
+	var hits int32
+	server := newRateServer(t, &hits)
+	rates := NewExchangeRates(NewDcrdataProvider(server.URL))
+
+	for i := 0; i < 3; i++ {
+		if _, err := rates.Rate(context.Background(), DcrdataProvider, "USD"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if hits != 1 {
+		t.Fatalf("expected 1 request to the provider, got %d", hits)
+	}
+
+	if rates.CachedRate(DcrdataProvider, "usd") == nil {
+		t.Fatal("expected cached rate")
+	}
+
+	if _, err := rates.Rate(context.Background(), "unknown", "USD"); err != ErrUnknownProvider {
+		t.Fatalf("expected ErrUnknownProvider, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ExplorerTemplatesConfigKey is the user config key of the block explorer
+// templates. The user config is kept per network, so are the templates.
+const ExplorerTemplatesConfigKey = "explorer_templates"
+
+// Placeholders replaced in the block explorer templates.
+const (
+	ExplorerHashPlaceholder    = "{hash}"
+	ExplorerAddressPlaceholder = "{address}"
+	ExplorerBlockPlaceholder   = "{block}"
+)
+
+// ExplorerTemplates are the URL templates of the block explorer links. Tx and
+// Ticket contain ExplorerHashPlaceholder, Address contains
+// ExplorerAddressPlaceholder and Block contains ExplorerBlockPlaceholder, which
+// is replaced by the block height.
+type ExplorerTemplates struct {
+	Tx      string `json:"tx"`
+	Address string `json:"address"`
+	Block   string `json:"block"`
+	Ticket  string `json:"ticket"`
+}
+
+// DefaultExplorerTemplates returns the templates of a dcrdata instance at
+// baseURL. The templates are empty if baseURL is empty.
+func DefaultExplorerTemplates(baseURL string) ExplorerTemplates {
+	if baseURL == "" {
+		return ExplorerTemplates{}
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	return ExplorerTemplates{
+		Tx:      baseURL + "/tx/" + ExplorerHashPlaceholder,
+		Address: baseURL + "/address/" + ExplorerAddressPlaceholder,
+		Block:   baseURL + "/block/" + ExplorerBlockPlaceholder,
+		Ticket:  baseURL + "/tx/" + ExplorerHashPlaceholder,
+	}
+}
+
+// Validate returns an error if a template is not an http or https URL with
+// its placeholder.
+func (t ExplorerTemplates) Validate() error {
+	templates := []struct {
+		name, template, placeholder string
+	}{
+		{"transaction", t.Tx, ExplorerHashPlaceholder},
+		{"address", t.Address, ExplorerAddressPlaceholder},
+		{"block", t.Block, ExplorerBlockPlaceholder},
+		{"ticket", t.Ticket, ExplorerHashPlaceholder},
+	}
+	for _, tmpl := range templates {
+		if !strings.Contains(tmpl.template, tmpl.placeholder) {
+			return fmt.Errorf("%s template must contain %s", tmpl.name, tmpl.placeholder)
+		}
+		u, err := url.Parse(strings.Replace(tmpl.template, tmpl.placeholder, "x", -1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s template is not an http or https URL", tmpl.name)
+		}
+	}
+	return nil
+}
+
+// withDefaults returns t with its empty templates set to those of defaults.
+func (t ExplorerTemplates) withDefaults(defaults ExplorerTemplates) ExplorerTemplates {
+	if t.Tx == "" {
+		t.Tx = defaults.Tx
+	}
+	if t.Address == "" {
+		t.Address = defaults.Address
+	}
+	if t.Block == "" {
+		t.Block = defaults.Block
+	}
+	if t.Ticket == "" {
+		t.Ticket = defaults.Ticket
+	}
+	return t
+}
+
+func expandTemplate(template, placeholder, value string) string {
+	if template == "" {
+		return ""
+	}
+	return strings.Replace(template, placeholder, url.PathEscape(value), -1)
+}
+
+// TxURL returns the link of the transaction with hash.
+func (t ExplorerTemplates) TxURL(hash string) string {
+	return expandTemplate(t.Tx, ExplorerHashPlaceholder, hash)
+}
+
+// AddressURL returns the link of address.
+func (t ExplorerTemplates) AddressURL(address string) string {
+	return expandTemplate(t.Address, ExplorerAddressPlaceholder, address)
+}
+
+// BlockURL returns the link of the block at height.
+func (t ExplorerTemplates) BlockURL(height int32) string {
+	return expandTemplate(t.Block, ExplorerBlockPlaceholder, fmt.Sprint(height))
+}
+
+// TicketURL returns the link of the ticket with hash.
+func (t ExplorerTemplates) TicketURL(hash string) string {
+	return expandTemplate(t.Ticket, ExplorerHashPlaceholder, hash)
+}
+
+// DefaultExplorerTemplates returns the templates of the explorer of the
+// network, which are used for the templates not set by the user.
+func (wal *Wallet) DefaultExplorerTemplates() ExplorerTemplates {
+	return DefaultExplorerTemplates(DefaultExplorerURL(wal.Net))
+}
+
+// ExplorerTemplates returns the block explorer templates set by the user,
+// with the defaults of the network for the templates that are not set.
+func (wal *Wallet) ExplorerTemplates() ExplorerTemplates {
+	var templates ExplorerTemplates
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(ExplorerTemplatesConfigKey, &templates); err != nil {
+			templates = ExplorerTemplates{}
+		}
+	}
+	return templates.withDefaults(wal.DefaultExplorerTemplates())
+}
+
+// SetExplorerTemplates validates and saves the block explorer templates in the
+// user config. Templates equal to the defaults of the network are not saved so
+// that they follow the defaults.
+func (wal *Wallet) SetExplorerTemplates(templates ExplorerTemplates) error {
+	if err := templates.Validate(); err != nil {
+		return err
+	}
+
+	defaults := wal.DefaultExplorerTemplates()
+	if templates.Tx == defaults.Tx {
+		templates.Tx = ""
+	}
+	if templates.Address == defaults.Address {
+		templates.Address = ""
+	}
+	if templates.Block == defaults.Block {
+		templates.Block = ""
+	}
+	if templates.Ticket == defaults.Ticket {
+		templates.Ticket = ""
+	}
+	if templates == (ExplorerTemplates{}) {
+		wal.ResetExplorerTemplates()
+		return nil
+	}
+	wal.multi.SaveUserConfigValue(ExplorerTemplatesConfigKey, templates)
+	return nil
+}
+
+// ResetExplorerTemplates removes the templates set by the user so that the
+// defaults of the network are used.
+func (wal *Wallet) ResetExplorerTemplates() {
+	wal.multi.DeleteUserConfigValueForKey(ExplorerTemplatesConfigKey)
+}
@@ -0,0 +1,47 @@
+package wallet
+
+import "testing"
+
+func TestExplorerTemplates(t *testing.T) {
+	templates := DefaultExplorerTemplates("https://testnet.dcrdata.org/")
+	if err := templates.Validate(); err != nil {
+		t.Fatalf("default templates are invalid: %v", err)
+	}
+
+	tests := []struct {
+		url, expected string
+	}{
+		{templates.TxURL("abc"), "https://testnet.dcrdata.org/tx/abc"},
+		{templates.AddressURL("TsAddr"), "https://testnet.dcrdata.org/address/TsAddr"},
+		{templates.BlockURL(1234), "https://testnet.dcrdata.org/block/1234"},
+		{templates.TicketURL("def"), "https://testnet.dcrdata.org/tx/def"},
+	}
+	for _, test := range tests {
+		if test.url != test.expected {
+			t.Errorf("expected %q, got %q", test.expected, test.url)
+		}
+	}
+
+	custom := ExplorerTemplates{Address: "https://example.org/addr?a={address}"}.withDefaults(templates)
+	if url := custom.AddressURL("TsAddr"); url != "https://example.org/addr?a=TsAddr" {
+		t.Errorf("unexpected custom address URL %q", url)
+	}
+	if custom.Tx != templates.Tx {
+		t.Errorf("expected the default tx template, got %q", custom.Tx)
+	}
+
+	invalid := []ExplorerTemplates{
+		{Tx: "https://example.org/tx", Address: templates.Address, Block: templates.Block, Ticket: templates.Ticket},
+		{Tx: "ftp://example.org/{hash}", Address: templates.Address, Block: templates.Block, Ticket: templates.Ticket},
+		{Tx: templates.Tx, Address: templates.Address, Block: "/block/{block}", Ticket: templates.Ticket},
+	}
+	for _, tmpl := range invalid {
+		if err := tmpl.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", tmpl)
+		}
+	}
+
+	if url := DefaultExplorerTemplates("").TxURL("abc"); url != "" {
+		t.Errorf("expected no URL without an explorer, got %q", url)
+	}
+}
@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"fmt"
+
+	"decred.org/dcrwallet/v2/wallet/txrules"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// Fee rates of the presets offered when sending, in atoms/kB. The low rate is
+// the default relay fee, the minimum accepted by the network.
+const (
+	FeeRateLow    = txrules.DefaultRelayFeePerKb
+	FeeRateNormal = 2 * FeeRateLow
+	FeeRateHigh   = 5 * FeeRateLow
+
+	// MaxFeeRate is the highest fee rate that can be entered, it guards
+	// against paying a fee of several DCR by mistake.
+	MaxFeeRate dcrutil.Amount = 1e6
+)
+
+// FeePreset is a fee rate offered for selection with the number of blocks a
+// transaction paying it is expected to wait before being mined.
+type FeePreset struct {
+	Rate   dcrutil.Amount
+	Blocks int
+}
+
+// FeePresets are the fee rates offered when sending, from the cheapest.
+var FeePresets = []FeePreset{
+	{FeeRateLow, 3},
+	{FeeRateNormal, 2},
+	{FeeRateHigh, 1},
+}
+
+// ConfirmationBlocks returns the estimated number of blocks a transaction
+// paying rate waits before being mined, that of the most expensive preset
+// rate pays for.
+func ConfirmationBlocks(rate dcrutil.Amount) int {
+	blocks := FeePresets[0].Blocks
+	for _, preset := range FeePresets {
+		if rate >= preset.Rate {
+			blocks = preset.Blocks
+		}
+	}
+	return blocks
+}
+
+// ValidateFeeRate returns an error if rate is below the relay fee, which the
+// network rejects, or above MaxFeeRate.
+func ValidateFeeRate(rate dcrutil.Amount) error {
+	if rate < FeeRateLow {
+		return fmt.Errorf("fee rate is below the minimum of %d atoms/kB", int64(FeeRateLow))
+	}
+	if rate > MaxFeeRate {
+		return fmt.Errorf("fee rate is above the maximum of %d atoms/kB", int64(MaxFeeRate))
+	}
+	return nil
+}
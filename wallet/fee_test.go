@@ -0,0 +1,31 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+func TestFeeRate(t *testing.T) {
+	tests := []struct {
+		rate   dcrutil.Amount
+		valid  bool
+		blocks int
+	}{
+		{FeeRateLow - 1, false, 3},
+		{FeeRateLow, true, 3},
+		{FeeRateNormal - 1, true, 3},
+		{FeeRateNormal, true, 2},
+		{FeeRateHigh, true, 1},
+		{MaxFeeRate, true, 1},
+		{MaxFeeRate + 1, false, 1},
+	}
+	for _, test := range tests {
+		if err := ValidateFeeRate(test.rate); (err == nil) != test.valid {
+			t.Errorf("%d: expected valid %v, got error %v", test.rate, test.valid, err)
+		}
+		if blocks := ConfirmationBlocks(test.rate); blocks != test.blocks {
+			t.Errorf("%d: expected %d blocks, got %d", test.rate, test.blocks, blocks)
+		}
+	}
+}
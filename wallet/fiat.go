@@ -0,0 +1,154 @@
+package wallet
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// defaultFiatDecimals is the number of decimal places used for currencies
+// that are not in ISO-4217, such as stablecoins quoted by exchanges.
+const defaultFiatDecimals = 2
+
+// suffixSymbolLanguages are the languages in which the currency symbol is
+// conventionally written after the amount.
+var suffixSymbolLanguages = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"it": true, "nb": true, "pl": true, "pt": true, "ru": true, "sv": true,
+}
+
+// FiatConverter converts DCR amounts to and from a fiat currency at a fixed
+// exchange rate, and formats fiat amounts according to the conventions of a
+// locale.
+type FiatConverter struct {
+	code        string
+	rate        float64
+	symbol      string
+	decimals    int
+	symbolAfter bool
+	printer     *message.Printer
+}
+
+// NewFiatConverter returns a FiatConverter for the currency and rate of
+// exchangeRate that formats amounts for the lang locale. Currencies that are
+// not in ISO-4217 are formatted with their code as the symbol.
+func NewFiatConverter(exchangeRate *ExchangeRate, lang language.Tag) *FiatConverter {
+	fc := &FiatConverter{
+		code:     strings.ToUpper(exchangeRate.Currency),
+		rate:     exchangeRate.Rate,
+		decimals: defaultFiatDecimals,
+		printer:  message.NewPrinter(lang),
+	}
+	fc.symbol = fc.code
+
+	if base, _ := lang.Base(); suffixSymbolLanguages[base.String()] {
+		fc.symbolAfter = true
+	}
+
+	unit, err := currency.ParseISO(fc.code)
+	if err != nil {
+		// Not a fiat currency, e.g. USDT. The code is shown after the amount
+		// like any other crypto asset.
+		fc.symbolAfter = true
+		return fc
+	}
+
+	fc.decimals, _ = currency.Standard.Rounding(unit)
+	fc.symbol = fc.printer.Sprint(currency.Symbol(unit))
+	if fc.symbol == fc.code {
+		// Prefer the narrow symbol (e.g. ₦ for NGN) over the ISO code when the
+		// locale has no dedicated symbol for the currency.
+		fc.symbol = fc.printer.Sprint(currency.NarrowSymbol(unit))
+	}
+
+	return fc
+}
+
+// Code returns the currency code, e.g. EUR.
+func (fc *FiatConverter) Code() string {
+	return fc.code
+}
+
+// Symbol returns the currency symbol for the converter's locale.
+func (fc *FiatConverter) Symbol() string {
+	return fc.symbol
+}
+
+// Decimals returns the number of decimal places fiat amounts are shown with.
+func (fc *FiatConverter) Decimals() int {
+	return fc.decimals
+}
+
+// Rate returns the value of 1 DCR in the fiat currency.
+func (fc *FiatConverter) Rate() float64 {
+	return fc.rate
+}
+
+// AtRate returns a copy of the converter that converts at rate, e.g. the rate
+// of the day a transaction was mined.
+func (fc *FiatConverter) AtRate(rate float64) *FiatConverter {
+	c := *fc
+	c.rate = rate
+	return &c
+}
+
+// ToFiat converts a DCR amount to the fiat currency.
+func (fc *FiatConverter) ToFiat(dcr float64) float64 {
+	return dcr * fc.rate
+}
+
+// ToDCR converts a fiat amount to DCR.
+func (fc *FiatConverter) ToDCR(fiat float64) float64 {
+	return fiat / fc.rate
+}
+
+// Format formats a fiat amount with the currency symbol placed and the number
+// of decimals chosen according to the locale and currency.
+func (fc *FiatConverter) Format(fiat float64) string {
+	return fc.FormatPrecise(fiat, fc.decimals)
+}
+
+// FormatPrecise is like Format but shows the specified number of decimals,
+// for amounts such as fees that are too small for the currency's precision.
+func (fc *FiatConverter) FormatPrecise(fiat float64, decimals int) string {
+	sign := ""
+	if fiat < 0 {
+		sign = "-"
+		fiat = -fiat
+	}
+
+	amount := fc.printer.Sprint(number.Decimal(fiat, number.Scale(decimals)))
+	if fc.symbolAfter {
+		return sign + amount + " " + fc.symbol
+	}
+
+	// Separate symbols that end in a letter (e.g. "US$" is fine, "CHF" isn't)
+	// from the amount.
+	separator := ""
+	if r := []rune(fc.symbol); unicode.IsLetter(r[len(r)-1]) {
+		separator = " "
+	}
+	return sign + fc.symbol + separator + amount
+}
+
+// FormatDCR converts a DCR amount to the fiat currency and formats it.
+func (fc *FiatConverter) FormatDCR(dcr float64) string {
+	return fc.Format(fc.ToFiat(dcr))
+}
+
+// FormatAmount converts an atom amount to the fiat currency and formats it.
+func (fc *FiatConverter) FormatAmount(amount dcrutil.Amount) string {
+	return fc.FormatDCR(amount.ToCoin())
+}
+
+// EditorText formats a fiat amount as plain text with the currency's number
+// of decimals, suitable for an input field.
+func (fc *FiatConverter) EditorText(fiat float64) string {
+	return strconv.FormatFloat(fiat, 'f', fc.decimals, 64)
+}
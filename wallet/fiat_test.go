@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestFiatConverterFormat(t *testing.T) {
+	tests := []struct {
+		currency string
+		lang     language.Tag
+		amount   float64
+		want     string
+	}{
+		{"USD", language.English, 1234.5, "$1,234.50"},
+		{"EUR", language.English, 1234.5, "€1,234.50"},
+		{"EUR", language.French, 1234.5, "1 234,50 €"},
+		{"GBP", language.English, -12.345, "-£12.35"},
+		{"NGN", language.English, 1234.5, "₦1,234.50"},
+		{"JPY", language.English, 1234.5, "¥1,234"},
+		{"USDT", language.English, 20.1, "20.10 USDT"},
+	}
+
+	for _, test := range tests {
+		fc := NewFiatConverter(&ExchangeRate{Currency: test.currency, Rate: 1}, test.lang)
+		if got := fc.Format(test.amount); got != test.want {
+			t.Errorf("%s/%s: expected %q, got %q", test.currency, test.lang, test.want, got)
+		}
+	}
+}
+
+func TestFiatConverterConversion(t *testing.T) {
+	fc := NewFiatConverter(&ExchangeRate{Currency: "eur", Rate: 20}, language.English)
+	if fc.Code() != "EUR" {
+		t.Fatalf("expected EUR, got %s", fc.Code())
+	}
+	if got := fc.ToFiat(2.5); got != 50 {
+		t.Fatalf("expected 50, got %v", got)
+	}
+	if got := fc.ToDCR(50); got != 2.5 {
+		t.Fatalf("expected 2.5, got %v", got)
+	}
+	if got := fc.EditorText(12.3); got != "12.30" {
+		t.Fatalf("expected 12.30, got %s", got)
+	}
+}
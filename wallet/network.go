@@ -0,0 +1,15 @@
+package wallet
+
+import "github.com/planetdecred/dcrlibwallet"
+
+// DefaultExplorerURL returns the base URL of the block explorer of net.
+func DefaultExplorerURL(net string) string {
+	switch net {
+	case dcrlibwallet.Testnet3:
+		return "https://testnet.dcrdata.org"
+	case "mainnet":
+		return "https://explorer.dcrdata.org"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,24 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestGetBlockExplorerURL(t *testing.T) {
+	tests := []struct {
+		net      string
+		expected string
+	}{
+		{"mainnet", "https://explorer.dcrdata.org/tx/abc"},
+		{dcrlibwallet.Testnet3, "https://testnet.dcrdata.org/tx/abc"},
+		{"unknown", ""},
+	}
+	for _, test := range tests {
+		wal := &Wallet{Net: test.net}
+		if url := wal.GetBlockExplorerURL("abc"); url != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.net, test.expected, url)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	w "decred.org/dcrwallet/v2/wallet"
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdscript"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// OfflineTxVersion is the version of the offline transaction file
+	// format written by this package.
+	OfflineTxVersion = 1
+
+	// OfflineTxExtension is the extension of the offline transaction files.
+	OfflineTxExtension = "dcrtx"
+)
+
+var (
+	// ErrOfflineTxNetwork is returned when an offline transaction is used on
+	// a network other than the one it was built for.
+	ErrOfflineTxNetwork = errors.New("the transaction is for another network")
+
+	// ErrOfflineTxNoWallet is returned when no wallet has the account an
+	// offline transaction spends from.
+	ErrOfflineTxNoWallet = errors.New("no wallet has the account the transaction spends from")
+)
+
+// OfflineTx is a transaction moved between a watch-only wallet, which builds
+// and publishes it, and a wallet with the private keys of the same account on
+// an offline machine, which signs it. It is saved to files as JSON.
+type OfflineTx struct {
+	Version int    `json:"version"`
+	Network string `json:"network"`
+	// AccountXpub is the extended public key of the account spent from,
+	// which finds the account in the signing wallet.
+	AccountXpub string `json:"account_xpub"`
+	// Tx is the hex encoded serialized transaction.
+	Tx     string           `json:"tx"`
+	Inputs []OfflineTxInput `json:"inputs"`
+}
+
+// OfflineTxInput is what the signing wallet needs to know about an input of
+// an OfflineTx, in the order of the inputs of the transaction.
+type OfflineTxInput struct {
+	// PkScript is the hex encoded script of the output spent.
+	PkScript string `json:"pk_script"`
+	// Branch and Index are the derivation path of the address of the output
+	// spent in the account.
+	Branch uint32 `json:"branch"`
+	Index  uint32 `json:"index"`
+}
+
+// DecodedOfflineTx is an OfflineTx as it is shown to the user.
+type DecodedOfflineTx struct {
+	Hash    string
+	Inputs  []DecodedTxIO
+	Outputs []DecodedTxIO
+	Fee     dcrutil.Amount
+	Size    int
+	// Signed is true if all the inputs are signed.
+	Signed bool
+	// FeeUnverified is true if the amount of an input, and so the fee, is
+	// only known from the transaction file.
+	FeeUnverified bool
+}
+
+// DecodedTxIO is an input or an output of a DecodedOfflineTx. Outpoint is
+// only set for inputs.
+type DecodedTxIO struct {
+	Outpoint string
+	Address  string
+	Amount   dcrutil.Amount
+	// Unverified is true for the inputs whose amount is only known from the
+	// transaction file.
+	Unverified bool
+	// Own is true for the outputs paying an address of the wallet the
+	// transaction was decoded for.
+	Own bool
+}
+
+// OfflineTx returns the transaction to be signed by a wallet with the private
+// keys of the account, which is how watch-only wallets spend.
+func (tx *TxAuthor) OfflineTx() (*OfflineTx, error) {
+	unsignedTx, err := tx.unsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if unsignedTx.ChangeIndex >= 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+
+	ctx := context.Background()
+	xpub, err := tx.wallet.Internal().AccountXpub(ctx, tx.account)
+	if err != nil {
+		return nil, err
+	}
+
+	otx := &OfflineTx{
+		Version:     OfflineTxVersion,
+		Network:     tx.params.Name,
+		AccountXpub: xpub.String(),
+		Inputs:      make([]OfflineTxInput, len(unsignedTx.PrevScripts)),
+	}
+	for i, pkScript := range unsignedTx.PrevScripts {
+		_, addrs := stdscript.ExtractAddrs(0, pkScript, tx.params)
+		if len(addrs) != 1 {
+			return nil, fmt.Errorf("input %d: unsupported script", i)
+		}
+		known, err := tx.wallet.Internal().KnownAddress(ctx, addrs[0])
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %v", i, err)
+		}
+		bip44, ok := known.(w.BIP0044Address)
+		if !ok {
+			return nil, fmt.Errorf("input %d: address %s is not derived from the account", i, addrs[0])
+		}
+		_, branch, index := bip44.Path()
+		otx.Inputs[i] = OfflineTxInput{
+			PkScript: hex.EncodeToString(pkScript),
+			Branch:   branch,
+			Index:    index,
+		}
+	}
+
+	if err = otx.setMsgTx(unsignedTx.Tx); err != nil {
+		return nil, err
+	}
+	// The transaction is signed and published elsewhere, it is built again
+	// if needed.
+	tx.unsignedTx = nil
+	return otx, nil
+}
+
+// ReadOfflineTx reads an OfflineTx written by WriteOfflineTx.
+func ReadOfflineTx(r io.Reader) (*OfflineTx, error) {
+	otx := new(OfflineTx)
+	if err := json.NewDecoder(r).Decode(otx); err != nil {
+		return nil, fmt.Errorf("invalid transaction file: %v", err)
+	}
+	if otx.Version != OfflineTxVersion {
+		return nil, fmt.Errorf("unsupported transaction file version %d", otx.Version)
+	}
+
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+	if len(msgTx.TxIn) != len(otx.Inputs) {
+		return nil, errors.New("invalid transaction file: inputs do not match the transaction")
+	}
+	return otx, nil
+}
+
+// WriteOfflineTx writes otx to wr as JSON.
+func WriteOfflineTx(wr io.Writer, otx *OfflineTx) error {
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", "  ")
+	return enc.Encode(otx)
+}
+
+// MsgTx returns the decoded transaction of otx.
+func (otx *OfflineTx) MsgTx() (*wire.MsgTx, error) {
+	serialized, err := hex.DecodeString(otx.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	msgTx := new(wire.MsgTx)
+	if err = msgTx.Deserialize(bytes.NewReader(serialized)); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	return msgTx, nil
+}
+
+func (otx *OfflineTx) setMsgTx(msgTx *wire.MsgTx) error {
+	serialized, err := msgTx.Bytes()
+	if err != nil {
+		return err
+	}
+	otx.Tx = hex.EncodeToString(serialized)
+	return nil
+}
+
+// prevScripts returns the scripts of the outputs spent by otx, keyed by their
+// outpoint in msgTx.
+func (otx *OfflineTx) prevScripts(msgTx *wire.MsgTx) (map[wire.OutPoint][]byte, error) {
+	scripts := make(map[wire.OutPoint][]byte, len(otx.Inputs))
+	for i, input := range otx.Inputs {
+		pkScript, err := hex.DecodeString(input.PkScript)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: invalid script: %v", i, err)
+		}
+		scripts[msgTx.TxIn[i].PreviousOutPoint] = pkScript
+	}
+	return scripts, nil
+}
+
+// Decode returns otx as it is shown to the user, after checking that it is a
+// transaction of the network of params. The amounts of the inputs are those
+// of the transaction file and are marked unverified.
+func (otx *OfflineTx) Decode(params *chaincfg.Params) (*DecodedOfflineTx, error) {
+	if otx.Network != params.Name {
+		return nil, ErrOfflineTxNetwork
+	}
+
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+	prevScripts, err := otx.prevScripts(msgTx)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &DecodedOfflineTx{
+		Hash:    msgTx.TxHash().String(),
+		Inputs:  make([]DecodedTxIO, len(msgTx.TxIn)),
+		Outputs: make([]DecodedTxIO, len(msgTx.TxOut)),
+		Size:    msgTx.SerializeSize(),
+		Signed:  true,
+	}
+	for i, in := range msgTx.TxIn {
+		decoded.Inputs[i] = DecodedTxIO{
+			Outpoint:   in.PreviousOutPoint.String(),
+			Address:    scriptAddress(prevScripts[in.PreviousOutPoint], params),
+			Amount:     dcrutil.Amount(in.ValueIn),
+			Unverified: true,
+		}
+		decoded.Fee += dcrutil.Amount(in.ValueIn)
+		decoded.Signed = decoded.Signed && len(in.SignatureScript) > 0
+	}
+	for i, out := range msgTx.TxOut {
+		decoded.Outputs[i] = DecodedTxIO{
+			Address: scriptAddress(out.PkScript, params),
+			Amount:  dcrutil.Amount(out.Value),
+		}
+		decoded.Fee -= dcrutil.Amount(out.Value)
+	}
+	decoded.FeeUnverified = len(msgTx.TxIn) > 0
+	return decoded, nil
+}
+
+// DecodeForWallet returns otx as it is shown to the user of wal. The amounts
+// of the inputs are checked against the outputs they spend if wal has the
+// transactions of these outputs, an error is returned if they differ. The
+// outputs paying addresses of wal are marked so that a change output paying
+// another wallet stands out.
+func (otx *OfflineTx) DecodeForWallet(wal *dcrlibwallet.Wallet) (*DecodedOfflineTx, error) {
+	decoded, err := otx.Decode(wal.Internal().ChainParams())
+	if err != nil {
+		return nil, err
+	}
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]*chainhash.Hash, len(msgTx.TxIn))
+	for i, in := range msgTx.TxIn {
+		hash := in.PreviousOutPoint.Hash
+		hashes[i] = &hash
+	}
+	prevTxs, _, err := wal.Internal().GetTransactionsByHashes(context.Background(), hashes)
+	if err != nil {
+		return nil, err
+	}
+	if err = decoded.checkWallet(msgTx, prevTxs, wal.HaveAddress); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// checkWallet verifies the amounts of the inputs of d, the decoding of msgTx,
+// against the outputs of prevTxs they spend, and marks the outputs paying an
+// address for which haveAddress returns true.
+func (d *DecodedOfflineTx) checkWallet(msgTx *wire.MsgTx, prevTxs []*wire.MsgTx, haveAddress func(string) bool) error {
+	byHash := make(map[chainhash.Hash]*wire.MsgTx, len(prevTxs))
+	for _, prevTx := range prevTxs {
+		byHash[prevTx.TxHash()] = prevTx
+	}
+
+	d.FeeUnverified = false
+	for i, in := range msgTx.TxIn {
+		prevTx, ok := byHash[in.PreviousOutPoint.Hash]
+		if !ok || int(in.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			d.FeeUnverified = true
+			continue
+		}
+		if prevTx.TxOut[in.PreviousOutPoint.Index].Value != in.ValueIn {
+			return fmt.Errorf("input %d: the amount of the transaction file does not match the output spent", i)
+		}
+		d.Inputs[i].Unverified = false
+	}
+
+	for i := range d.Outputs {
+		d.Outputs[i].Own = haveAddress(d.Outputs[i].Address)
+	}
+	return nil
+}
+
+// scriptAddress returns the address paid by pkScript, or its hex encoding if
+// it does not pay a single address.
+func scriptAddress(pkScript []byte, params *chaincfg.Params) string {
+	_, addrs := stdscript.ExtractAddrs(0, pkScript, params)
+	if len(addrs) != 1 {
+		return hex.EncodeToString(pkScript)
+	}
+	return addrs[0].String()
+}
+
+// FindOfflineTxAccount returns the wallet of wallets and the number of its
+// account with the extended public key otx spends from.
+// ErrOfflineTxNoWallet is returned if there is none. Watch-only wallets are
+// skipped if spendable is set.
+func FindOfflineTxAccount(wallets []*dcrlibwallet.Wallet, otx *OfflineTx, spendable bool) (*dcrlibwallet.Wallet, uint32, error) {
+	ctx := context.Background()
+	for _, wal := range wallets {
+		if spendable && wal.IsWatchingOnlyWallet() {
+			continue
+		}
+		accounts, err := wal.GetAccountsRaw()
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, account := range accounts.Acc {
+			xpub, err := wal.Internal().AccountXpub(ctx, uint32(account.Number))
+			if err == nil && xpub.String() == otx.AccountXpub {
+				return wal, uint32(account.Number), nil
+			}
+		}
+	}
+	return nil, 0, ErrOfflineTxNoWallet
+}
+
+// SignOfflineTx signs the inputs of otx with the private keys of account of
+// wal, unlocked with privatePassphrase, and returns the signed transaction.
+// privatePassphrase is zeroed.
+func SignOfflineTx(wal *dcrlibwallet.Wallet, account uint32, otx *OfflineTx, privatePassphrase []byte) (*OfflineTx, error) {
+	defer func() {
+		for i := range privatePassphrase {
+			privatePassphrase[i] = 0
+		}
+	}()
+
+	params := wal.Internal().ChainParams()
+	if otx.Network != params.Name {
+		return nil, ErrOfflineTxNetwork
+	}
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		return nil, err
+	}
+	prevScripts, err := otx.prevScripts(msgTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The offline wallet has not seen the addresses of the inputs, which
+	// must be derived for their private keys to be found.
+	ctx := context.Background()
+	for _, input := range otx.Inputs {
+		if err = wal.Internal().SyncLastReturnedAddress(ctx, account, input.Branch, input.Index); err != nil {
+			return nil, err
+		}
+	}
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+	if err = wal.Internal().Unlock(ctx, privatePassphrase, lock); err != nil {
+		log.Error(err)
+		return nil, ErrBadPass
+	}
+
+	sigErrs, err := wal.Internal().SignTransaction(ctx, msgTx, txscript.SigHashAll, prevScripts, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(sigErrs) > 0 {
+		return nil, fmt.Errorf("input %d: %v", sigErrs[0].InputIndex, sigErrs[0].Error)
+	}
+
+	signed := *otx
+	if err = signed.setMsgTx(msgTx); err != nil {
+		return nil, err
+	}
+	return &signed, nil
+}
+
+// PublishOfflineTx publishes otx, which must be signed, through the network
+// backend of wal. The hash of the transaction is returned.
+func PublishOfflineTx(wal *dcrlibwallet.Wallet, otx *OfflineTx) (string, error) {
+	decoded, err := otx.DecodeForWallet(wal)
+	if err != nil {
+		return "", err
+	}
+	if !decoded.Signed {
+		return "", errors.New("the transaction is not signed")
+	}
+
+	n, err := wal.Internal().NetworkBackend()
+	if err != nil {
+		return "", err
+	}
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		return "", err
+	}
+	hash, err := wal.Internal().PublishTransaction(context.Background(), msgTx, n)
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
@@ -0,0 +1,157 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+)
+
+func testOfflineTx(t *testing.T, params *chaincfg.Params) (*OfflineTx, []string) {
+	t.Helper()
+
+	var addrs []string
+	var scripts [][]byte
+	for i := byte(0); i < 3; i++ {
+		addr, err := stdaddr.NewAddressPubKeyHashEcdsaSecp256k1V0(bytes.Repeat([]byte{i}, 20), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, script := addr.PaymentScript()
+		addrs = append(addrs, addr.String())
+		scripts = append(scripts, script)
+	}
+
+	msgTx := wire.NewMsgTx()
+	otx := &OfflineTx{Version: OfflineTxVersion, Network: params.Name, AccountXpub: "xpub"}
+	for i, amount := range []int64{3e8, 2e8} {
+		hash := chainhash.Hash{byte(i + 1)}
+		msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&hash, uint32(i), wire.TxTreeRegular), amount, nil))
+		otx.Inputs = append(otx.Inputs, OfflineTxInput{PkScript: hex.EncodeToString(scripts[i]), Index: uint32(i)})
+	}
+	msgTx.AddTxOut(wire.NewTxOut(4e8, scripts[2]))
+	msgTx.AddTxOut(wire.NewTxOut(99990000, scripts[0]))
+	if err := otx.setMsgTx(msgTx); err != nil {
+		t.Fatal(err)
+	}
+	return otx, addrs
+}
+
+func TestOfflineTxRoundTrip(t *testing.T) {
+	params := chaincfg.TestNet3Params()
+	otx, addrs := testOfflineTx(t, params)
+
+	var buf bytes.Buffer
+	if err := WriteOfflineTx(&buf, otx); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadOfflineTx(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := read.Decode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Inputs) != 2 || len(decoded.Outputs) != 2 {
+		t.Fatalf("expected 2 inputs and 2 outputs, got %d and %d", len(decoded.Inputs), len(decoded.Outputs))
+	}
+	if decoded.Inputs[1].Address != addrs[1] || decoded.Inputs[1].Amount != 2e8 {
+		t.Errorf("unexpected input %+v", decoded.Inputs[1])
+	}
+	if decoded.Outputs[0].Address != addrs[2] || decoded.Outputs[0].Amount != 4e8 {
+		t.Errorf("unexpected output %+v", decoded.Outputs[0])
+	}
+	if decoded.Fee != dcrutil.Amount(10000) {
+		t.Errorf("expected a fee of 10000 atoms, got %d", int64(decoded.Fee))
+	}
+	if decoded.Signed {
+		t.Error("expected the transaction not to be signed")
+	}
+
+	if _, err = read.Decode(chaincfg.MainNetParams()); err != ErrOfflineTxNetwork {
+		t.Errorf("expected %v, got %v", ErrOfflineTxNetwork, err)
+	}
+}
+
+func TestReadOfflineTxInvalid(t *testing.T) {
+	otx, _ := testOfflineTx(t, chaincfg.TestNet3Params())
+
+	tests := map[string]func(*OfflineTx){
+		"version": func(otx *OfflineTx) { otx.Version++ },
+		"tx":      func(otx *OfflineTx) { otx.Tx = "00" },
+		"inputs":  func(otx *OfflineTx) { otx.Inputs = otx.Inputs[1:] },
+	}
+	for name, change := range tests {
+		invalid := *otx
+		change(&invalid)
+
+		var buf bytes.Buffer
+		if err := WriteOfflineTx(&buf, &invalid); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ReadOfflineTx(&buf); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := ReadOfflineTx(bytes.NewBufferString("not json")); err == nil {
+		t.Error("expected an error reading an invalid file")
+	}
+}
+
+func TestOfflineTxCheckWallet(t *testing.T) {
+	params := chaincfg.TestNet3Params()
+	otx, addrs := testOfflineTx(t, params)
+	msgTx, err := otx.MsgTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The wallet has the transaction spent by the first input only.
+	prevTx := wire.NewMsgTx()
+	prevTx.AddTxOut(wire.NewTxOut(3e8, nil))
+	prevHash := prevTx.TxHash()
+	msgTx.TxIn[0].PreviousOutPoint = *wire.NewOutPoint(&prevHash, 0, wire.TxTreeRegular)
+	if err = otx.setMsgTx(msgTx); err != nil {
+		t.Fatal(err)
+	}
+	haveAddress := func(addr string) bool { return addr == addrs[0] }
+
+	decoded, err := otx.Decode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.FeeUnverified || !decoded.Inputs[0].Unverified {
+		t.Fatalf("expected the amounts of a decoded file to be unverified")
+	}
+	if err = decoded.checkWallet(msgTx, []*wire.MsgTx{prevTx}, haveAddress); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Inputs[0].Unverified || !decoded.Inputs[1].Unverified || !decoded.FeeUnverified {
+		t.Errorf("expected only the first input to be verified, got %+v (fee unverified %v)", decoded.Inputs, decoded.FeeUnverified)
+	}
+	if decoded.Outputs[0].Own || !decoded.Outputs[1].Own {
+		t.Errorf("expected only the second output to be marked own, got %+v", decoded.Outputs)
+	}
+
+	// An input amount changed in the file does not match the output spent.
+	prevTx.TxOut[0].Value = 25e7
+	msgTx.TxIn[0].PreviousOutPoint.Hash = prevTx.TxHash()
+	if err = otx.setMsgTx(msgTx); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err = otx.Decode(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = decoded.checkWallet(msgTx, []*wire.MsgTx{prevTx}, haveAddress); err == nil {
+		t.Error("expected an error for an input amount that does not match the output spent")
+	}
+}
@@ -0,0 +1,272 @@
+package wallet
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// PaymentRequestsConfigKey is the user config key of the payment requests.
+const PaymentRequestsConfigKey = "payment_requests"
+
+// PaymentRequestStatus is the state of a payment request.
+type PaymentRequestStatus int
+
+const (
+	PaymentRequestUnpaid PaymentRequestStatus = iota
+	PaymentRequestPartiallyPaid
+	PaymentRequestPaid
+	PaymentRequestExpired
+)
+
+func (s PaymentRequestStatus) String() string {
+	switch s {
+	case PaymentRequestPartiallyPaid:
+		return "partially paid"
+	case PaymentRequestPaid:
+		return "paid"
+	case PaymentRequestExpired:
+		return "expired"
+	default:
+		return "unpaid"
+	}
+}
+
+var (
+	// ErrPaymentRequestAmount is returned when a payment request is created
+	// with a negative amount.
+	ErrPaymentRequestAmount = errors.New("the payment request amount is invalid")
+
+	// ErrPaymentRequestExpiry is returned when a payment request is created
+	// already expired.
+	ErrPaymentRequestExpiry = errors.New("the payment request is already expired")
+
+	// ErrPaymentRequestAddress is returned when no address that isn't used
+	// by another payment request is available.
+	ErrPaymentRequestAddress = errors.New("no unused address is available for the payment request")
+)
+
+// PaymentRequest is a request for a payment to a fresh address of an account,
+// marked paid once the address receives the amount requested.
+type PaymentRequest struct {
+	ID       string `json:"id"`
+	WalletID int    `json:"wallet_id"`
+	Account  int32  `json:"account"`
+	Address  string `json:"address"`
+	// Amount is the amount requested in atoms, 0 if any amount is accepted.
+	Amount    int64     `json:"amount"`
+	Memo      string    `json:"memo,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Expiry is the time the request expires, it is zero if the request
+	// doesn't expire.
+	Expiry time.Time `json:"expiry"`
+	// Received is the amount received by the address, in atoms, in the
+	// transactions with TxHashes.
+	Received int64    `json:"received"`
+	TxHashes []string `json:"tx_hashes,omitempty"`
+}
+
+// Status returns the state of r at now. A request paid after it expired is
+// paid.
+func (r *PaymentRequest) Status(now time.Time) PaymentRequestStatus {
+	switch {
+	case r.Received > 0 && r.Received >= r.Amount:
+		return PaymentRequestPaid
+	case !r.Expiry.IsZero() && !now.Before(r.Expiry):
+		return PaymentRequestExpired
+	case r.Received > 0:
+		return PaymentRequestPartiallyPaid
+	default:
+		return PaymentRequestUnpaid
+	}
+}
+
+// URI returns the payment URI of r, with its memo as message.
+func (r *PaymentRequest) URI() string {
+	return PaymentURI{Address: r.Address, Amount: dcrutil.Amount(r.Amount), Message: r.Memo}.String()
+}
+
+// applyTx adds the outputs of tx paying the address of r to what r received.
+// It returns false if tx doesn't pay r or was already applied.
+func (r *PaymentRequest) applyTx(tx *dcrlibwallet.Transaction) bool {
+	if tx.WalletID != r.WalletID {
+		return false
+	}
+	for _, hash := range r.TxHashes {
+		if hash == tx.Hash {
+			return false
+		}
+	}
+
+	var received int64
+	for _, out := range tx.Outputs {
+		if out.Address == r.Address {
+			received += out.Amount
+		}
+	}
+	if received == 0 {
+		return false
+	}
+	r.Received += received
+	r.TxHashes = append(r.TxHashes, tx.Hash)
+	return true
+}
+
+// PaymentRequests returns the payment requests, the most recent first.
+func (wal *Wallet) PaymentRequests() []PaymentRequest {
+	var requests []PaymentRequest
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(PaymentRequestsConfigKey, &requests); err != nil {
+			return nil
+		}
+	}
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.After(requests[j].CreatedAt)
+	})
+	return requests
+}
+
+// PaymentRequestsRevision returns a number changed every time the payment
+// requests are changed, the requests listed are read again when it changes.
+func (wal *Wallet) PaymentRequestsRevision() int {
+	wal.requestsMtx.Lock()
+	defer wal.requestsMtx.Unlock()
+	return wal.requestsRev
+}
+
+// savePaymentRequests saves requests, wal.requestsMtx must be held.
+func (wal *Wallet) savePaymentRequests(requests []PaymentRequest) {
+	wal.multi.SaveUserConfigValue(PaymentRequestsConfigKey, requests)
+	wal.requestsRev++
+}
+
+// CreatePaymentRequest creates and saves a request for a payment of amount
+// atoms, or of any amount if it is 0, to a fresh address of account of w. The
+// request expires at expiry unless it is zero.
+func (wal *Wallet) CreatePaymentRequest(w *dcrlibwallet.Wallet, account int32, amount int64, memo string, expiry time.Time) (*PaymentRequest, error) {
+	now := time.Now()
+	if amount < 0 {
+		return nil, ErrPaymentRequestAmount
+	}
+	if !expiry.IsZero() && !expiry.After(now) {
+		return nil, ErrPaymentRequestExpiry
+	}
+
+	wal.requestsMtx.Lock()
+	defer wal.requestsMtx.Unlock()
+	requests := wal.PaymentRequests()
+	reserved := make(map[string]bool, len(requests))
+	for _, r := range requests {
+		reserved[r.Address] = true
+	}
+
+	// The wallet returns the unused addresses again past the gap limit,
+	// which may be those of other requests.
+	var address string
+	for i := uint32(0); i <= dcrlibwallet.AddressGapLimit; i++ {
+		addr, err := w.NextAddress(account)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved[addr] {
+			address = addr
+			break
+		}
+	}
+	if address == "" {
+		return nil, ErrPaymentRequestAddress
+	}
+
+	r := PaymentRequest{
+		ID:        strconv.FormatInt(now.UnixNano(), 36),
+		WalletID:  w.ID,
+		Account:   account,
+		Address:   address,
+		Amount:    amount,
+		Memo:      strings.TrimSpace(memo),
+		CreatedAt: now,
+		Expiry:    expiry,
+	}
+	wal.savePaymentRequests(append(requests, r))
+	return &r, nil
+}
+
+// DeletePaymentRequest removes the payment request with id.
+func (wal *Wallet) DeletePaymentRequest(id string) {
+	wal.requestsMtx.Lock()
+	defer wal.requestsMtx.Unlock()
+	requests := wal.PaymentRequests()
+	kept := requests[:0]
+	for _, r := range requests {
+		if r.ID != id {
+			kept = append(kept, r)
+		}
+	}
+	wal.savePaymentRequests(kept)
+}
+
+// UpdatePaymentRequests adds the payments of tx to the payment requests it
+// pays. The requests updated are returned, none if tx was already applied.
+func (wal *Wallet) UpdatePaymentRequests(tx *dcrlibwallet.Transaction) []PaymentRequest {
+	wal.requestsMtx.Lock()
+	defer wal.requestsMtx.Unlock()
+	requests := wal.PaymentRequests()
+	var updated []PaymentRequest
+	for i := range requests {
+		if requests[i].applyTx(tx) {
+			updated = append(updated, requests[i])
+		}
+	}
+	if len(updated) > 0 {
+		wal.savePaymentRequests(requests)
+	}
+	return updated
+}
+
+// RefreshPaymentRequests adds the payments received while the app wasn't
+// running to the payment requests, from the transactions of their wallets.
+// It reads every transaction of the wallets and should not be called on the
+// UI goroutine.
+func (wal *Wallet) RefreshPaymentRequests() error {
+	wallets := make(map[int]bool)
+	for _, r := range wal.PaymentRequests() {
+		wallets[r.WalletID] = true
+	}
+
+	var txs []dcrlibwallet.Transaction
+	for walletID := range wallets {
+		w := wal.multi.WalletWithID(walletID)
+		if w == nil {
+			continue
+		}
+		walletTxs, err := w.GetTransactionsRaw(0, 0, dcrlibwallet.TxFilterAll, true)
+		if err != nil {
+			return err
+		}
+		txs = append(txs, walletTxs...)
+	}
+
+	// The requests are read again, they may have changed while the
+	// transactions were read.
+	wal.requestsMtx.Lock()
+	defer wal.requestsMtx.Unlock()
+	requests := wal.PaymentRequests()
+	changed := false
+	for i := range txs {
+		for j := range requests {
+			if requests[j].applyTx(&txs[i]) {
+				changed = true
+			}
+		}
+	}
+
+	if changed {
+		wal.savePaymentRequests(requests)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestPaymentRequestStatus(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		amount   int64
+		received int64
+		expiry   time.Time
+		status   PaymentRequestStatus
+	}{
+		{"unpaid", 100, 0, time.Time{}, PaymentRequestUnpaid},
+		{"unpaid before expiry", 100, 0, now.Add(time.Hour), PaymentRequestUnpaid},
+		{"partially paid", 100, 40, time.Time{}, PaymentRequestPartiallyPaid},
+		{"paid", 100, 100, time.Time{}, PaymentRequestPaid},
+		{"overpaid", 100, 150, time.Time{}, PaymentRequestPaid},
+		{"any amount unpaid", 0, 0, time.Time{}, PaymentRequestUnpaid},
+		{"any amount paid", 0, 1, time.Time{}, PaymentRequestPaid},
+		{"expired", 100, 0, now, PaymentRequestExpired},
+		{"expired partially paid", 100, 40, now.Add(-time.Hour), PaymentRequestExpired},
+		{"paid after expiry", 100, 100, now.Add(-time.Hour), PaymentRequestPaid},
+	}
+	for _, test := range tests {
+		r := PaymentRequest{Amount: test.amount, Received: test.received, Expiry: test.expiry}
+		if status := r.Status(now); status != test.status {
+			t.Errorf("%s: status %s, expected %s", test.name, status, test.status)
+		}
+	}
+}
+
+func TestPaymentRequestApplyTx(t *testing.T) {
+	r := PaymentRequest{WalletID: 1, Address: "DsAddress", Amount: 100}
+	tx := &dcrlibwallet.Transaction{
+		WalletID: 1,
+		Hash:     "tx1",
+		Outputs: []*dcrlibwallet.TxOutput{
+			{Address: "DsAddress", Amount: 30},
+			{Address: "DsOther", Amount: 500},
+			{Address: "DsAddress", Amount: 10},
+		},
+	}
+
+	if !r.applyTx(tx) || r.Received != 40 || len(r.TxHashes) != 1 {
+		t.Fatalf("unexpected request after the first payment: %+v", r)
+	}
+	if r.applyTx(tx) || r.Received != 40 {
+		t.Errorf("a transaction was applied twice: %+v", r)
+	}
+
+	otherWallet := *tx
+	otherWallet.WalletID, otherWallet.Hash = 2, "tx2"
+	if r.applyTx(&otherWallet) {
+		t.Error("a transaction of another wallet was applied")
+	}
+
+	unrelated := &dcrlibwallet.Transaction{WalletID: 1, Hash: "tx3", Outputs: []*dcrlibwallet.TxOutput{{Address: "DsOther", Amount: 60}}}
+	if r.applyTx(unrelated) {
+		t.Error("a transaction not paying the request was applied")
+	}
+
+	second := &dcrlibwallet.Transaction{WalletID: 1, Hash: "tx4", Outputs: []*dcrlibwallet.TxOutput{{Address: "DsAddress", Amount: 60}}}
+	if !r.applyTx(second) || r.Status(time.Now()) != PaymentRequestPaid {
+		t.Errorf("the request is not paid: %+v", r)
+	}
+}
+
+func TestPaymentRequestURI(t *testing.T) {
+	r := PaymentRequest{Address: "DsAddress", Amount: 150000000, Memo: "Invoice 12"}
+	if uri := r.URI(); uri != "decred:DsAddress?amount=1.5&message=Invoice%2012" {
+		t.Errorf("unexpected URI %s", uri)
+	}
+	r = PaymentRequest{Address: "DsAddress"}
+	if uri := r.URI(); uri != "decred:DsAddress" {
+		t.Errorf("unexpected URI %s", uri)
+	}
+}
@@ -0,0 +1,362 @@
+package wallet
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PriceHistoryFile is the name of the file the price history is saved to
+	// in the app data directory.
+	PriceHistoryFile = "price_history.json"
+
+	// priceHistoryDayLayout is the format of the days the price history is
+	// keyed by, both in the saved file and in imported CSV files.
+	priceHistoryDayLayout = "2006-01-02"
+
+	// historicalRangeDays is the most days of rates requested at once from a
+	// HistoricalRangeProvider.
+	historicalRangeDays = 90
+)
+
+// ErrNoHistoricalRate is returned when the rate of a past day is not in the
+// price history and cannot be fetched from any provider.
+var ErrNoHistoricalRate = errors.New("no historical exchange rate available")
+
+// HistoricalRateProvider is implemented by exchange rate providers that can
+// report the value of DCR on a past day.
+type HistoricalRateProvider interface {
+	ExchangeRateProvider
+	// FetchHistoricalRate queries the provider for the value of 1 DCR in the
+	// specified currency on the UTC day of t.
+	FetchHistoricalRate(ctx context.Context, currency string, t time.Time) (*ExchangeRate, error)
+}
+
+// HistoricalRangeProvider is implemented by historical rate providers that can
+// report the rates of a range of days in a single request.
+type HistoricalRangeProvider interface {
+	HistoricalRateProvider
+	// FetchHistoricalRates queries the provider for the daily values of 1 DCR
+	// in the specified currency from the UTC day of from to the UTC day of
+	// to. The days the provider has no rate for are omitted.
+	FetchHistoricalRates(ctx context.Context, currency string, from, to time.Time) ([]*ExchangeRate, error)
+}
+
+// PriceHistory is a local store of daily DCR exchange rates keyed by currency
+// and UTC day. It is used to value transactions at the time they were mined.
+type PriceHistory struct {
+	mtx    sync.RWMutex
+	path   string
+	prices map[string]map[string]float64 // currency -> day -> rate
+	fills  map[string]*sync.Mutex        // currency -> held while filling
+}
+
+// NewPriceHistory returns a PriceHistory backed by the file at path. The
+// prices saved in the file are loaded if it exists. A file that cannot be
+// parsed is moved aside to path with a ".bad" suffix, and the history starts
+// empty.
+func NewPriceHistory(path string) (*PriceHistory, error) {
+	ph := &PriceHistory{
+		path:   path,
+		prices: make(map[string]map[string]float64),
+		fills:  make(map[string]*sync.Mutex),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ph, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &ph.prices); err != nil {
+		log.Errorf("invalid price history file %s, starting with an empty history: %v", path, err)
+		ph.prices = make(map[string]map[string]float64)
+		if err = os.Rename(path, path+".bad"); err != nil {
+			log.Errorf("error moving the invalid price history file aside: %v", err)
+		}
+	}
+
+	return ph, nil
+}
+
+func priceHistoryDay(t time.Time) string {
+	return t.UTC().Format(priceHistoryDayLayout)
+}
+
+// Rate returns the value of 1 DCR in currency on the UTC day of t. ok is false
+// if the rate of that day is not in the price history.
+func (ph *PriceHistory) Rate(currency string, t time.Time) (rate float64, ok bool) {
+	ph.mtx.RLock()
+	defer ph.mtx.RUnlock()
+	rate, ok = ph.prices[strings.ToUpper(currency)][priceHistoryDay(t)]
+	return
+}
+
+// Add saves the value of 1 DCR in currency on the UTC day of t, replacing any
+// rate previously saved for that day.
+func (ph *PriceHistory) Add(currency string, t time.Time, rate float64) error {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	ph.set(currency, t, rate)
+	return ph.save()
+}
+
+func (ph *PriceHistory) set(currency string, t time.Time, rate float64) {
+	currency = strings.ToUpper(currency)
+	if ph.prices[currency] == nil {
+		ph.prices[currency] = make(map[string]float64)
+	}
+	ph.prices[currency][priceHistoryDay(t)] = rate
+}
+
+// save writes the price history to a temporary file which then replaces the
+// saved file so that an interrupted write does not lose the history. The
+// caller must hold the write lock.
+func (ph *PriceHistory) save() error {
+	data, err := json.Marshal(ph.prices)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(ph.path), 0700); err != nil {
+		return err
+	}
+
+	tmpPath := ph.path + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, ph.path)
+}
+
+// ImportCSV adds the rates read from r to the price history and returns the
+// number of rates imported. Each record must have the fields date, currency
+// and rate, e.g. "2021-06-01,USD,150.25". A header line is skipped.
+func (ph *PriceHistory) ImportCSV(r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return 0, err
+	}
+
+	type price struct {
+		currency string
+		day      time.Time
+		rate     float64
+	}
+	prices := make([]price, 0, len(records))
+	for i, record := range records {
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+		}
+
+		day, err := time.Parse(priceHistoryDayLayout, record[0])
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return 0, fmt.Errorf("line %d: invalid date %q", i+1, record[0])
+		}
+
+		rate, err := strconv.ParseFloat(record[2], 64)
+		if err != nil || rate <= 0 {
+			return 0, fmt.Errorf("line %d: invalid rate %q", i+1, record[2])
+		}
+
+		if record[1] == "" {
+			return 0, fmt.Errorf("line %d: missing currency", i+1)
+		}
+
+		prices = append(prices, price{record[1], day, rate})
+	}
+
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	for _, p := range prices {
+		ph.set(p.currency, p.day, p.rate)
+	}
+	return len(prices), ph.save()
+}
+
+// FetchRate returns the value of 1 DCR in currency on the UTC day of t. If the
+// rate is not in the price history, it is fetched from provider, or from any
+// other registered provider if provider cannot report past rates, and saved.
+func (ph *PriceHistory) FetchRate(ctx context.Context, er *ExchangeRates, provider, currency string, t time.Time) (float64, error) {
+	if rate, ok := ph.Rate(currency, t); ok {
+		return rate, nil
+	}
+
+	p := er.historicalProvider(provider, currency)
+	if p == nil {
+		return 0, ErrNoHistoricalRate
+	}
+
+	rate, err := p.FetchHistoricalRate(ctx, currency, t)
+	if err != nil {
+		return 0, err
+	}
+
+	return rate.Rate, ph.Add(currency, t, rate.Rate)
+}
+
+// Fill fetches the rates of the days of times that are missing from the
+// price history and saves them once they are all fetched. The days whose rate
+// cannot be fetched are skipped, the first error is returned with the number
+// of rates added.
+//
+// Fills of the same currency run one at a time: the days fetched by a fill
+// are not fetched again by the fills waiting for it.
+func (ph *PriceHistory) Fill(ctx context.Context, er *ExchangeRates, provider, currency string, times []time.Time) (int, error) {
+	currency = strings.ToUpper(currency)
+	fill := ph.fillLock(currency)
+	fill.Lock()
+	defer fill.Unlock()
+
+	missing := ph.missingDays(currency, times)
+	if len(missing) == 0 {
+		return 0, nil
+	}
+
+	p := er.historicalProvider(provider, currency)
+	if p == nil {
+		return 0, ErrNoHistoricalRate
+	}
+
+	var rates []*ExchangeRate
+	var firstErr error
+	if rp, ok := p.(HistoricalRangeProvider); ok {
+		rates, firstErr = fetchHistoricalRanges(ctx, rp, currency, missing)
+	} else {
+		for _, day := range missing {
+			if ctx.Err() != nil {
+				break
+			}
+			rate, err := p.FetchHistoricalRate(ctx, currency, day)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			rate.Timestamp = day
+			rates = append(rates, rate)
+		}
+	}
+
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	var added int
+	for _, rate := range rates {
+		if _, ok := ph.prices[currency][priceHistoryDay(rate.Timestamp)]; ok {
+			continue
+		}
+		ph.set(currency, rate.Timestamp, rate.Rate)
+		added++
+	}
+	if added > 0 {
+		if err := ph.save(); err != nil {
+			return added, err
+		}
+	}
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr == nil {
+		// The provider may have no rate for some of the days.
+		for _, day := range missing {
+			if _, ok := ph.prices[currency][priceHistoryDay(day)]; !ok {
+				firstErr = ErrNoHistoricalRate
+				break
+			}
+		}
+	}
+	return added, firstErr
+}
+
+// fillLock returns the lock held while the price history of currency is
+// filled.
+func (ph *PriceHistory) fillLock(currency string) *sync.Mutex {
+	ph.mtx.Lock()
+	defer ph.mtx.Unlock()
+	if ph.fills[currency] == nil {
+		ph.fills[currency] = new(sync.Mutex)
+	}
+	return ph.fills[currency]
+}
+
+// missingDays returns the UTC days of times, oldest first, that have no rate
+// in currency.
+func (ph *PriceHistory) missingDays(currency string, times []time.Time) []time.Time {
+	ph.mtx.RLock()
+	defer ph.mtx.RUnlock()
+
+	var missing []time.Time
+	seen := make(map[string]bool)
+	for _, t := range times {
+		day := priceHistoryDay(t)
+		if _, ok := ph.prices[currency][day]; ok || seen[day] {
+			continue
+		}
+		seen[day] = true
+		missing = append(missing, t.UTC().Truncate(24*time.Hour))
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Before(missing[j]) })
+	return missing
+}
+
+// fetchHistoricalRanges fetches the rates of days, sorted oldest first, with
+// a request per range of up to historicalRangeDays days. The ranges that
+// cannot be fetched are skipped, the first error is returned with the rates
+// fetched.
+func fetchHistoricalRanges(ctx context.Context, p HistoricalRangeProvider, currency string, days []time.Time) ([]*ExchangeRate, error) {
+	var rates []*ExchangeRate
+	var firstErr error
+	for start := 0; start < len(days) && ctx.Err() == nil; {
+		end := start + 1
+		for end < len(days) && days[end].Sub(days[start]) < historicalRangeDays*24*time.Hour {
+			end++
+		}
+
+		fetched, err := p.FetchHistoricalRates(ctx, currency, days[start], days[end-1])
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		rates = append(rates, fetched...)
+		start = end
+	}
+	return rates, firstErr
+}
+
+// historicalProvider returns the provider past rates of currency are fetched
+// from. The named provider is preferred, any other provider that can quote
+// currency is used otherwise.
+func (er *ExchangeRates) historicalProvider(name, currency string) HistoricalRateProvider {
+	if p, ok := er.Provider(name); ok {
+		if hp, ok := p.(HistoricalRateProvider); ok && supportsCurrency(p, currency) {
+			return hp
+		}
+	}
+
+	for _, name := range er.Providers() {
+		p, _ := er.Provider(name)
+		if hp, ok := p.(HistoricalRateProvider); ok && supportsCurrency(p, currency) {
+			return hp
+		}
+	}
+	return nil
+}
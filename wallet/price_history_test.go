@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPriceHistoryImportCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PriceHistoryFile)
+	ph, err := NewPriceHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	csv := "date,currency,rate\n2021-06-01,USD,150.25\n2021-06-02, eur ,120.5\n"
+	n, err := ph.ImportCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 rates imported, got %d", n)
+	}
+
+	if _, err = ph.ImportCSV(strings.NewReader("2021-06-03,USD,abc\n")); err == nil {
+		t.Fatal("expected error for invalid rate")
+	}
+
+	// Reload from disk to check the imported rates were saved.
+	ph, err = NewPriceHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2021, 6, 2, 18, 30, 0, 0, time.UTC)
+	if rate, ok := ph.Rate("EUR", day); !ok || rate != 120.5 {
+		t.Fatalf("expected EUR rate 120.5, got %v (%v)", rate, ok)
+	}
+	if _, ok := ph.Rate("USD", day); ok {
+		t.Fatal("unexpected USD rate")
+	}
+}
+
+func TestPriceHistoryInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PriceHistoryFile)
+	if err := ioutil.WriteFile(path, []byte("{\"USD\": {"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ph, err := NewPriceHistory(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := ph.Rate("USD", time.Now()); ok {
+		t.Fatal("unexpected USD rate")
+	}
+	if _, err = os.Stat(path + ".bad"); err != nil {
+		t.Fatalf("expected the invalid file to be moved aside: %v", err)
+	}
+	if _, err = os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no price history file, got %v", err)
+	}
+}
+
+func TestPriceHistoryFetchRate(t *testing.T) {
+	var hits int32
+	server := newRateServer(t, &hits)
+	rates := NewExchangeRates(NewDcrdataProvider(server.URL), NewCoinGeckoProvider(server.URL))
+
+	ph, err := NewPriceHistory(filepath.Join(t.TempDir(), PriceHistoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	times := []time.Time{day, day.Add(time.Hour), day.Add(2 * time.Hour)}
+
+	// dcrdata has no price history, the rate is fetched from CoinGecko.
+	added, err := ph.Fill(context.Background(), rates, DcrdataProvider, "USD", times)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if added != 1 || hits != 1 {
+		t.Fatalf("expected 1 rate fetched, got %d (%d requests)", added, hits)
+	}
+
+	rate, err := ph.FetchRate(context.Background(), rates, DcrdataProvider, "USD", day)
+	if err != nil || rate != 15.5 {
+		t.Fatalf("expected rate 15.5, got %v (%v)", rate, err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected stored rate to be used, got %d requests", hits)
+	}
+}
+
+// testHistoricalProvider reports a rate for every day but those in fail, and
+// counts the rates requested.
+type testHistoricalProvider struct {
+	fail     map[string]bool
+	requests int32
+}
+
+func (p *testHistoricalProvider) Name() string         { return "test" }
+func (p *testHistoricalProvider) Currencies() []string { return []string{"USD"} }
+
+func (p *testHistoricalProvider) FetchRate(ctx context.Context, currency string) (*ExchangeRate, error) {
+	return p.FetchHistoricalRate(ctx, currency, time.Now())
+}
+
+func (p *testHistoricalProvider) FetchHistoricalRate(_ context.Context, currency string, t time.Time) (*ExchangeRate, error) {
+	atomic.AddInt32(&p.requests, 1)
+	if p.fail[priceHistoryDay(t)] {
+		return nil, errors.New("no rate")
+	}
+	return &ExchangeRate{Provider: p.Name(), Currency: currency, Rate: float64(t.Day()), Timestamp: t}, nil
+}
+
+func TestPriceHistoryFill(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PriceHistoryFile)
+	ph, err := NewPriceHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &testHistoricalProvider{fail: map[string]bool{"2021-06-02": true}}
+	rates := NewExchangeRates(provider)
+	var times []time.Time
+	for day := 1; day <= 4; day++ {
+		times = append(times, time.Date(2021, 6, day, 12, 0, 0, 0, time.UTC))
+	}
+
+	// Concurrent fills of the same days request each day once, the failed day
+	// is skipped.
+	var wg sync.WaitGroup
+	var added int32
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, _ := ph.Fill(context.Background(), rates, "test", "USD", times)
+			atomic.AddInt32(&added, int32(n))
+		}()
+	}
+	wg.Wait()
+	if added != 3 {
+		t.Fatalf("expected 3 rates added, got %d", added)
+	}
+	if provider.requests != 4+2 {
+		t.Fatalf("expected the rates of 4 days then the failed day twice to be requested, got %d requests", provider.requests)
+	}
+
+	ph, err = NewPriceHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tm := range times {
+		_, ok := ph.Rate("USD", tm)
+		if expected := tm.Day() != 2; ok != expected {
+			t.Errorf("rate of %s saved: %v, expected %v", priceHistoryDay(tm), ok, expected)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package wallet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// Recipient is a destination of a payment.
+type Recipient struct {
+	Address string
+	Amount  dcrutil.Amount
+}
+
+// ReadRecipientsCSV reads the recipients of a batch payment from r. Each record
+// must have the fields address and amount in DCR, e.g. "DsAddr,1.5". A header
+// line is skipped. validAddress reports whether an address is valid on the
+// network of the wallets.
+func ReadRecipientsCSV(r io.Reader, validAddress func(string) bool) ([]Recipient, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]Recipient, 0, len(records))
+	for i, record := range records {
+		address, amountField := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+
+		amount, err := strconv.ParseFloat(amountField, 64)
+		if err != nil {
+			if i == 0 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid amount %q", i+1, amountField)
+		}
+
+		atoms, err := dcrutil.NewAmount(amount)
+		if err != nil || atoms <= 0 {
+			return nil, fmt.Errorf("line %d: invalid amount %q", i+1, amountField)
+		}
+
+		if !validAddress(address) {
+			return nil, fmt.Errorf("line %d: invalid address %q", i+1, address)
+		}
+
+		recipients = append(recipients, Recipient{address, atoms})
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("no recipients found")
+	}
+	return recipients, nil
+}
@@ -0,0 +1,42 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadRecipientsCSV(t *testing.T) {
+	validAddress := func(address string) bool {
+		return strings.HasPrefix(address, "Ts")
+	}
+
+	csv := "address,amount\nTsAddr1,1.5\n TsAddr2 , 0.00000001\n"
+	recipients, err := ReadRecipientsCSV(strings.NewReader(csv), validAddress)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Recipient{{"TsAddr1", 150000000}, {"TsAddr2", 1}}
+	if len(recipients) != len(expected) {
+		t.Fatalf("expected %d recipients, got %d", len(expected), len(recipients))
+	}
+	for i, r := range recipients {
+		if r != expected[i] {
+			t.Errorf("expected recipient %+v, got %+v", expected[i], r)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"address,amount\n",
+		"TsAddr1,1\nTsAddr2,abc\n",
+		"TsAddr1,-1\n",
+		"TsAddr1,0\n",
+		"DsAddr1,1\n",
+		"TsAddr1,1,extra\n",
+	}
+	for _, csv := range invalid {
+		if _, err := ReadRecipientsCSV(strings.NewReader(csv), validAddress); err == nil {
+			t.Errorf("expected an error for %q", csv)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/chaincfg/chainhash"
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// ScheduledPaymentsConfigKey is the user config key of the scheduled payments.
+const ScheduledPaymentsConfigKey = "scheduled_payments"
+
+// maxMissedRuns is the number of missed runs counted at most by DueRuns, a
+// cron schedule running every minute could otherwise be counted for long.
+const maxMissedRuns = 1000
+
+// ScheduleKind is how often a scheduled payment is made.
+type ScheduleKind string
+
+const (
+	ScheduleDaily   ScheduleKind = "daily"
+	ScheduleWeekly  ScheduleKind = "weekly"
+	ScheduleMonthly ScheduleKind = "monthly"
+	// ScheduleCron runs at the times matched by a 5 fields cron expression.
+	ScheduleCron ScheduleKind = "cron"
+)
+
+// ScheduleKinds are the kinds of schedule in the order they are offered.
+var ScheduleKinds = []ScheduleKind{ScheduleDaily, ScheduleWeekly, ScheduleMonthly, ScheduleCron}
+
+var (
+	// ErrScheduledPaymentAmount is returned when a scheduled payment is saved
+	// without an amount.
+	ErrScheduledPaymentAmount = errors.New("the scheduled payment has no amount")
+
+	// ErrNoExchangeRate is returned when the DCR amount of a payment in fiat
+	// is requested without an exchange rate for its currency.
+	ErrNoExchangeRate = errors.New("no exchange rate for the currency of the payment")
+
+	// ErrScheduledPaymentWallet is returned when a scheduled payment is paid
+	// from a wallet that was removed.
+	ErrScheduledPaymentWallet = errors.New("the wallet of the scheduled payment does not exist")
+)
+
+// Schedule is when a scheduled payment is made.
+type Schedule struct {
+	Kind ScheduleKind `json:"kind"`
+	// Cron is the cron expression of the ScheduleCron schedules, in the
+	// minute hour day-of-month month day-of-week format.
+	Cron string `json:"cron,omitempty"`
+}
+
+// Validate returns an error if s is of an unknown kind or has an invalid
+// cron expression.
+func (s Schedule) Validate() error {
+	switch s.Kind {
+	case ScheduleDaily, ScheduleWeekly, ScheduleMonthly:
+		return nil
+	case ScheduleCron:
+		_, err := parseCron(s.Cron)
+		return err
+	default:
+		return fmt.Errorf("unknown schedule %q", s.Kind)
+	}
+}
+
+// String returns the cron expression of the cron schedules and the kind of
+// the others.
+func (s Schedule) String() string {
+	if s.Kind == ScheduleCron {
+		return s.Cron
+	}
+	return string(s.Kind)
+}
+
+// Next returns the first run of s after the time after. The daily, weekly and
+// monthly runs are at the time of the day of start, the monthly runs on the
+// day of the month of start or on the last day of the shorter months. The
+// first run is not before start. The zero time is returned if s is invalid.
+func (s Schedule) Next(start, after time.Time) time.Time {
+	if after.Before(start) {
+		after = start.Add(-time.Nanosecond)
+	}
+
+	switch s.Kind {
+	case ScheduleDaily, ScheduleWeekly:
+		days := 1
+		if s.Kind == ScheduleWeekly {
+			days = 7
+		}
+		// Start close to the run, the days are not all 24h long across
+		// daylight saving time changes.
+		n := int(after.Sub(start).Hours()/24)/days - 1
+		if n < 0 {
+			n = 0
+		}
+		for {
+			run := start.AddDate(0, 0, n*days)
+			if run.After(after) {
+				return run
+			}
+			n++
+		}
+	case ScheduleMonthly:
+		n := (after.Year()-start.Year())*12 + int(after.Month()-start.Month()) - 1
+		if n < 0 {
+			n = 0
+		}
+		for {
+			run := addMonths(start, n)
+			if run.After(after) {
+				return run
+			}
+			n++
+		}
+	case ScheduleCron:
+		spec, err := parseCron(s.Cron)
+		if err != nil {
+			return time.Time{}
+		}
+		return spec.next(after)
+	}
+	return time.Time{}
+}
+
+// addMonths adds n months to t, moving to the last day of the month the days
+// it does not have.
+func addMonths(t time.Time, n int) time.Time {
+	firstDay := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstDay.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstDay.AddDate(0, 0, day-1)
+}
+
+// cronSpec is a parsed cron expression, each field being the set of the
+// values it matches.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are true if the day fields are *, the days matching
+	// either field being matched when both are restricted.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// parseCron parses a 5 fields cron expression. The fields are lists of
+// values, ranges and * with an optional /step, the day of week 7 is sunday.
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected %d fields", expr, len(cronFields))
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, field := range fields {
+		set, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expr, err)
+		}
+		sets[i] = set
+	}
+
+	spec := &cronSpec{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	if spec.dow&(1<<7) != 0 {
+		spec.dow |= 1
+	}
+	return spec, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, item[i+1:])
+			}
+			item = item[:i]
+		}
+
+		lo, hi := f.min, f.max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid %s %q", f.name, item)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid %s %q", f.name, item)
+				}
+			}
+			if lo < f.min || hi > f.max || lo > hi {
+				return 0, fmt.Errorf("%s %q out of range %d-%d", f.name, item, f.min, f.max)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func (spec *cronSpec) matchDay(t time.Time) bool {
+	dom := spec.dom&(1<<uint(t.Day())) != 0
+	dow := spec.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case spec.domAny && spec.dowAny:
+		return true
+	case spec.domAny:
+		return dow
+	case spec.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// next returns the first minute matched by spec after the time after, or the
+// zero time if none is matched in the next 5 years, e.g. for the 30th of
+// february.
+func (spec *cronSpec) next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if spec.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !spec.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if spec.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if spec.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// ScheduledPayment is a payment made on a schedule, after it is confirmed
+// with the passphrase of the source wallet.
+type ScheduledPayment struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Address string `json:"address"`
+	// Amount is the amount paid in atoms, if the payment is in DCR.
+	Amount int64 `json:"amount,omitempty"`
+	// FiatAmount is the amount paid in FiatCurrency, converted to DCR at the
+	// exchange rate of the time of the payment. The payment is in DCR if
+	// FiatCurrency is empty.
+	FiatAmount   float64  `json:"fiat_amount,omitempty"`
+	FiatCurrency string   `json:"fiat_currency,omitempty"`
+	WalletID     int      `json:"wallet_id"`
+	Account      int32    `json:"account"`
+	Schedule     Schedule `json:"schedule"`
+	// Start is the time of the first run.
+	Start time.Time `json:"start"`
+	// LastRun is the time of the last run that was paid or skipped, it is the
+	// zero time until then.
+	LastRun    time.Time `json:"last_run"`
+	LastTxHash string    `json:"last_tx_hash,omitempty"`
+}
+
+// IsFiat returns true if the amount of p is in a fiat currency.
+func (p *ScheduledPayment) IsFiat() bool {
+	return p.FiatCurrency != ""
+}
+
+// Atoms returns the amount of p in atoms, fiat converting the amounts in fiat
+// currency.
+func (p *ScheduledPayment) Atoms(fiat *FiatConverter) (int64, error) {
+	if !p.IsFiat() {
+		return p.Amount, nil
+	}
+	if fiat == nil || fiat.Code() != p.FiatCurrency {
+		return 0, ErrNoExchangeRate
+	}
+	amount, err := dcrutil.NewAmount(fiat.ToDCR(p.FiatAmount))
+	if err != nil {
+		return 0, err
+	}
+	return int64(amount), nil
+}
+
+// NextRun returns the time of the first run of p that was neither paid nor
+// skipped.
+func (p *ScheduledPayment) NextRun() time.Time {
+	if p.LastRun.IsZero() {
+		return p.Schedule.Next(p.Start, p.Start.Add(-time.Nanosecond))
+	}
+	return p.Schedule.Next(p.Start, p.LastRun)
+}
+
+// DueRuns returns the number of runs of p due at now, more than one if runs
+// were missed while the app was closed, and the time of the latest. Missed
+// runs are paid once and marked done up to latest.
+func (p *ScheduledPayment) DueRuns(now time.Time) (count int, latest time.Time) {
+	run := p.NextRun()
+	for !run.IsZero() && !run.After(now) {
+		count++
+		latest = run
+		if count == maxMissedRuns {
+			break
+		}
+		run = p.Schedule.Next(p.Start, run)
+	}
+	return count, latest
+}
+
+// ValidateScheduledPayment returns an error if p has no label, amount or
+// valid schedule or if its address is not valid according to validAddress.
+func ValidateScheduledPayment(p ScheduledPayment, validAddress func(string) bool) error {
+	if strings.TrimSpace(p.Label) == "" {
+		return errors.New("the scheduled payment has no label")
+	}
+	if !validAddress(p.Address) {
+		return fmt.Errorf("invalid address %q", p.Address)
+	}
+	if (p.IsFiat() && p.FiatAmount <= 0) || (!p.IsFiat() && p.Amount <= 0) {
+		return ErrScheduledPaymentAmount
+	}
+	return p.Schedule.Validate()
+}
+
+// ScheduledPayments returns the scheduled payments, sorted by next run.
+func (wal *Wallet) ScheduledPayments() []ScheduledPayment {
+	var payments []ScheduledPayment
+	if wal.multi != nil {
+		if err := wal.multi.ReadUserConfigValue(ScheduledPaymentsConfigKey, &payments); err != nil {
+			return nil
+		}
+	}
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].NextRun().Before(payments[j].NextRun())
+	})
+	return payments
+}
+
+// SaveScheduledPayment validates p and saves it in place of the scheduled
+// payment with its ID, a new ID is given to p if it has none.
+func (wal *Wallet) SaveScheduledPayment(p ScheduledPayment) error {
+	p.Label, p.Address = strings.TrimSpace(p.Label), strings.TrimSpace(p.Address)
+	if err := ValidateScheduledPayment(p, wal.multi.IsAddressValid); err != nil {
+		return err
+	}
+	if p.ID == "" {
+		p.ID = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	payments := wal.ScheduledPayments()
+	saved := payments[:0]
+	for _, existing := range payments {
+		if existing.ID != p.ID {
+			saved = append(saved, existing)
+		}
+	}
+	wal.multi.SaveUserConfigValue(ScheduledPaymentsConfigKey, append(saved, p))
+	return nil
+}
+
+// DeleteScheduledPayment removes the scheduled payment with id.
+func (wal *Wallet) DeleteScheduledPayment(id string) {
+	payments := wal.ScheduledPayments()
+	kept := payments[:0]
+	for _, p := range payments {
+		if p.ID != id {
+			kept = append(kept, p)
+		}
+	}
+	wal.multi.SaveUserConfigValue(ScheduledPaymentsConfigKey, kept)
+}
+
+// MarkScheduledPaymentRun marks the runs of the scheduled payment with id up
+// to run as done, paid by the transaction txHash or skipped if it is empty.
+func (wal *Wallet) MarkScheduledPaymentRun(id string, run time.Time, txHash string) {
+	payments := wal.ScheduledPayments()
+	for i := range payments {
+		if payments[i].ID == id {
+			payments[i].LastRun = run
+			if txHash != "" {
+				payments[i].LastTxHash = txHash
+			}
+		}
+	}
+	wal.multi.SaveUserConfigValue(ScheduledPaymentsConfigKey, payments)
+}
+
+// DueScheduledPayments returns the scheduled payments with runs due at now.
+func (wal *Wallet) DueScheduledPayments(now time.Time) []ScheduledPayment {
+	var due []ScheduledPayment
+	for _, p := range wal.ScheduledPayments() {
+		if count, _ := p.DueRuns(now); count > 0 {
+			due = append(due, p)
+		}
+	}
+	return due
+}
+
+// PayScheduledPayment pays p from its account, signing the transaction with
+// privatePassphrase, and marks its runs up to run as done. fiat converts the
+// amounts in fiat currency. The hash of the transaction is returned.
+func (wal *Wallet) PayScheduledPayment(p ScheduledPayment, run time.Time, fiat *FiatConverter, privatePassphrase []byte) (string, error) {
+	w := wal.multi.WalletWithID(p.WalletID)
+	if w == nil {
+		return "", ErrScheduledPaymentWallet
+	}
+
+	atoms, err := p.Atoms(fiat)
+	if err != nil {
+		return "", err
+	}
+
+	txAuthor, err := NewTxAuthor(w, p.Account)
+	if err != nil {
+		return "", err
+	}
+	if err = txAuthor.AddSendDestination(p.Address, atoms, false); err != nil {
+		return "", err
+	}
+
+	hash, err := txAuthor.Broadcast(privatePassphrase)
+	if err != nil {
+		return "", err
+	}
+	txHash, err := chainhash.NewHash(hash)
+	if err != nil {
+		return "", err
+	}
+
+	wal.MarkScheduledPaymentRun(p.ID, run, txHash.String())
+	return txHash.String(), nil
+}
@@ -0,0 +1,155 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNext(t *testing.T) {
+	start := date("2022-01-31 09:30")
+	tests := []struct {
+		schedule Schedule
+		after    string
+		next     string
+	}{
+		{Schedule{Kind: ScheduleDaily}, "2021-12-01 00:00", "2022-01-31 09:30"},
+		{Schedule{Kind: ScheduleDaily}, "2022-01-31 09:30", "2022-02-01 09:30"},
+		{Schedule{Kind: ScheduleDaily}, "2022-03-10 12:00", "2022-03-11 09:30"},
+		{Schedule{Kind: ScheduleWeekly}, "2022-02-01 00:00", "2022-02-07 09:30"},
+		{Schedule{Kind: ScheduleWeekly}, "2022-02-07 09:30", "2022-02-14 09:30"},
+		{Schedule{Kind: ScheduleMonthly}, "2022-02-01 00:00", "2022-02-28 09:30"},
+		{Schedule{Kind: ScheduleMonthly}, "2022-02-28 09:30", "2022-03-31 09:30"},
+		{Schedule{Kind: ScheduleMonthly}, "2022-04-15 00:00", "2022-04-30 09:30"},
+		{Schedule{Kind: ScheduleCron, Cron: "0 12 * * 1-5"}, "2022-02-04 12:00", "2022-02-07 12:00"},
+		{Schedule{Kind: ScheduleCron, Cron: "*/15 * * * *"}, "2022-02-01 10:07", "2022-02-01 10:15"},
+		{Schedule{Kind: ScheduleCron, Cron: "0 0 1,15 * *"}, "2022-02-01 00:00", "2022-02-15 00:00"},
+		{Schedule{Kind: ScheduleCron, Cron: "0 8 * 6 7"}, "2022-02-01 00:00", "2022-06-05 08:00"},
+		{Schedule{Kind: ScheduleCron, Cron: "0 0 13 * 5"}, "2022-02-01 00:00", "2022-02-04 00:00"},
+		{Schedule{Kind: ScheduleCron, Cron: "0 0 30 2 *"}, "2022-02-01 00:00", "0001-01-01 00:00"},
+	}
+
+	for _, test := range tests {
+		next := test.schedule.Next(start, date(test.after))
+		if !next.Equal(date(test.next)) {
+			t.Errorf("%s after %s: expected %s, got %s", test.schedule, test.after, test.next, next)
+		}
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	valid := []Schedule{
+		{Kind: ScheduleMonthly},
+		{Kind: ScheduleCron, Cron: "30 9 1-7 * 1"},
+		{Kind: ScheduleCron, Cron: "0,30 */2 * 1-12/3 *"},
+	}
+	for _, s := range valid {
+		if err := s.Validate(); err != nil {
+			t.Errorf("%s: unexpected error %v", s, err)
+		}
+	}
+
+	invalid := []Schedule{
+		{Kind: "yearly"},
+		{Kind: ScheduleCron, Cron: "* * * *"},
+		{Kind: ScheduleCron, Cron: "60 * * * *"},
+		{Kind: ScheduleCron, Cron: "* * 0 * *"},
+		{Kind: ScheduleCron, Cron: "* * * * 8"},
+		{Kind: ScheduleCron, Cron: "*/0 * * * *"},
+		{Kind: ScheduleCron, Cron: "5-1 * * * *"},
+		{Kind: ScheduleCron, Cron: "mon * * * *"},
+	}
+	for _, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
+
+func TestScheduledPaymentDueRuns(t *testing.T) {
+	p := ScheduledPayment{
+		Schedule: Schedule{Kind: ScheduleDaily},
+		Start:    date("2022-02-01 09:00"),
+	}
+
+	if count, _ := p.DueRuns(date("2022-02-01 08:59")); count != 0 {
+		t.Errorf("expected no run due before the start, got %d", count)
+	}
+
+	count, latest := p.DueRuns(date("2022-02-01 09:00"))
+	if count != 1 || !latest.Equal(p.Start) {
+		t.Errorf("expected the first run due, got %d at %s", count, latest)
+	}
+
+	// The app was closed for three runs.
+	p.LastRun = date("2022-02-01 09:00")
+	count, latest = p.DueRuns(date("2022-02-04 18:00"))
+	if count != 3 || !latest.Equal(date("2022-02-04 09:00")) {
+		t.Errorf("expected 3 missed runs, got %d up to %s", count, latest)
+	}
+
+	p.LastRun = latest
+	if count, _ := p.DueRuns(date("2022-02-04 18:00")); count != 0 {
+		t.Errorf("expected no run due after the missed runs are done, got %d", count)
+	}
+	if next := p.NextRun(); !next.Equal(date("2022-02-05 09:00")) {
+		t.Errorf("unexpected next run %s", next)
+	}
+
+	p.Schedule = Schedule{Kind: ScheduleCron, Cron: "* * * * *"}
+	if count, _ := p.DueRuns(date("2022-03-04 18:00")); count != maxMissedRuns {
+		t.Errorf("expected the missed runs to be capped, got %d", count)
+	}
+}
+
+func TestScheduledPaymentAtoms(t *testing.T) {
+	p := ScheduledPayment{Amount: 150000000}
+	if atoms, err := p.Atoms(nil); err != nil || atoms != 150000000 {
+		t.Errorf("unexpected amount %d, %v", atoms, err)
+	}
+
+	p = ScheduledPayment{FiatAmount: 50, FiatCurrency: "USD"}
+	if _, err := p.Atoms(nil); err != ErrNoExchangeRate {
+		t.Errorf("expected ErrNoExchangeRate, got %v", err)
+	}
+	eur := NewFiatConverter(&ExchangeRate{Currency: "eur", Rate: 25}, language.English)
+	if _, err := p.Atoms(eur); err != ErrNoExchangeRate {
+		t.Errorf("expected ErrNoExchangeRate, got %v", err)
+	}
+	usd := NewFiatConverter(&ExchangeRate{Currency: "usd", Rate: 25}, language.English)
+	if atoms, err := p.Atoms(usd); err != nil || atoms != 200000000 {
+		t.Errorf("unexpected amount %d, %v", atoms, err)
+	}
+}
+
+func TestValidateScheduledPayment(t *testing.T) {
+	p := ScheduledPayment{
+		Label:    "Rent",
+		Address:  "TsLandlord",
+		Amount:   100000000,
+		Schedule: Schedule{Kind: ScheduleMonthly},
+	}
+	if err := ValidateScheduledPayment(p, validTestAddress); err != nil {
+		t.Fatal(err)
+	}
+
+	noLabel, badAddress, noAmount, badSchedule := p, p, p, p
+	noLabel.Label = " "
+	badAddress.Address = "DsLandlord"
+	noAmount.Amount, noAmount.FiatCurrency = 100, "USD"
+	badSchedule.Schedule = Schedule{Kind: ScheduleCron, Cron: "every day"}
+	for _, invalid := range []ScheduledPayment{noLabel, badAddress, noAmount, badSchedule} {
+		if err := ValidateScheduledPayment(invalid, validTestAddress); err == nil {
+			t.Errorf("expected an error validating %+v", invalid)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package wallet
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+const (
+	// TxExportCSV, TxExportJSON and TxExportOFX are the formats the
+	// transaction history can be exported to.
+	TxExportCSV  = "csv"
+	TxExportJSON = "json"
+	TxExportOFX  = "ofx"
+
+	ofxTimeLayout = "20060102150405"
+)
+
+// ErrUnknownExportFormat is returned when transactions are exported to a
+// format other than TxExportCSV, TxExportJSON or TxExportOFX.
+var ErrUnknownExportFormat = errors.New("unknown export format")
+
+// TxExportFormats returns the supported export formats.
+func TxExportFormats() []string {
+	return []string{TxExportCSV, TxExportJSON, TxExportOFX}
+}
+
+// TxExportOptions selects the transactions to export and how to export them.
+type TxExportOptions struct {
+	Format string
+	// Filter is one of the dcrlibwallet.TxFilter* values.
+	Filter int32
+	// From and To limit the export to transactions with a timestamp in
+	// [From, To). A zero time leaves that end of the range open.
+	From, To time.Time
+	// FiatCurrency is the currency transactions are valued in at the rate of
+	// the day they were mined. No fiat value is exported if it is empty.
+	FiatCurrency string
+}
+
+// TxExportRecord is a transaction as it is written to an export file.
+type TxExportRecord struct {
+	Wallet        string    `json:"wallet"`
+	Hash          string    `json:"hash"`
+	Timestamp     time.Time `json:"timestamp"`
+	Direction     string    `json:"direction"`
+	Type          string    `json:"type"`
+	Amount        float64   `json:"amount"`
+	Fee           float64   `json:"fee"`
+	Account       string    `json:"account"`
+	Confirmations int32     `json:"confirmations"`
+	FiatValue     *float64  `json:"fiat_value,omitempty"`
+	FiatCurrency  string    `json:"fiat_currency,omitempty"`
+	Label         string    `json:"label,omitempty"`
+	Note          string    `json:"note,omitempty"`
+}
+
+// SignedTxAmount returns the amount of tx as it is shown to the user: the mix
+// denomination for mixed transactions and a negative amount for sent ones.
+func SignedTxAmount(tx *dcrlibwallet.Transaction) int64 {
+	switch {
+	case tx.Type == dcrlibwallet.TxTypeMixed:
+		return tx.MixDenomination
+	case tx.Type == dcrlibwallet.TxTypeRegular && tx.Direction == dcrlibwallet.TxDirectionSent:
+		return -tx.Amount
+	default:
+		return tx.Amount
+	}
+}
+
+func txDirectionName(direction int32) string {
+	switch direction {
+	case dcrlibwallet.TxDirectionSent:
+		return "sent"
+	case dcrlibwallet.TxDirectionReceived:
+		return "received"
+	case dcrlibwallet.TxDirectionTransferred:
+		return "transferred"
+	default:
+		return "unknown"
+	}
+}
+
+// txAccountNumber returns the wallet account tx was sent from, or received to
+// if no input belongs to the wallet.
+func txAccountNumber(tx *dcrlibwallet.Transaction) int32 {
+	if tx.Direction != dcrlibwallet.TxDirectionReceived {
+		for _, input := range tx.Inputs {
+			if input.AccountNumber != -1 {
+				return input.AccountNumber
+			}
+		}
+	}
+	for _, output := range tx.Outputs {
+		if output.AccountNumber != -1 {
+			return output.AccountNumber
+		}
+	}
+	return -1
+}
+
+// TxExportRecords returns the transactions of wallets that match opts, newest
+// first.
+func (wal *Wallet) TxExportRecords(wallets []*dcrlibwallet.Wallet, opts TxExportOptions) ([]TxExportRecord, error) {
+	var records []TxExportRecord
+	for _, w := range wallets {
+		txs, err := w.GetTransactionsRaw(0, 0, opts.Filter, true)
+		if err != nil {
+			return nil, err
+		}
+
+		bestBlock := w.GetBestBlock()
+		labels := wal.TxLabels()
+		for i := range txs {
+			tx := &txs[i]
+			timestamp := time.Unix(tx.Timestamp, 0).UTC()
+			if (!opts.From.IsZero() && timestamp.Before(opts.From)) || (!opts.To.IsZero() && !timestamp.Before(opts.To)) {
+				continue
+			}
+
+			record := TxExportRecord{
+				Wallet:    w.Name,
+				Hash:      tx.Hash,
+				Timestamp: timestamp,
+				Direction: txDirectionName(tx.Direction),
+				Type:      tx.Type,
+				Amount:    dcrutil.Amount(SignedTxAmount(tx)).ToCoin(),
+				Fee:       dcrutil.Amount(tx.Fee).ToCoin(),
+			}
+			label := labels.Of(w.ID, tx.Hash)
+			record.Label, record.Note = label.Label, label.Note
+			if account := txAccountNumber(tx); account != -1 {
+				record.Account, _ = w.AccountName(account)
+			}
+			if tx.BlockHeight != -1 {
+				record.Confirmations = bestBlock - tx.BlockHeight + 1
+			}
+
+			if opts.FiatCurrency != "" {
+				record.FiatCurrency = strings.ToUpper(opts.FiatCurrency)
+				if rate, ok := wal.priceHistory.Rate(opts.FiatCurrency, timestamp); ok && tx.BlockHeight != -1 {
+					value := record.Amount * rate
+					record.FiatValue = &value
+				}
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.After(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// FillTxExportRecords fetches the rates of the days the mined transactions of
+// records were mined on that are missing from the price history, in the fiat
+// currency of the records, and values them at those rates. provider is
+// preferred to fetch the rates. Records whose rate cannot be fetched keep no
+// fiat value. It returns the number of rates added.
+func (wal *Wallet) FillTxExportRecords(ctx context.Context, provider string, records []TxExportRecord) (int, error) {
+	times := make(map[string][]time.Time)
+	for _, r := range records {
+		if r.FiatCurrency != "" && r.FiatValue == nil && r.Confirmations > 0 {
+			times[r.FiatCurrency] = append(times[r.FiatCurrency], r.Timestamp)
+		}
+	}
+
+	var added int
+	var firstErr error
+	for currency, currencyTimes := range times {
+		n, err := wal.priceHistory.Fill(ctx, wal.exchangeRates, provider, currency, currencyTimes)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		added += n
+	}
+
+	for i := range records {
+		r := &records[i]
+		if r.FiatCurrency == "" || r.FiatValue != nil || r.Confirmations <= 0 {
+			continue
+		}
+		if rate, ok := wal.priceHistory.Rate(r.FiatCurrency, r.Timestamp); ok {
+			value := r.Amount * rate
+			r.FiatValue = &value
+		}
+	}
+	return added, firstErr
+}
+
+// WriteTxExport writes records to w in the specified format.
+func WriteTxExport(w io.Writer, format string, records []TxExportRecord) error {
+	switch format {
+	case TxExportCSV:
+		return writeTxCSV(w, records)
+	case TxExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if records == nil {
+			records = []TxExportRecord{}
+		}
+		return enc.Encode(records)
+	case TxExportOFX:
+		return writeTxOFX(w, records)
+	default:
+		return ErrUnknownExportFormat
+	}
+}
+
+func hasFiatValues(records []TxExportRecord) bool {
+	for _, r := range records {
+		if r.FiatCurrency != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTxLabels(records []TxExportRecord) bool {
+	for _, r := range records {
+		if r.Label != "" || r.Note != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func formatDCR(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', -1, 64)
+}
+
+func writeTxCSV(w io.Writer, records []TxExportRecord) error {
+	withFiat, withLabels := hasFiatValues(records), hasTxLabels(records)
+	header := []string{"wallet", "hash", "timestamp", "direction", "type", "amount", "fee", "account", "confirmations"}
+	if withFiat {
+		header = append(header, "fiat_value", "fiat_currency")
+	}
+	if withLabels {
+		header = append(header, "label", "note")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		row := []string{
+			r.Wallet,
+			r.Hash,
+			r.Timestamp.Format(time.RFC3339),
+			r.Direction,
+			r.Type,
+			formatDCR(r.Amount),
+			formatDCR(r.Fee),
+			r.Account,
+			strconv.Itoa(int(r.Confirmations)),
+		}
+		if withFiat {
+			fiatValue := ""
+			if r.FiatValue != nil {
+				fiatValue = strconv.FormatFloat(*r.FiatValue, 'f', 2, 64)
+			}
+			row = append(row, fiatValue, r.FiatCurrency)
+		}
+		if withLabels {
+			row = append(row, r.Label, r.Note)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ofxTxType returns the OFX transaction type of a record.
+func ofxTxType(r TxExportRecord) string {
+	if r.Type != dcrlibwallet.TxTypeRegular {
+		return "OTHER"
+	}
+	switch r.Direction {
+	case "sent":
+		return "DEBIT"
+	case "received":
+		return "CREDIT"
+	default:
+		return "XFER"
+	}
+}
+
+func ofxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// writeTxOFX writes records as an OFX 2.2 bank statement. Each wallet is
+// exported as a separate account statement in DCR.
+func writeTxOFX(w io.Writer, records []TxExportRecord) error {
+	var wallets []string
+	byWallet := make(map[string][]TxExportRecord)
+	for _, r := range records {
+		if _, ok := byWallet[r.Wallet]; !ok {
+			wallets = append(wallets, r.Wallet)
+		}
+		byWallet[r.Wallet] = append(byWallet[r.Wallet], r)
+	}
+
+	now := time.Now().UTC().Format(ofxTimeLayout)
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	b.WriteString(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	b.WriteString("<OFX>\n")
+	b.WriteString("<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>")
+	fmt.Fprintf(&b, "<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n", now)
+	b.WriteString("<BANKMSGSRSV1>\n")
+
+	for i, wallet := range wallets {
+		txs := byWallet[wallet]
+		fmt.Fprintf(&b, "<STMTTRNRS><TRNUID>%d</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n", i+1)
+		b.WriteString("<STMTRS><CURDEF>DCR</CURDEF>\n")
+		fmt.Fprintf(&b, "<BANKACCTFROM><BANKID>godcr</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", ofxEscape(wallet))
+		// records are sorted newest first.
+		fmt.Fprintf(&b, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n",
+			txs[len(txs)-1].Timestamp.Format(ofxTimeLayout), txs[0].Timestamp.Format(ofxTimeLayout))
+
+		for _, r := range txs {
+			memo := r.Account
+			if r.FiatValue != nil {
+				memo = fmt.Sprintf("%s %.2f %s", memo, *r.FiatValue, r.FiatCurrency)
+			}
+			if r.Note != "" {
+				memo = fmt.Sprintf("%s %s", memo, r.Note)
+			}
+			name := r.Type
+			if r.Label != "" {
+				name = r.Label
+			}
+			b.WriteString("<STMTTRN>")
+			fmt.Fprintf(&b, "<TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID>",
+				ofxTxType(r), r.Timestamp.Format(ofxTimeLayout), formatDCR(r.Amount), r.Hash)
+			fmt.Fprintf(&b, "<NAME>%s</NAME><MEMO>%s</MEMO>", ofxEscape(name), ofxEscape(strings.TrimSpace(memo)))
+			b.WriteString("</STMTTRN>\n")
+		}
+
+		b.WriteString("</BANKTRANLIST>\n</STMTRS>\n</STMTTRNRS>\n")
+	}
+
+	b.WriteString("</BANKMSGSRSV1>\n</OFX>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,144 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testExportRecords() []TxExportRecord {
+	fiatValue := 30.5
+	return []TxExportRecord{
+		{
+			Wallet:        "savings",
+			Hash:          "a1",
+			Timestamp:     time.Date(2021, 6, 2, 10, 0, 0, 0, time.UTC),
+			Direction:     "sent",
+			Type:          "Regular",
+			Amount:        -1.5,
+			Fee:           0.0001,
+			Account:       "default",
+			Confirmations: 10,
+			FiatValue:     &fiatValue,
+			FiatCurrency:  "EUR",
+		},
+		{
+			Wallet:       "savings",
+			Hash:         "b2",
+			Timestamp:    time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC),
+			Direction:    "received",
+			Type:         "Regular",
+			Amount:       2,
+			Account:      "a & b",
+			FiatCurrency: "EUR",
+		},
+	}
+}
+
+func TestWriteTxExport(t *testing.T) {
+	records := testExportRecords()
+
+	var buf bytes.Buffer
+	if err := WriteTxExport(&buf, TxExportCSV, records); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 csv lines, got %d", len(lines))
+	}
+	if !strings.HasSuffix(lines[0], "fiat_value,fiat_currency") {
+		t.Fatalf("expected fiat columns in header, got %q", lines[0])
+	}
+	if want := "savings,a1,2021-06-02T10:00:00Z,sent,Regular,-1.5,0.0001,default,10,30.50,EUR"; lines[1] != want {
+		t.Fatalf("expected %q, got %q", want, lines[1])
+	}
+
+	buf.Reset()
+	if err := WriteTxExport(&buf, TxExportJSON, records); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []TxExportRecord
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || decoded[1].FiatValue != nil || *decoded[0].FiatValue != 30.5 {
+		t.Fatalf("unexpected json records %+v", decoded)
+	}
+
+	buf.Reset()
+	if err := WriteTxExport(&buf, TxExportOFX, records); err != nil {
+		t.Fatal(err)
+	}
+	ofx := buf.String()
+	for _, want := range []string{
+		"<ACCTID>savings</ACCTID>",
+		"<TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20210602100000</DTPOSTED><TRNAMT>-1.5</TRNAMT><FITID>a1</FITID>",
+		"<DTSTART>20210601100000</DTSTART><DTEND>20210602100000</DTEND>",
+		"<MEMO>a &amp; b</MEMO>",
+	} {
+		if !strings.Contains(ofx, want) {
+			t.Fatalf("expected ofx to contain %q:\n%s", want, ofx)
+		}
+	}
+
+	if err := WriteTxExport(&buf, "xls", records); err != ErrUnknownExportFormat {
+		t.Fatalf("expected ErrUnknownExportFormat, got %v", err)
+	}
+}
+
+func TestWriteTxExportLabels(t *testing.T) {
+	records := testExportRecords()
+	records[1].Label, records[1].Note = "Rent", "June, flat 2"
+
+	var buf bytes.Buffer
+	if err := WriteTxExport(&buf, TxExportCSV, records); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[0], "fiat_currency,label,note") {
+		t.Fatalf("expected label columns in header, got %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "EUR,,") || !strings.HasSuffix(lines[2], `EUR,Rent,"June, flat 2"`) {
+		t.Fatalf("unexpected labels in %q", lines[1:])
+	}
+
+	buf.Reset()
+	if err := WriteTxExport(&buf, TxExportOFX, records); err != nil {
+		t.Fatal(err)
+	}
+	if want := "<NAME>Rent</NAME><MEMO>a &amp; b June, flat 2</MEMO>"; !strings.Contains(buf.String(), want) {
+		t.Fatalf("expected ofx to contain %q:\n%s", want, buf.String())
+	}
+}
+
+func TestFillTxExportRecords(t *testing.T) {
+	ph, err := NewPriceHistory(filepath.Join(t.TempDir(), PriceHistoryFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := new(testHistoricalProvider)
+	wal := &Wallet{priceHistory: ph, exchangeRates: NewExchangeRates(provider)}
+
+	valued := 1.0
+	records := []TxExportRecord{
+		{Hash: "mined", Timestamp: time.Date(2021, 6, 3, 10, 0, 0, 0, time.UTC), Amount: 2, Confirmations: 5, FiatCurrency: "USD"},
+		{Hash: "pending", Timestamp: time.Date(2021, 6, 4, 10, 0, 0, 0, time.UTC), Amount: 1, FiatCurrency: "USD"},
+		{Hash: "valued", Timestamp: time.Date(2021, 6, 5, 10, 0, 0, 0, time.UTC), Amount: 1, Confirmations: 5, FiatValue: &valued, FiatCurrency: "USD"},
+	}
+
+	// Only the rate of the mined record without a value is fetched.
+	added, err := wal.FillTxExportRecords(context.Background(), "", records)
+	if err != nil || added != 1 || provider.requests != 1 {
+		t.Fatalf("expected 1 rate added with 1 request, got %d with %d requests (%v)", added, provider.requests, err)
+	}
+	if records[0].FiatValue == nil || *records[0].FiatValue != 6 {
+		t.Errorf("the mined record was valued at %v, expected 6", records[0].FiatValue)
+	}
+	if records[1].FiatValue != nil {
+		t.Errorf("the pending record was valued")
+	}
+}
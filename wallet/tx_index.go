@@ -0,0 +1,396 @@
+package wallet
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// txIndexBatchSize is the number of transactions read at once when the index
+// of a wallet is built.
+const txIndexBatchSize = 1000
+
+// TxConfirmState selects the transactions by confirmation.
+type TxConfirmState int
+
+const (
+	TxConfirmStateAny TxConfirmState = iota
+	// TxConfirmStatePending is for the transactions with less
+	// confirmations than required by the wallet.
+	TxConfirmStatePending
+	TxConfirmStateConfirmed
+)
+
+// TxSearchQuery selects the transactions of a wallet. The zero value of each
+// field selects every transaction.
+type TxSearchQuery struct {
+	// Text is matched case insensitively against the prefix of the hash, the
+	// addresses and the labels of the transactions.
+	Text string
+	// Filter is a dcrlibwallet.TxFilter* value.
+	Filter int32
+	// MinAmount and MaxAmount bound the amount of the transactions, in atoms.
+	// They are not bound if 0.
+	MinAmount, MaxAmount int64
+	// From and To bound the time of the transactions, To is excluded.
+	From, To time.Time
+	// Account selects the transactions with inputs or outputs of an account,
+	// if it is not nil.
+	Account *int32
+	Confirm TxConfirmState
+}
+
+// IsZero returns true if q selects every transaction.
+func (q *TxSearchQuery) IsZero() bool {
+	return strings.TrimSpace(q.Text) == "" && q.Filter == dcrlibwallet.TxFilterAll &&
+		q.MinAmount == 0 && q.MaxAmount == 0 && q.From.IsZero() && q.To.IsZero() &&
+		q.Account == nil && q.Confirm == TxConfirmStateAny
+}
+
+// txIndexEntry is a transaction of the index with its searchable fields.
+type txIndexEntry struct {
+	tx dcrlibwallet.Transaction
+	// hash and lowerAddresses are lower case, lowerAddresses[i] is
+	// addresses[i].
+	hash           string
+	addresses      []string
+	lowerAddresses []string
+}
+
+func newTxIndexEntry(tx dcrlibwallet.Transaction) txIndexEntry {
+	e := txIndexEntry{tx: tx, hash: strings.ToLower(tx.Hash)}
+	for _, out := range tx.Outputs {
+		if out.Address != "" {
+			e.addresses = append(e.addresses, out.Address)
+			e.lowerAddresses = append(e.lowerAddresses, strings.ToLower(out.Address))
+		}
+	}
+	return e
+}
+
+// hasAccount returns true if an input or an output of the transaction of e
+// belongs to account.
+func (e *txIndexEntry) hasAccount(account int32) bool {
+	for _, in := range e.tx.Inputs {
+		if in.AccountNumber == account {
+			return true
+		}
+	}
+	for _, out := range e.tx.Outputs {
+		if out.AccountNumber == account {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesText returns true if text, in lower case, prefixes the hash of the
+// transaction of e or is in one of its addresses or their labels, or in the
+// label of the transaction. labels is keyed by address and by transaction
+// hash.
+func (e *txIndexEntry) matchesText(text string, labels map[string]string) bool {
+	if strings.HasPrefix(e.hash, text) {
+		return true
+	}
+	if label, ok := labels[e.tx.Hash]; ok && strings.Contains(strings.ToLower(label), text) {
+		return true
+	}
+	for i, address := range e.lowerAddresses {
+		if strings.Contains(address, text) {
+			return true
+		}
+		if label, ok := labels[e.addresses[i]]; ok && strings.Contains(strings.ToLower(label), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the transaction of e is selected by q. typeFilter
+// returns true if a transaction matches q.Filter.
+func (q *TxSearchQuery) matches(e *txIndexEntry, labels map[string]string, bestBlock, requiredConfs int32, typeFilter func(*dcrlibwallet.Transaction) bool) bool {
+	tx := &e.tx
+	amount := tx.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	if (q.MinAmount > 0 && amount < q.MinAmount) || (q.MaxAmount > 0 && amount > q.MaxAmount) {
+		return false
+	}
+	if (!q.From.IsZero() && tx.Timestamp < q.From.Unix()) || (!q.To.IsZero() && tx.Timestamp >= q.To.Unix()) {
+		return false
+	}
+	if q.Account != nil && !e.hasAccount(*q.Account) {
+		return false
+	}
+
+	switch confirmed := tx.Confirmations(bestBlock) >= requiredConfs; q.Confirm {
+	case TxConfirmStatePending:
+		if confirmed {
+			return false
+		}
+	case TxConfirmStateConfirmed:
+		if !confirmed {
+			return false
+		}
+	}
+
+	if text := strings.ToLower(strings.TrimSpace(q.Text)); text != "" && !e.matchesText(text, labels) {
+		return false
+	}
+	return q.Filter == dcrlibwallet.TxFilterAll || typeFilter(tx)
+}
+
+// walletTxIndex is the index of the transactions of a wallet.
+type walletTxIndex struct {
+	// entries are ordered from the newest transaction.
+	entries []txIndexEntry
+	byHash  map[string]int
+}
+
+func (wi *walletTxIndex) add(tx dcrlibwallet.Transaction) {
+	if i, ok := wi.byHash[tx.Hash]; ok {
+		wi.entries[i] = newTxIndexEntry(tx)
+		return
+	}
+
+	// New transactions are usually the most recent, the entries are
+	// shifted until their place is found.
+	wi.entries = append(wi.entries, txIndexEntry{})
+	i := len(wi.entries) - 1
+	for ; i > 0 && wi.entries[i-1].tx.Timestamp < tx.Timestamp; i-- {
+		wi.entries[i] = wi.entries[i-1]
+		wi.byHash[wi.entries[i].tx.Hash] = i
+	}
+	wi.entries[i] = newTxIndexEntry(tx)
+	wi.byHash[tx.Hash] = i
+}
+
+// TxIndex keeps the transactions of the wallets in memory to search them
+// without reading the wallet databases. The index of a wallet is built in the
+// background by Build and kept up to date with the transaction notifications.
+type TxIndex struct {
+	mu      sync.RWMutex
+	wallets map[int]*walletTxIndex
+	builds  map[int]*txIndexBuild
+}
+
+// txIndexBuild is an index being built.
+type txIndexBuild struct {
+	// updates are the notifications received while the index is built,
+	// they are applied to it once it is built.
+	updates []func(*walletTxIndex)
+	// again is set if the index is to be built again once it is built, the
+	// transactions of the wallet having changed meanwhile. It is built again
+	// with ctx, the context of the last Build call.
+	again bool
+	ctx   context.Context
+}
+
+// NewTxIndex returns an empty transaction index.
+func NewTxIndex() *TxIndex {
+	return &TxIndex{
+		wallets: make(map[int]*walletTxIndex),
+		builds:  make(map[int]*txIndexBuild),
+	}
+}
+
+// Build indexes the transactions of w, replacing its previous index once it
+// is built. If the index of w is being built, it is built again with ctx once
+// done or failed instead. It returns ctx.Err() if ctx is canceled before.
+func (idx *TxIndex) Build(ctx context.Context, w *dcrlibwallet.Wallet) error {
+	fetch := func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+		return w.GetTransactionsRaw(offset, limit, dcrlibwallet.TxFilterAll, true)
+	}
+	return idx.build(ctx, w.ID, fetch)
+}
+
+func (idx *TxIndex) build(ctx context.Context, walletID int, fetch func(offset, limit int32) ([]dcrlibwallet.Transaction, error)) error {
+	idx.mu.Lock()
+	if b, ok := idx.builds[walletID]; ok {
+		b.again, b.ctx = true, ctx
+		idx.mu.Unlock()
+		return nil
+	}
+	b := &txIndexBuild{ctx: ctx}
+	idx.builds[walletID] = b
+	idx.mu.Unlock()
+
+	for {
+		wi, err := readTxIndex(ctx, fetch)
+
+		idx.mu.Lock()
+		if idx.builds[walletID] != b {
+			// The wallet was removed meanwhile.
+			idx.mu.Unlock()
+			return err
+		}
+		if err == nil {
+			for _, update := range b.updates {
+				update(wi)
+			}
+			idx.wallets[walletID] = wi
+		}
+		if !b.again {
+			delete(idx.builds, walletID)
+			idx.mu.Unlock()
+			return err
+		}
+		// The transactions notified so far are read again.
+		b.updates, b.again = nil, false
+		ctx = b.ctx
+		idx.mu.Unlock()
+	}
+}
+
+// readTxIndex reads the transactions returned by fetch, newest first, page
+// by page. The transactions stored meanwhile shift the pages, the
+// transactions read twice are indexed once.
+func readTxIndex(ctx context.Context, fetch func(offset, limit int32) ([]dcrlibwallet.Transaction, error)) (*walletTxIndex, error) {
+	wi := &walletTxIndex{byHash: make(map[string]int)}
+	for offset := int32(0); ; offset += txIndexBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		txs, err := fetch(offset, txIndexBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, tx := range txs {
+			if _, ok := wi.byHash[tx.Hash]; ok {
+				continue
+			}
+			wi.byHash[tx.Hash] = len(wi.entries)
+			wi.entries = append(wi.entries, newTxIndexEntry(tx))
+		}
+		if len(txs) < txIndexBatchSize {
+			return wi, nil
+		}
+	}
+}
+
+// update applies update to the index of the wallet with walletID, and to the
+// index being built if any.
+func (idx *TxIndex) update(walletID int, update func(*walletTxIndex)) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if wi, ok := idx.wallets[walletID]; ok {
+		update(wi)
+	}
+	if b, ok := idx.builds[walletID]; ok {
+		b.updates = append(b.updates, update)
+	}
+}
+
+// Ready returns true if the transactions of the wallet with walletID are
+// indexed.
+func (idx *TxIndex) Ready(walletID int) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	_, ok := idx.wallets[walletID]
+	return ok
+}
+
+// AddTx adds tx to the index of its wallet or updates it, if the wallet is
+// indexed or being indexed.
+func (idx *TxIndex) AddTx(tx *dcrlibwallet.Transaction) {
+	added := *tx
+	idx.update(tx.WalletID, func(wi *walletTxIndex) {
+		wi.add(added)
+	})
+}
+
+// ConfirmTx sets the height of the block the transaction with hash of the
+// wallet with walletID was mined in.
+func (idx *TxIndex) ConfirmTx(walletID int, hash string, height int32) {
+	idx.update(walletID, func(wi *walletTxIndex) {
+		if i, ok := wi.byHash[hash]; ok {
+			wi.entries[i].tx.BlockHeight = height
+		}
+	})
+}
+
+// Remove drops the index of the wallet with walletID, and the index being
+// built.
+func (idx *TxIndex) Remove(walletID int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.wallets, walletID)
+	delete(idx.builds, walletID)
+}
+
+// Search returns the transactions of w selected by q, the newest first unless
+// newestFirst is false. labels is keyed by address and by transaction hash.
+// ok is false if w is not indexed yet.
+func (idx *TxIndex) Search(w *dcrlibwallet.Wallet, q TxSearchQuery, labels map[string]string, newestFirst bool) (txs []dcrlibwallet.Transaction, ok bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	wi, ok := idx.wallets[w.ID]
+	if !ok {
+		return nil, false
+	}
+
+	bestBlock, requiredConfs := w.GetBestBlock(), w.RequiredConfirmations()
+	typeFilter := func(tx *dcrlibwallet.Transaction) bool {
+		return w.TxMatchesFilter(tx, q.Filter)
+	}
+	txs = searchTxIndex(wi.entries, q, labels, bestBlock, requiredConfs, typeFilter)
+	if !newestFirst {
+		for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+			txs[i], txs[j] = txs[j], txs[i]
+		}
+	}
+	return txs, true
+}
+
+func searchTxIndex(entries []txIndexEntry, q TxSearchQuery, labels map[string]string, bestBlock, requiredConfs int32, typeFilter func(*dcrlibwallet.Transaction) bool) []dcrlibwallet.Transaction {
+	txs := make([]dcrlibwallet.Transaction, 0)
+	for i := range entries {
+		if q.matches(&entries[i], labels, bestBlock, requiredConfs, typeFilter) {
+			txs = append(txs, entries[i].tx)
+		}
+	}
+	return txs
+}
+
+// TxIndex returns the index of the transactions of the wallets.
+func (wal *Wallet) TxIndex() *TxIndex {
+	return wal.txIndex
+}
+
+// TxSearchLabels returns the labels searched by TxIndex.Search for the
+// wallet with walletID: the labels of the addresses and the labels and notes
+// of the transactions of the wallet.
+func (wal *Wallet) TxSearchLabels(walletID int) map[string]string {
+	labels := wal.AddressLabels()
+	for hash, text := range wal.TxLabels().searchText(walletID) {
+		labels[hash] = text
+	}
+	return labels
+}
+
+// SearchTransactions returns the transactions of w selected by q, the newest
+// first unless newestFirst is false. indexed is false if w is not indexed
+// yet, in which case no transaction is returned: searching the wallet
+// database is as slow as indexing it.
+func (wal *Wallet) SearchTransactions(w *dcrlibwallet.Wallet, q TxSearchQuery, newestFirst bool) (txs []dcrlibwallet.Transaction, indexed bool) {
+	return wal.txIndex.Search(w, q, wal.TxSearchLabels(w.ID), newestFirst)
+}
+
+// BuildTxIndex indexes the transactions of the wallets in the background,
+// one wallet after the other, until the app is shut down.
+func (wal *Wallet) BuildTxIndex() {
+	go func() {
+		for _, w := range wal.multi.AllWallets() {
+			if err := wal.txIndex.Build(wal.ctx, w); err != nil {
+				if wal.ctx.Err() != nil {
+					return
+				}
+				log.Errorf("error indexing the transactions of wallet %d: %v", w.ID, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,168 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func testIndexTxs() []dcrlibwallet.Transaction {
+	return []dcrlibwallet.Transaction{
+		{
+			Hash: "ab01", Timestamp: 300, BlockHeight: -1, Amount: 5e8, Direction: dcrlibwallet.TxDirectionReceived,
+			Outputs: []*dcrlibwallet.TxOutput{{Address: "DsRent", AccountNumber: 0}},
+		},
+		{
+			Hash: "cd02", Timestamp: 200, BlockHeight: 95, Amount: -2e8, Direction: dcrlibwallet.TxDirectionSent,
+			Inputs:  []*dcrlibwallet.TxInput{{AccountNumber: 1}},
+			Outputs: []*dcrlibwallet.TxOutput{{Address: "", AccountNumber: -1}, {Address: "DsShop", AccountNumber: -1}},
+		},
+		{
+			Hash: "ab03", Timestamp: 100, BlockHeight: 10, Amount: 1e8, Direction: dcrlibwallet.TxDirectionReceived,
+			Outputs: []*dcrlibwallet.TxOutput{{Address: "DsSalary", AccountNumber: 2}},
+		},
+	}
+}
+
+func searchHashes(entries []txIndexEntry, q TxSearchQuery, labels map[string]string) []string {
+	typeFilter := func(tx *dcrlibwallet.Transaction) bool {
+		return tx.Direction == dcrlibwallet.TxDirectionSent
+	}
+	var hashes []string
+	for _, tx := range searchTxIndex(entries, q, labels, 100, 2, typeFilter) {
+		hashes = append(hashes, tx.Hash)
+	}
+	return hashes
+}
+
+func TestTxIndexSearch(t *testing.T) {
+	var entries []txIndexEntry
+	for _, tx := range testIndexTxs() {
+		entries = append(entries, newTxIndexEntry(tx))
+	}
+	account := int32(1)
+	labels := map[string]string{"DsShop": "Coffee shop", "ab03": "June salary"}
+
+	tests := []struct {
+		name   string
+		q      TxSearchQuery
+		hashes []string
+	}{
+		{"all", TxSearchQuery{}, []string{"ab01", "cd02", "ab03"}},
+		{"hash prefix", TxSearchQuery{Text: "AB"}, []string{"ab01", "ab03"}},
+		{"address", TxSearchQuery{Text: "dsshop"}, []string{"cd02"}},
+		{"address label", TxSearchQuery{Text: "coffee"}, []string{"cd02"}},
+		{"tx label", TxSearchQuery{Text: "salary"}, []string{"ab03"}},
+		{"type", TxSearchQuery{Filter: dcrlibwallet.TxFilterSent}, []string{"cd02"}},
+		{"min amount", TxSearchQuery{MinAmount: 2e8}, []string{"ab01", "cd02"}},
+		{"amount range", TxSearchQuery{MinAmount: 1e8, MaxAmount: 2e8}, []string{"cd02", "ab03"}},
+		{"dates", TxSearchQuery{From: time.Unix(150, 0), To: time.Unix(300, 0)}, []string{"cd02"}},
+		{"account", TxSearchQuery{Account: &account}, []string{"cd02"}},
+		{"pending", TxSearchQuery{Confirm: TxConfirmStatePending}, []string{"ab01"}},
+		{"confirmed", TxSearchQuery{Confirm: TxConfirmStateConfirmed}, []string{"cd02", "ab03"}},
+		{"none", TxSearchQuery{Text: "zz"}, nil},
+	}
+	for _, test := range tests {
+		if hashes := searchHashes(entries, test.q, labels); !reflect.DeepEqual(hashes, test.hashes) {
+			t.Errorf("%s: found %v, expected %v", test.name, hashes, test.hashes)
+		}
+	}
+}
+
+func TestWalletTxIndexAdd(t *testing.T) {
+	wi := &walletTxIndex{byHash: make(map[string]int)}
+	for _, tx := range testIndexTxs() {
+		wi.add(tx)
+	}
+	wi.add(dcrlibwallet.Transaction{Hash: "ef04", Timestamp: 250})
+	wi.add(dcrlibwallet.Transaction{Hash: "ef05", Timestamp: 400})
+	wi.add(dcrlibwallet.Transaction{Hash: "cd02", Timestamp: 200, BlockHeight: 96})
+
+	expected := []string{"ef05", "ab01", "ef04", "cd02", "ab03"}
+	for i, hash := range expected {
+		if wi.entries[i].tx.Hash != hash || wi.byHash[hash] != i {
+			t.Fatalf("entry %d is %s at %d, expected %s", i, wi.entries[i].tx.Hash, wi.byHash[hash], hash)
+		}
+	}
+	if wi.entries[3].tx.BlockHeight != 96 {
+		t.Errorf("the updated transaction was not replaced")
+	}
+}
+
+func TestTxIndexBuild(t *testing.T) {
+	const walletID = 1
+	db := new(testTxDB)
+	for i := 0; i < txIndexBatchSize+10; i++ {
+		db.insert(dcrlibwallet.Transaction{Hash: fmt.Sprintf("old%d", i), Timestamp: int64(100 + i)})
+	}
+	idx := NewTxIndex()
+
+	// A transaction stored once the first page is read shifts the next page,
+	// and is notified while the index is built.
+	newTx := dcrlibwallet.Transaction{Hash: "new", Timestamp: 1e6, WalletID: walletID}
+	pages := 0
+	fetch := func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+		page, err := db.fetch(offset, limit)
+		if pages++; pages == 1 {
+			db.insert(newTx)
+			idx.AddTx(&newTx)
+			idx.ConfirmTx(walletID, "old0", 7)
+		}
+		return page, err
+	}
+	if err := idx.build(context.Background(), walletID, fetch); err != nil {
+		t.Fatal(err)
+	}
+
+	wi := idx.wallets[walletID]
+	if len(wi.entries) != len(db.txs) || len(wi.byHash) != len(db.txs) {
+		t.Fatalf("indexed %d transactions, expected %d", len(wi.entries), len(db.txs))
+	}
+	for i, tx := range db.txs {
+		if wi.entries[i].tx.Hash != tx.Hash || wi.byHash[tx.Hash] != i {
+			t.Fatalf("entry %d is %s, expected %s", i, wi.entries[i].tx.Hash, tx.Hash)
+		}
+	}
+	if tx := wi.entries[wi.byHash["old0"]].tx; tx.BlockHeight != 7 {
+		t.Errorf("the confirmation notified during the build was lost")
+	}
+	if len(idx.builds) != 0 {
+		t.Errorf("the build was not done")
+	}
+}
+
+func TestTxIndexBuildAgain(t *testing.T) {
+	const walletID = 1
+	db := new(testTxDB)
+	for i := 0; i < txIndexBatchSize+10; i++ {
+		db.insert(dcrlibwallet.Transaction{Hash: fmt.Sprintf("tx%d", i), Timestamp: int64(i)})
+	}
+	idx := NewTxIndex()
+
+	// The index is built again by another caller while the context of the
+	// running build is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	pages := 0
+	fetch := func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+		if pages++; pages == 1 {
+			if err := idx.build(context.Background(), walletID, db.fetch); err != nil {
+				t.Fatal(err)
+			}
+			cancel()
+		}
+		return db.fetch(offset, limit)
+	}
+	if err := idx.build(ctx, walletID, fetch); err != nil {
+		t.Fatalf("the build was not done again: %v", err)
+	}
+	if !idx.Ready(walletID) || len(idx.wallets[walletID].entries) != len(db.txs) {
+		t.Errorf("the wallet was not indexed")
+	}
+	if len(idx.builds) != 0 {
+		t.Errorf("the build was not done")
+	}
+}
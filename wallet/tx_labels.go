@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// TxLabelsConfigKey is the user config key of the labels and the notes of the
+// transactions.
+const TxLabelsConfigKey = "tx_labels"
+
+// TxLabel is what the user recorded about a transaction: a short label shown
+// with the transaction and a longer free text note.
+type TxLabel struct {
+	Label string `json:"label,omitempty"`
+	Note  string `json:"note,omitempty"`
+}
+
+// TxLabels maps the keys of the transactions, "walletID:hash", to their
+// TxLabel.
+type TxLabels map[string]TxLabel
+
+func txLabelKey(walletID int, hash string) string {
+	return fmt.Sprintf("%d:%s", walletID, hash)
+}
+
+// Of returns the TxLabel of the transaction with hash of the wallet with
+// walletID.
+func (labels TxLabels) Of(walletID int, hash string) TxLabel {
+	return labels[txLabelKey(walletID, hash)]
+}
+
+// searchText returns the labels and notes of the transactions of the wallet
+// with walletID by hash, as they are searched.
+func (labels TxLabels) searchText(walletID int) map[string]string {
+	prefix := fmt.Sprintf("%d:", walletID)
+	texts := make(map[string]string)
+	for key, label := range labels {
+		if hash := strings.TrimPrefix(key, prefix); hash != key {
+			texts[hash] = label.Label + "\n" + label.Note
+		}
+	}
+	return texts
+}
+
+// txLabelCache keeps the labels of the transactions in memory, they are read
+// for every transaction listed.
+type txLabelCache struct {
+	mu     sync.Mutex
+	labels TxLabels
+}
+
+// loadLabels returns the cached labels, read from the config of multi the
+// first time. c.mu must be held.
+func (c *txLabelCache) loadLabels(multi *dcrlibwallet.MultiWallet) TxLabels {
+	if c.labels == nil {
+		labels := make(TxLabels)
+		if multi != nil {
+			if err := multi.ReadUserConfigValue(TxLabelsConfigKey, &labels); err != nil || labels == nil {
+				labels = make(TxLabels)
+			}
+		}
+		c.labels = labels
+	}
+	return c.labels
+}
+
+// TxLabels returns the labels of the transactions of all wallets. The map
+// must not be changed.
+func (wal *Wallet) TxLabels() TxLabels {
+	wal.txLabels.mu.Lock()
+	defer wal.txLabels.mu.Unlock()
+	return wal.txLabels.loadLabels(wal.multi)
+}
+
+// TxLabelOf returns the TxLabel of the transaction with hash of the wallet
+// with walletID.
+func (wal *Wallet) TxLabelOf(walletID int, hash string) TxLabel {
+	return wal.TxLabels().Of(walletID, hash)
+}
+
+// SetTxLabel sets the label and the note of tx of w, which are removed if
+// both are empty. The change outputs of a sent transaction inherit its label
+// in the coin control of w, unless they have another label.
+func (wal *Wallet) SetTxLabel(w *dcrlibwallet.Wallet, tx *dcrlibwallet.Transaction, label, note string) {
+	label, note = strings.TrimSpace(label), strings.TrimSpace(note)
+	key := txLabelKey(w.ID, tx.Hash)
+
+	wal.txLabels.mu.Lock()
+	// The cached labels are replaced rather than changed as the map returned
+	// by TxLabels may be read concurrently.
+	cached := wal.txLabels.loadLabels(wal.multi)
+	labels := make(TxLabels, len(cached)+1)
+	for k, v := range cached {
+		labels[k] = v
+	}
+	previous := labels[key]
+	if label == "" && note == "" {
+		delete(labels, key)
+	} else {
+		labels[key] = TxLabel{Label: label, Note: note}
+	}
+	wal.txLabels.labels = labels
+	wal.txLabels.mu.Unlock()
+
+	wal.multi.SaveUserConfigValue(TxLabelsConfigKey, labels)
+
+	coins := ReadCoinControl(w)
+	if inheritTxLabel(coins, tx, previous.Label, label) {
+		SaveCoinControl(w, coins)
+	}
+}
+
+// inheritTxLabel sets label on the change outputs of tx in coins if they
+// have no label or the previous label of tx, and returns true if one was
+// changed.
+func inheritTxLabel(coins CoinControl, tx *dcrlibwallet.Transaction, previous, label string) bool {
+	if tx.Direction != dcrlibwallet.TxDirectionSent {
+		return false
+	}
+
+	changed := false
+	for _, out := range tx.Outputs {
+		if !out.Internal || out.AccountNumber == -1 {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", tx.Hash, out.Index)
+		info := coins[key]
+		if info.Label != "" && info.Label != previous {
+			continue
+		}
+		if info.Label != label {
+			info.Label = label
+			coins[key] = info
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,57 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+func TestInheritTxLabel(t *testing.T) {
+	tx := &dcrlibwallet.Transaction{
+		Hash:      "ab",
+		Direction: dcrlibwallet.TxDirectionSent,
+		Outputs: []*dcrlibwallet.TxOutput{
+			{Index: 0, AccountNumber: -1},
+			{Index: 1, AccountNumber: 0, Internal: true},
+			{Index: 2, AccountNumber: 0, Internal: true},
+			{Index: 3, AccountNumber: 1},
+		},
+	}
+	coins := CoinControl{"ab:2": {Label: "mine", Frozen: true}}
+
+	if !inheritTxLabel(coins, tx, "", "Rent") {
+		t.Fatalf("the change was not labeled")
+	}
+	expected := CoinControl{"ab:1": {Label: "Rent"}, "ab:2": {Label: "mine", Frozen: true}}
+	if !reflect.DeepEqual(coins, expected) {
+		t.Fatalf("coins are %v, expected %v", coins, expected)
+	}
+
+	// The inherited label follows the label of the transaction.
+	inheritTxLabel(coins, tx, "Rent", "")
+	if coins["ab:1"].Label != "" || coins["ab:2"].Label != "mine" {
+		t.Errorf("coins are %v after the label was removed", coins)
+	}
+
+	tx.Direction = dcrlibwallet.TxDirectionReceived
+	if inheritTxLabel(coins, tx, "", "Salary") {
+		t.Errorf("the outputs of a received transaction were labeled")
+	}
+}
+
+func TestTxLabelsSearchText(t *testing.T) {
+	labels := TxLabels{
+		txLabelKey(1, "ab"):  {Label: "Rent", Note: "June"},
+		txLabelKey(2, "cd"):  {Label: "Coffee"},
+		txLabelKey(11, "ef"): {Note: "other wallet"},
+	}
+	if label := labels.Of(2, "cd"); label.Label != "Coffee" {
+		t.Errorf("the label of cd is %q", label.Label)
+	}
+
+	expected := map[string]string{"ab": "Rent\nJune"}
+	if texts := labels.searchText(1); !reflect.DeepEqual(texts, expected) {
+		t.Errorf("searched %v, expected %v", texts, expected)
+	}
+}
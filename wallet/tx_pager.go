@@ -0,0 +1,202 @@
+package wallet
+
+import (
+	"sync"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// TxPageSize is the number of transactions read at once by a TxPager.
+const TxPageSize = 100
+
+// TxPager loads the transactions of a wallet page by page as they are listed.
+// The transactions already loaded are the cursor of the next page: the wallet
+// database orders them the same way, so the next page starts at their count.
+// New transactions are merged into the loaded ones instead of reloading them.
+//
+// The methods of TxPager are safe for concurrent use, LoadMore reads the
+// database and should not be called on the UI goroutine.
+type TxPager struct {
+	fetch       func(offset, limit int32) ([]dcrlibwallet.Transaction, error)
+	matches     func(*dcrlibwallet.Transaction) bool
+	newestFirst bool
+	pageSize    int32
+
+	mu      sync.RWMutex
+	txs     []dcrlibwallet.Transaction
+	byHash  map[string]int
+	loading bool
+	done    bool
+}
+
+// NewTxPager returns a pager over the transactions of w selected by txFilter,
+// a dcrlibwallet.TxFilter* value. No transaction is loaded until LoadMore is
+// called.
+func NewTxPager(w *dcrlibwallet.Wallet, txFilter int32, newestFirst bool) *TxPager {
+	fetch := func(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+		return w.GetTransactionsRaw(offset, limit, txFilter, newestFirst)
+	}
+	matches := func(tx *dcrlibwallet.Transaction) bool {
+		return w.TxMatchesFilter(tx, txFilter)
+	}
+	return newTxPager(fetch, matches, newestFirst, TxPageSize)
+}
+
+func newTxPager(fetch func(offset, limit int32) ([]dcrlibwallet.Transaction, error), matches func(*dcrlibwallet.Transaction) bool, newestFirst bool, pageSize int32) *TxPager {
+	return &TxPager{
+		fetch:       fetch,
+		matches:     matches,
+		newestFirst: newestFirst,
+		pageSize:    pageSize,
+		byHash:      make(map[string]int),
+	}
+}
+
+// Len returns the number of transactions loaded.
+func (p *TxPager) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.txs)
+}
+
+// Tx returns the loaded transaction at index i, ok is false if i is out of
+// range. The transactions may be dropped by Merge and Confirm after Len is
+// read.
+func (p *TxPager) Tx(i int) (tx dcrlibwallet.Transaction, ok bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if i < 0 || i >= len(p.txs) {
+		return tx, false
+	}
+	return p.txs[i], true
+}
+
+// Transactions returns a copy of the transactions loaded.
+func (p *TxPager) Transactions() []dcrlibwallet.Transaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]dcrlibwallet.Transaction(nil), p.txs...)
+}
+
+// Loading returns true while a page is read.
+func (p *TxPager) Loading() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.loading
+}
+
+// Done returns true once every transaction is loaded.
+func (p *TxPager) Done() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.done
+}
+
+// LoadMore reads the next page of transactions and returns those that were
+// not loaded yet. It does nothing if a page is already being read or if every
+// transaction is loaded.
+func (p *TxPager) LoadMore() ([]dcrlibwallet.Transaction, error) {
+	p.mu.Lock()
+	if p.loading || p.done {
+		p.mu.Unlock()
+		return nil, nil
+	}
+	p.loading = true
+	offset := int32(len(p.txs))
+	p.mu.Unlock()
+
+	page, err := p.fetch(offset, p.pageSize)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.loading = false
+	if err != nil {
+		return nil, err
+	}
+
+	var added []dcrlibwallet.Transaction
+	for _, tx := range page {
+		// The transactions merged since the page was read are already
+		// loaded.
+		if i, ok := p.byHash[tx.Hash]; ok {
+			p.txs[i] = tx
+			continue
+		}
+		p.byHash[tx.Hash] = len(p.txs)
+		p.txs = append(p.txs, tx)
+		added = append(added, tx)
+	}
+	p.done = int32(len(page)) < p.pageSize
+	return added, nil
+}
+
+// before returns true if a is listed before b.
+func (p *TxPager) before(a, b *dcrlibwallet.Transaction) bool {
+	if p.newestFirst {
+		return a.Timestamp > b.Timestamp
+	}
+	return a.Timestamp < b.Timestamp
+}
+
+// Merge adds tx to the loaded transactions, or updates it if it is loaded. It
+// returns false if tx is not selected by the filter of the pager, or if it is
+// listed after the loaded transactions, in which case it will be read with
+// the next pages.
+func (p *TxPager) Merge(tx *dcrlibwallet.Transaction) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if i, ok := p.byHash[tx.Hash]; ok {
+		if !p.matches(tx) {
+			p.remove(i)
+			return true
+		}
+		p.txs[i] = *tx
+		return true
+	}
+	if !p.matches(tx) {
+		return false
+	}
+
+	i := len(p.txs)
+	for i > 0 && p.before(tx, &p.txs[i-1]) {
+		i--
+	}
+	if i == len(p.txs) && !p.done {
+		return false
+	}
+
+	p.txs = append(p.txs, dcrlibwallet.Transaction{})
+	copy(p.txs[i+1:], p.txs[i:])
+	p.txs[i] = *tx
+	for j := i; j < len(p.txs); j++ {
+		p.byHash[p.txs[j].Hash] = j
+	}
+	return true
+}
+
+// Confirm sets the height of the block the loaded transaction with hash was
+// mined in. The transaction is dropped if it is no longer selected by the
+// filter of the pager. It returns false if the transaction is not loaded.
+func (p *TxPager) Confirm(hash string, height int32) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	i, ok := p.byHash[hash]
+	if !ok {
+		return false
+	}
+	p.txs[i].BlockHeight = height
+	if !p.matches(&p.txs[i]) {
+		p.remove(i)
+	}
+	return true
+}
+
+func (p *TxPager) remove(i int) {
+	delete(p.byHash, p.txs[i].Hash)
+	p.txs = append(p.txs[:i], p.txs[i+1:]...)
+	for j := i; j < len(p.txs); j++ {
+		p.byHash[p.txs[j].Hash] = j
+	}
+}
@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/planetdecred/dcrlibwallet"
+)
+
+// testTxDB is the transactions of a wallet database, read newest first.
+type testTxDB struct {
+	txs []dcrlibwallet.Transaction
+}
+
+func (db *testTxDB) insert(tx dcrlibwallet.Transaction) {
+	db.txs = append(db.txs, tx)
+	sort.SliceStable(db.txs, func(i, j int) bool { return db.txs[i].Timestamp > db.txs[j].Timestamp })
+}
+
+func (db *testTxDB) fetch(offset, limit int32) ([]dcrlibwallet.Transaction, error) {
+	var page []dcrlibwallet.Transaction
+	for i := offset; i < offset+limit && int(i) < len(db.txs); i++ {
+		page = append(page, db.txs[i])
+	}
+	return page, nil
+}
+
+func pagerHashes(p *TxPager) []string {
+	var hashes []string
+	for _, tx := range p.Transactions() {
+		hashes = append(hashes, tx.Hash)
+	}
+	return hashes
+}
+
+func TestTxPagerLoadMore(t *testing.T) {
+	db := new(testTxDB)
+	for _, h := range []string{"a", "b", "c", "d", "e"} {
+		db.insert(dcrlibwallet.Transaction{Hash: h, Timestamp: int64(h[0])})
+	}
+	p := newTxPager(db.fetch, func(*dcrlibwallet.Transaction) bool { return true }, true, 2)
+
+	added, _ := p.LoadMore()
+	if len(added) != 2 || p.Done() {
+		t.Fatalf("loaded %d transactions, done %v", len(added), p.Done())
+	}
+
+	// A transaction stored before the next page is read shifts the page, it
+	// is merged once notified.
+	db.insert(dcrlibwallet.Transaction{Hash: "f", Timestamp: int64('f')})
+	p.LoadMore()
+	if !p.Merge(&dcrlibwallet.Transaction{Hash: "f", Timestamp: int64('f')}) {
+		t.Fatalf("the new transaction was not merged")
+	}
+	for !p.Done() {
+		p.LoadMore()
+	}
+
+	expected := []string{"f", "e", "d", "c", "b", "a"}
+	if hashes := pagerHashes(p); !reflect.DeepEqual(hashes, expected) {
+		t.Errorf("loaded %v, expected %v", hashes, expected)
+	}
+}
+
+func TestTxPagerMerge(t *testing.T) {
+	db := new(testTxDB)
+	for ts := int64(10); ts <= 50; ts += 10 {
+		db.insert(dcrlibwallet.Transaction{Hash: string(rune('a' + ts/10)), Timestamp: ts, BlockHeight: -1})
+	}
+	unmined := func(tx *dcrlibwallet.Transaction) bool { return tx.BlockHeight == -1 }
+	p := newTxPager(db.fetch, unmined, true, 3)
+	p.LoadMore()
+
+	if p.Merge(&dcrlibwallet.Transaction{Hash: "x", Timestamp: 5, BlockHeight: -1}) {
+		t.Errorf("a transaction after the loaded page was merged")
+	}
+	if p.Merge(&dcrlibwallet.Transaction{Hash: "y", Timestamp: 60, BlockHeight: 7}) {
+		t.Errorf("a transaction not matching the filter was merged")
+	}
+	if !p.Merge(&dcrlibwallet.Transaction{Hash: "z", Timestamp: 45, BlockHeight: -1}) {
+		t.Errorf("a transaction within the loaded page was not merged")
+	}
+	if !p.Confirm("d", 9) {
+		t.Errorf("a loaded transaction was not confirmed")
+	}
+
+	expected := []string{"f", "z", "e"}
+	if hashes := pagerHashes(p); !reflect.DeepEqual(hashes, expected) {
+		t.Errorf("loaded %v, expected %v", hashes, expected)
+	}
+	for i, tx := range p.Transactions() {
+		if p.byHash[tx.Hash] != i {
+			t.Errorf("%s is indexed at %d, expected %d", tx.Hash, p.byHash[tx.Hash], i)
+		}
+	}
+	if _, ok := p.Tx(len(expected)); ok {
+		t.Errorf("a transaction was returned out of range")
+	}
+}
@@ -0,0 +1,350 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	w "decred.org/dcrwallet/v2/wallet"
+	"decred.org/dcrwallet/v2/wallet/txauthor"
+	"decred.org/dcrwallet/v2/wallet/txrules"
+	"github.com/decred/dcrd/chaincfg/v3"
+	"github.com/decred/dcrd/dcrutil/v4"
+	"github.com/decred/dcrd/txscript/v4"
+	"github.com/decred/dcrd/txscript/v4/stdaddr"
+	"github.com/decred/dcrd/wire"
+	"github.com/planetdecred/dcrlibwallet"
+	"github.com/planetdecred/dcrlibwallet/txhelper"
+)
+
+// TxAuthor builds, signs and publishes a transaction from an account of a
+// wallet. Unlike dcrlibwallet.TxAuthor, which always pays the default relay
+// fee, the fee rate of the transaction can be chosen.
+type TxAuthor struct {
+	wallet        *dcrlibwallet.Wallet
+	account       uint32
+	params        *chaincfg.Params
+	feeRate       dcrutil.Amount
+	destinations  []dcrlibwallet.TransactionDestination
+	changeAddress string
+	selection     CoinSelection
+	coins         CoinControl
+	// inputKeys are the output keys of the outputs selected by the user,
+	// spent regardless of the coin selection.
+	inputKeys  []string
+	unsignedTx *txauthor.AuthoredTx
+}
+
+// NewTxAuthor returns a TxAuthor spending from account of wal at the
+// FeeRateLow fee rate, with the coin selection strategy saved for account.
+func NewTxAuthor(wal *dcrlibwallet.Wallet, account int32) (*TxAuthor, error) {
+	if wal == nil {
+		return nil, errors.New(dcrlibwallet.ErrWalletNotFound)
+	}
+	if _, err := wal.GetAccount(account); err != nil {
+		return nil, err
+	}
+
+	return &TxAuthor{
+		wallet:    wal,
+		account:   uint32(account),
+		params:    wal.Internal().ChainParams(),
+		feeRate:   FeeRateLow,
+		selection: ReadCoinSelection(wal, account),
+		coins:     ReadCoinControl(wal),
+	}, nil
+}
+
+// AddSendDestination adds an output paying atoms to address. If sendMax is
+// set, the output receives all the spendable balance left after the other
+// outputs and the fee.
+func (tx *TxAuthor) AddSendDestination(address string, atoms int64, sendMax bool) error {
+	if _, err := stdaddr.DecodeAddress(address, tx.params); err != nil {
+		return errors.New(dcrlibwallet.ErrInvalidAddress)
+	}
+	if !sendMax && (atoms <= 0 || atoms > dcrutil.MaxAmount) {
+		return errors.New("invalid amount")
+	}
+
+	tx.destinations = append(tx.destinations, dcrlibwallet.TransactionDestination{
+		Address:    address,
+		AtomAmount: atoms,
+		SendMax:    sendMax,
+	})
+	tx.unsignedTx = nil
+	return nil
+}
+
+// SetFeeRate sets the fee rate of the transaction in atoms/kB.
+func (tx *TxAuthor) SetFeeRate(rate dcrutil.Amount) error {
+	if err := ValidateFeeRate(rate); err != nil {
+		return err
+	}
+	tx.feeRate = rate
+	tx.unsignedTx = nil
+	return nil
+}
+
+// FeeRate returns the fee rate of the transaction in atoms/kB.
+func (tx *TxAuthor) FeeRate() dcrutil.Amount {
+	return tx.feeRate
+}
+
+// SetCoinSelection sets the strategy of selection of the outputs spent.
+func (tx *TxAuthor) SetCoinSelection(selection CoinSelection) {
+	tx.selection = selection
+	tx.unsignedTx = nil
+}
+
+// UseInputs spends exactly the outputs with keys, the "hash:index"
+// dcrlibwallet.UnspentOutput.OutputKey, which may be frozen. The coin
+// selection is used again if keys is empty.
+func (tx *TxAuthor) UseInputs(keys []string) {
+	tx.inputKeys = keys
+	tx.unsignedTx = nil
+}
+
+// EstimatedConfirmation returns the estimated number of blocks the transaction
+// waits before being mined at its fee rate, and the time they take.
+func (tx *TxAuthor) EstimatedConfirmation() (int, time.Duration) {
+	blocks := ConfirmationBlocks(tx.feeRate)
+	return blocks, time.Duration(blocks) * tx.params.TargetTimePerBlock
+}
+
+// EstimateFeeAndSize returns the fee, the change and the size of the
+// transaction once signed.
+func (tx *TxAuthor) EstimateFeeAndSize() (*dcrlibwallet.TxFeeAndSize, error) {
+	unsignedTx, err := tx.unsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	fee := txrules.FeeForSerializeSize(tx.feeRate, unsignedTx.EstimatedSignedSerializeSize)
+	feeAndSize := &dcrlibwallet.TxFeeAndSize{
+		EstimatedSignedSize: unsignedTx.EstimatedSignedSerializeSize,
+		Fee:                 &dcrlibwallet.Amount{AtomValue: int64(fee), DcrValue: fee.ToCoin()},
+	}
+	if unsignedTx.ChangeIndex >= 0 && !tx.sendsMax() {
+		change := dcrutil.Amount(unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex].Value)
+		feeAndSize.Change = &dcrlibwallet.Amount{AtomValue: int64(change), DcrValue: change.ToCoin()}
+	}
+	return feeAndSize, nil
+}
+
+// SendMaxAmount returns the amount paid to the destination added with
+// sendMax, that is the spendable balance of the account, or of the outputs
+// selected with UseInputs, less the other outputs and the fee.
+func (tx *TxAuthor) SendMaxAmount() (dcrutil.Amount, error) {
+	if !tx.sendsMax() {
+		return 0, errors.New("no destination receives the max amount")
+	}
+
+	unsignedTx, err := tx.unsignedTransaction()
+	if err != nil {
+		return 0, err
+	}
+	return dcrutil.Amount(unsignedTx.Tx.TxOut[unsignedTx.ChangeIndex].Value), nil
+}
+
+// sendsMax returns true if a destination receives the max amount.
+func (tx *TxAuthor) sendsMax() bool {
+	for _, destination := range tx.destinations {
+		if destination.SendMax {
+			return true
+		}
+	}
+	return false
+}
+
+// Broadcast signs the transaction with privatePassphrase and publishes it. The
+// hash of the transaction is returned. privatePassphrase is zeroed.
+func (tx *TxAuthor) Broadcast(privatePassphrase []byte) ([]byte, error) {
+	defer func() {
+		for i := range privatePassphrase {
+			privatePassphrase[i] = 0
+		}
+	}()
+
+	n, err := tx.wallet.Internal().NetworkBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	msgTx, err := tx.sign(privatePassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := tx.wallet.Internal().PublishTransaction(context.Background(), msgTx, n)
+	if err != nil {
+		return nil, err
+	}
+	// The signed transaction can't be published again.
+	tx.unsignedTx = nil
+	return hash[:], nil
+}
+
+// sign returns the transaction signed with privatePassphrase.
+func (tx *TxAuthor) sign(privatePassphrase []byte) (*wire.MsgTx, error) {
+	unsignedTx, err := tx.unsignedTransaction()
+	if err != nil {
+		return nil, err
+	}
+	if unsignedTx.ChangeIndex >= 0 {
+		unsignedTx.RandomizeChangePosition()
+	}
+	msgTx := unsignedTx.Tx
+
+	lock := make(chan time.Time, 1)
+	defer func() {
+		lock <- time.Time{}
+	}()
+
+	ctx := context.Background()
+	if err = tx.wallet.Internal().Unlock(ctx, privatePassphrase, lock); err != nil {
+		log.Error(err)
+		return nil, ErrBadPass
+	}
+
+	if _, err = tx.wallet.Internal().SignTransaction(ctx, msgTx, txscript.SigHashAll, nil, nil, nil); err != nil {
+		return nil, err
+	}
+	return msgTx, nil
+}
+
+// unsignedTransaction returns the transaction, built on the first call after
+// a change of the destinations or the fee rate.
+func (tx *TxAuthor) unsignedTransaction() (*txauthor.AuthoredTx, error) {
+	if tx.unsignedTx != nil {
+		return tx.unsignedTx, nil
+	}
+
+	ctx := context.Background()
+	outputs := make([]*wire.TxOut, 0, len(tx.destinations))
+	var algorithm w.OutputSelectionAlgorithm = w.OutputSelectionAlgorithmDefault
+	var changeSource txauthor.ChangeSource
+	for _, destination := range tx.destinations {
+		if !destination.SendMax {
+			output, err := txhelper.MakeTxOutput(destination.Address, destination.AtomAmount, tx.params)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, output)
+			continue
+		}
+
+		if changeSource != nil {
+			return nil, errors.New("cannot send max amount to multiple recipients")
+		}
+		// The max amount is what is left once the other outputs and the
+		// fee are paid, that is the change of a transaction spending all
+		// the outputs of the account.
+		source, err := txhelper.MakeTxChangeSource(destination.Address, tx.params)
+		if err != nil {
+			return nil, err
+		}
+		changeSource = source
+		algorithm = w.OutputSelectionAlgorithmAll
+	}
+
+	inputSource, err := tx.inputSource(changeSource != nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if changeSource == nil {
+		source, err := tx.changeSource(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changeSource = source
+	}
+
+	unsignedTx, err := tx.wallet.Internal().NewUnsignedTransaction(ctx, outputs, tx.feeRate, tx.account,
+		tx.wallet.RequiredConfirmations(), algorithm, changeSource, inputSource)
+	if err != nil {
+		return nil, err
+	}
+	if unsignedTx.ChangeIndex < 0 && algorithm == w.OutputSelectionAlgorithmAll {
+		// The max amount is dust that dcrwallet adds to the fee instead of
+		// paying it to the destination.
+		return nil, NewError(ErrCodeInsufficientFunds, errors.New(dcrlibwallet.ErrInsufficientBalance))
+	}
+	tx.unsignedTx = unsignedTx
+	return unsignedTx, nil
+}
+
+// inputSource returns the source of the outputs spent by the transaction, all
+// of them if sendMax is set. nil is returned to let dcrwallet select them at
+// random when no output is frozen or selected by the user.
+func (tx *TxAuthor) inputSource(sendMax bool) (txauthor.InputSource, error) {
+	if tx.selection == CoinSelectionPrivacy && int32(tx.account) != tx.wallet.MixedAccountNumber() {
+		return nil, errors.New("the privacy coin selection only spends from the mixed account")
+	}
+
+	frozen := false
+	for _, info := range tx.coins {
+		frozen = frozen || info.Frozen
+	}
+	if len(tx.inputKeys) == 0 && tx.selection == CoinSelectionRandom && !frozen {
+		return nil, nil
+	}
+
+	utxos, err := tx.wallet.UnspentOutputs(int32(tx.account))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*dcrlibwallet.UnspentOutput
+	if len(tx.inputKeys) > 0 {
+		byKey := make(map[string]*dcrlibwallet.UnspentOutput, len(utxos))
+		for _, utxo := range utxos {
+			byKey[utxo.OutputKey] = utxo
+		}
+		for _, key := range tx.inputKeys {
+			utxo, ok := byKey[key]
+			if !ok {
+				return nil, fmt.Errorf("output %s is not spendable from the account", key)
+			}
+			candidates = append(candidates, utxo)
+		}
+	} else {
+		for _, utxo := range utxos {
+			if !tx.coins.Frozen(utxo.OutputKey) {
+				candidates = append(candidates, utxo)
+			}
+		}
+	}
+
+	return func(target dcrutil.Amount) (*txauthor.InputDetail, error) {
+		if sendMax || len(tx.inputKeys) > 0 {
+			return inputDetail(candidates)
+		}
+		return inputDetail(SelectCoins(candidates, target, tx.selection))
+	}, nil
+}
+
+// changeSource returns the change source of the transaction, paying to an
+// internal address derived once. The change of the mixed account goes to the
+// unmixed account, as does all change if the mixer mixes change.
+func (tx *TxAuthor) changeSource(ctx context.Context) (txauthor.ChangeSource, error) {
+	if tx.changeAddress == "" {
+		account := tx.account
+		if int32(account) == tx.wallet.MixedAccountNumber() || tx.wallet.AccountMixerMixChange() {
+			account = uint32(tx.wallet.UnmixedAccountNumber())
+		}
+
+		address, err := tx.wallet.Internal().NewChangeAddress(ctx, account)
+		if err != nil {
+			return nil, fmt.Errorf("change address error: %v", err)
+		}
+		tx.changeAddress = address.String()
+	}
+
+	source, err := txhelper.MakeTxChangeSource(tx.changeAddress, tx.params)
+	if err != nil {
+		return nil, err
+	}
+	return source, nil
+}
@@ -0,0 +1,99 @@
+package wallet
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrutil/v4"
+)
+
+// PaymentURIScheme is the scheme of the payment URIs.
+const PaymentURIScheme = "decred"
+
+// PaymentURI is a request for a payment to an address, encoded in the BIP0021
+// style as decred:<address>?amount=<amount>&label=<label>&message=<message>.
+// Amount is 0 if no amount is requested.
+type PaymentURI struct {
+	Address string
+	Amount  dcrutil.Amount
+	Label   string
+	Message string
+}
+
+// IsPaymentURI returns true if s starts with the payment URI scheme.
+func IsPaymentURI(s string) bool {
+	s = strings.TrimSpace(s)
+	return len(s) > len(PaymentURIScheme) && strings.EqualFold(s[:len(PaymentURIScheme)+1], PaymentURIScheme+":")
+}
+
+// ParsePaymentURI parses a payment URI. The validity of the address on the
+// network of the wallets is not checked.
+func ParsePaymentURI(s string) (*PaymentURI, error) {
+	if !IsPaymentURI(s) {
+		return nil, fmt.Errorf("not a %s: URI", PaymentURIScheme)
+	}
+	s = strings.TrimSpace(s)[len(PaymentURIScheme)+1:]
+	// Some apps add slashes after the scheme like in URLs.
+	s = strings.TrimPrefix(s, "//")
+
+	address, rawQuery := s, ""
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		address, rawQuery = s[:i], s[i+1:]
+	}
+	if address == "" {
+		return nil, fmt.Errorf("payment URI has no address")
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment URI parameters: %v", err)
+	}
+
+	uri := &PaymentURI{
+		Address: address,
+		Label:   query.Get("label"),
+		Message: query.Get("message"),
+	}
+	if amount := query.Get("amount"); amount != "" {
+		dcr, err := strconv.ParseFloat(amount, 64)
+		if err != nil || dcr <= 0 {
+			return nil, fmt.Errorf("invalid payment URI amount %q", amount)
+		}
+		if uri.Amount, err = dcrutil.NewAmount(dcr); err != nil {
+			return nil, fmt.Errorf("invalid payment URI amount %q", amount)
+		}
+	}
+
+	// Required parameters that are not supported make the URI invalid.
+	for param := range query {
+		if strings.HasPrefix(param, "req-") {
+			return nil, fmt.Errorf("unsupported payment URI parameter %q", param)
+		}
+	}
+
+	return uri, nil
+}
+
+// String encodes the payment URI, the parameters that are not set are omitted.
+func (uri PaymentURI) String() string {
+	query := url.Values{}
+	if uri.Amount > 0 {
+		query.Set("amount", strconv.FormatFloat(uri.Amount.ToCoin(), 'f', -1, 64))
+	}
+	if uri.Label != "" {
+		query.Set("label", uri.Label)
+	}
+	if uri.Message != "" {
+		query.Set("message", uri.Message)
+	}
+
+	s := PaymentURIScheme + ":" + uri.Address
+	if len(query) > 0 {
+		// Spaces are encoded as %20 rather than + as some apps do not
+		// decode +.
+		s += "?" + strings.Replace(query.Encode(), "+", "%20", -1)
+	}
+	return s
+}
@@ -0,0 +1,48 @@
+package wallet
+
+import "testing"
+
+func TestPaymentURI(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected PaymentURI
+		encoded  string
+	}{
+		{"decred:DsAddr", PaymentURI{Address: "DsAddr"}, "decred:DsAddr"},
+		{"  Decred://DsAddr?amount=1.5", PaymentURI{Address: "DsAddr", Amount: 150000000}, "decred:DsAddr?amount=1.5"},
+		{
+			"decred:DsAddr?amount=0.00000001&label=Jane%20Doe&message=Invoice+42",
+			PaymentURI{"DsAddr", 1, "Jane Doe", "Invoice 42"},
+			"decred:DsAddr?amount=0.00000001&label=Jane%20Doe&message=Invoice%2042",
+		},
+		{"decred:DsAddr?foo=bar", PaymentURI{Address: "DsAddr"}, "decred:DsAddr"},
+	}
+	for _, test := range tests {
+		uri, err := ParsePaymentURI(test.uri)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.uri, err)
+			continue
+		}
+		if *uri != test.expected {
+			t.Errorf("%s: expected %+v, got %+v", test.uri, test.expected, *uri)
+		}
+		if encoded := uri.String(); encoded != test.encoded {
+			t.Errorf("%s: expected encoding %q, got %q", test.uri, test.encoded, encoded)
+		}
+	}
+
+	invalid := []string{
+		"DsAddr",
+		"bitcoin:1Addr",
+		"decred:",
+		"decred:?amount=1",
+		"decred:DsAddr?amount=abc",
+		"decred:DsAddr?amount=-1",
+		"decred:DsAddr?req-somethingnew=1",
+	}
+	for _, uri := range invalid {
+		if _, err := ParsePaymentURI(uri); err == nil {
+			t.Errorf("expected an error for %q", uri)
+		}
+	}
+}
@@ -3,9 +3,10 @@
 package wallet
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/planetdecred/dcrlibwallet"
@@ -25,6 +26,22 @@ type Wallet struct {
 	version     string
 	logFile     string
 	startUpTime time.Time
+	// ctx is canceled by Shutdown, it bounds the work the wallet runs in the
+	// background for the whole app, such as indexing the transactions.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	exchangeRates *ExchangeRates
+	priceHistory  *PriceHistory
+	txIndex       *TxIndex
+	txLabels      txLabelCache
+	// queueMtx serializes the changes of the broadcast queue, which is read
+	// and saved as a whole.
+	queueMtx sync.Mutex
+	// requestsMtx serializes the changes of the payment requests, which are
+	// also read and saved as a whole. requestsRev counts the changes.
+	requestsMtx sync.Mutex
+	requestsRev int
 }
 
 // NewWallet initializies an new Wallet instance.
@@ -34,6 +51,12 @@ func NewWallet(root, net, version, logFile string, buildDate time.Time) (*Wallet
 		return nil, fmt.Errorf(`root directory or network cannot be ""`)
 	}
 
+	priceHistory, err := NewPriceHistory(filepath.Join(root, PriceHistoryFile))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	wal := &Wallet{
 		Root:        root,
 		Net:         net,
@@ -41,6 +64,12 @@ func NewWallet(root, net, version, logFile string, buildDate time.Time) (*Wallet
 		version:     version,
 		logFile:     logFile,
 		startUpTime: time.Now(),
+		ctx:         ctx,
+		cancel:      cancel,
+
+		exchangeRates: NewExchangeRates(DefaultExchangeRateProviders()...),
+		priceHistory:  priceHistory,
+		txIndex:       NewTxIndex(),
 	}
 
 	return wal, nil
@@ -76,19 +105,11 @@ func (wal *Wallet) InitMultiWallet() error {
 	return nil
 }
 
-func (wal *Wallet) hdPrefix() string {
-	switch wal.Net {
-	case dcrlibwallet.Testnet3:
-		return dcrlibwallet.TestnetHDPath
-	case "mainnet":
-		return dcrlibwallet.MainnetHDPath
-	default:
-		return ""
-	}
-}
-
 // Shutdown shutsdown the multiwallet
 func (wal *Wallet) Shutdown() {
+	if wal.cancel != nil {
+		wal.cancel()
+	}
 	if wal.multi != nil {
 		wal.multi.Shutdown()
 	}
@@ -97,26 +118,15 @@ func (wal *Wallet) Shutdown() {
 // GetBlockExplorerURL accept transaction hash,
 // return the block explorer URL with respect to the network
 func (wal *Wallet) GetBlockExplorerURL(txnHash string) string {
-	switch wal.Net {
-	case dcrlibwallet.Testnet3:
-		return "https://testnet.dcrdata.org/tx/" + txnHash
-	case "mainnet":
-		return "https://explorer.dcrdata.org/tx/" + txnHash
-	default:
-		return ""
-	}
+	return wal.ExplorerTemplates().TxURL(txnHash)
 }
 
-//GetUSDExchangeValues gets the exchange rate of DCR - USDT from a specified endpoint
-func (wal *Wallet) GetUSDExchangeValues(target interface{}) error {
-	url := "https://api.bittrex.com/v3/markets/DCR-USDT/ticker"
-	resp, err := http.Get(url)
-	if err != nil {
-		return err
-	}
-
-	defer resp.Body.Close()
+// ExchangeRates returns the exchange rate providers available to the app.
+func (wal *Wallet) ExchangeRates() *ExchangeRates {
+	return wal.exchangeRates
+}
 
-	json.NewDecoder(resp.Body).Decode(target)
-	return nil
+// PriceHistory returns the store of past exchange rates.
+func (wal *Wallet) PriceHistory() *PriceHistory {
+	return wal.priceHistory
 }